@@ -0,0 +1,76 @@
+package xvalid
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// AuditEntry describes a failed validation
+type AuditEntry struct {
+	// Struct is the type of the subject, e.g. "main.User"
+	Struct string `json:"struct"`
+	// Field path of the error, empty for struct validators
+	Field []string `json:"field"`
+	// Rule name of the validator
+	Rule string `json:"rule"`
+	// Value is a redacted description of the value, e.g. "string(12)", that never holds the value itself
+	Value string `json:"value"`
+}
+
+// AuditSink records failed validations, e.g. to find out which rules users fail most. It is called for every error,
+// so it should be fast or hand the entry off.
+type AuditSink interface {
+	Record(ctx context.Context, entry AuditEntry)
+}
+
+// AuditFunc is a function that implements AuditSink
+type AuditFunc func(ctx context.Context, entry AuditEntry)
+
+// Record calls the function
+func (f AuditFunc) Record(ctx context.Context, entry AuditEntry) {
+	f(ctx, entry)
+}
+
+// Audit records every failed validation in the sink. Values are redacted to their type and size, so sensitive values
+// don't end up in logs.
+func (r Rules) Audit(sink AuditSink) Rules {
+	r.audit = sink
+	return r
+}
+
+// record the errors of a validator in the audit sink
+func (r Rules) record(ctx context.Context, subject any, vmap map[string]any, errs ErrorSlice) {
+	structType := fmt.Sprintf("%T", subject)
+	for _, err := range errs {
+		entry := AuditEntry{Struct: structType, Field: err.Field()}
+		if e, ok := err.(interface{ Rule() string }); ok {
+			entry.Rule = e.Rule()
+		}
+		if len(entry.Field) == 0 {
+			entry.Value = redactValue(subject)
+		} else if v, ok := fieldValue(vmap, entry.Field); ok {
+			entry.Value = redactValue(v)
+		}
+		r.audit.Record(ctx, entry)
+	}
+}
+
+// redactValue describes a value by its type, and its length if it has one
+func redactValue(v any) string {
+	if v == nil {
+		return "nil"
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Sprintf("%T(nil)", v)
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s(%d)", rv.Type(), rv.Len())
+	}
+	return rv.Type().String()
+}
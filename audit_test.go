@@ -0,0 +1,41 @@
+package xvalid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	type login struct {
+		Email    string   `json:"email"`
+		Password string   `json:"password"`
+		Age      *int     `json:"age"`
+		Tags     []string `json:"tags"`
+	}
+	l := login{}
+	entries := make([]AuditEntry, 0)
+	rules := New(&l).
+		Field(&l.Email, Email()).
+		Field(&l.Password, MinLength(8)).
+		Field(&l.Age, Min(18)).
+		Field(&l.Tags, MaxItems(1)).
+		Struct(StructFunc(func(any) Error { return NewError("Invalid login") })).
+		Audit(AuditFunc(func(ctx context.Context, entry AuditEntry) {
+			entries = append(entries, entry)
+		}))
+	age := 10
+	errs := rules.Validate(login{Email: "not an email", Password: "secret", Age: &age, Tags: []string{"a", "b"}})
+	assert.Len(t, errs, 5, "Errors")
+	assert.Equal(t, []AuditEntry{
+		{"xvalid.login", []string{"email"}, "email", "string(12)"},
+		{"xvalid.login", []string{"password"}, "minLength", "string(6)"},
+		{"xvalid.login", []string{"age"}, "min", "int"},
+		{"xvalid.login", []string{"tags"}, "maxItems", "[]string(2)"},
+		{"xvalid.login", nil, "structFunc", "xvalid.login"},
+	}, entries, "Entries")
+	for _, e := range entries {
+		assert.NotContains(t, e.Value, "secret", "Value redacted")
+	}
+}
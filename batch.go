@@ -0,0 +1,107 @@
+package xvalid
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// ValidateAll validates many subjects concurrently and returns the result of each subject at the same index. The
+// result is nil if the subject is valid. Validators must be safe for concurrent use, which all built-in validators are.
+// The distinct values of each Remote validator of the rules are checked in one batch first if its checker is a
+// RemoteBatchChecker, instead of one call per subject. Values of a batch that fails are checked one at a time.
+func ValidateAll[T any](rules Rules, subjects []T) []error {
+	ctx := batchRemote(rules, subjects)
+	results := make([]error, len(subjects))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(subjects) {
+		workers = len(subjects)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = rules.ValidateCtx(ctx, subjects[i])
+			}
+		}()
+	}
+	for i := range subjects {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// batchRemote checks the values of the remote validators that have a RemoteBatchChecker with one call per validator,
+// and returns a context holding the results for the validation of each subject
+func batchRemote[T any](rules Rules, subjects []T) context.Context {
+	ctx := context.Background()
+	batch := make(remoteBatch)
+	var vmaps []map[string]any
+	for _, v := range rules.validators {
+		c, ok := v.(*RemoteValidator)
+		if !ok || len(c.field) == 0 {
+			continue
+		}
+		checker, ok := c.remoteChecker().(RemoteBatchChecker)
+		if !ok {
+			continue
+		}
+		if vmaps == nil {
+			vmaps = make([]map[string]any, len(subjects))
+			for i, subject := range subjects {
+				vmaps[i] = rules.toMap(subject)
+			}
+		}
+		values := make([]any, 0)
+		seen := make(map[string]bool)
+		for _, vmap := range vmaps {
+			value, ok := fieldValue(vmap, c.field)
+			if !ok || value == nil || value == "" || seen[cacheKey(value)] {
+				continue
+			}
+			if _, cached := c.cache.get(value); cached {
+				continue
+			}
+			seen[cacheKey(value)] = true
+			values = append(values, value)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if results := c.checkBatch(ctx, checker, values); results != nil {
+			batch[c] = results
+		}
+	}
+	if len(batch) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteBatchKey{}, batch)
+}
+
+// ValidateSlice validates many subjects and combines the errors into one ErrorSlice. The index of the subject is
+// added to the field of each error after the given field, e.g. ["items", "3", "name"], which is encoded as
+// "items[3].name" in JSON.
+func ValidateSlice[T any](rules Rules, subjects []T, field ...string) error {
+	errs := make(ErrorSlice, 0)
+	for i, err := range ValidateAll(rules, subjects) {
+		if err == nil {
+			continue
+		}
+		parent := append(append(make([]string, 0, len(field)+1), field...), strconv.Itoa(i))
+		inner, ok := err.(ErrorSlice)
+		if !ok {
+			inner = ErrorSlice{NewError(err.Error())}
+		}
+		errs = append(errs, nestErrors(parent, inner)...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
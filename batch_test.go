@@ -0,0 +1,114 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAll(t *testing.T) {
+	type record struct {
+		Name string `json:"name"`
+	}
+	r := record{}
+	rules := New(&r).Field(&r.Name, Required())
+	subjects := make([]record, 1000)
+	for i := range subjects {
+		if i%3 != 0 {
+			subjects[i].Name = fmt.Sprint(i)
+		}
+	}
+	results := ValidateAll(rules, subjects)
+	assert.Len(t, results, len(subjects), "Result per subject")
+	for i, err := range results {
+		if i%3 == 0 {
+			assert.Equal(t, []string{"name"}, err.(ErrorSlice)[0].Field(), "Invalid subject %d", i)
+		} else {
+			assert.Nil(t, err, "Valid subject %d", i)
+		}
+	}
+	assert.Empty(t, ValidateAll(rules, []record{}), "No subjects")
+}
+
+// batchChecker accepts even numbers and counts the calls, failing batches if broken is set
+type batchChecker struct {
+	mu      sync.Mutex
+	checks  int
+	batches [][]any
+	broken  bool
+}
+
+func (b *batchChecker) Check(_ context.Context, value any) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.checks++
+	return value.(int)%2 == 0, nil
+}
+
+func (b *batchChecker) CheckBatch(_ context.Context, values []any) ([]bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.batches = append(b.batches, values)
+	if b.broken {
+		return nil, errors.New("batch failed")
+	}
+	valid := make([]bool, len(values))
+	for i, v := range values {
+		valid[i] = v.(int)%2 == 0
+	}
+	return valid, nil
+}
+
+func TestValidateAllBatch(t *testing.T) {
+	type record struct {
+		Code int `json:"code"`
+	}
+	r := record{}
+	subjects := make([]record, 100)
+	for i := range subjects {
+		subjects[i].Code = i % 10
+	}
+	checker := &batchChecker{}
+	rules := New(&r).Field(&r.Code, Remote("", RemoteOptions{Checker: checker}))
+	results := ValidateAll(rules, subjects)
+	assert.Len(t, checker.batches, 1, "One batch")
+	assert.Len(t, checker.batches[0], 10, "Distinct values")
+	assert.Equal(t, 0, checker.checks, "No single checks")
+	assert.Nil(t, results[2], "Accepted in batch")
+	assert.Len(t, results[3], 1, "Rejected in batch")
+
+	checker = &batchChecker{broken: true}
+	rules = New(&r).Field(&r.Code, Remote("", RemoteOptions{Checker: checker}))
+	results = ValidateAll(rules, subjects[:10])
+	assert.Len(t, checker.batches, 1, "Batch tried")
+	assert.Equal(t, 10, checker.checks, "Checked one at a time after the batch failed")
+	assert.Len(t, results[3], 1, "Rejected on its own")
+}
+
+func TestValidateSlice(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+		Qty  int    `json:"qty"`
+	}
+	i := item{}
+	rules := New(&i).Field(&i.Name, Required()).Field(&i.Qty, Min(1))
+	assert.Nil(t, ValidateSlice(rules, []item{{"a", 1}}, "items"), "All valid")
+
+	errs := ValidateSlice(rules, []item{{"a", 1}, {"b", 0}, {"", 2}}, "items").(ErrorSlice)
+	assert.Len(t, errs, 2, "Errors of all subjects")
+	assert.Equal(t, []string{"items", "1", "qty"}, errs[0].Field(), "Indexed field")
+	assert.Equal(t, "min", errs[0].(interface{ Rule() string }).Rule(), "Rule kept")
+	assert.Equal(t, []string{"items", "2", "name"}, errs[1].Field(), "Indexed field")
+	j, _ := json.Marshal(errs[1])
+	assert.JSONEq(t, `{"message":"Please enter the name","field":"items[2].name"}`, string(j), "Indexed JSON path")
+	assert.Contains(t, errs.ToMap(), "items[1].qty", "Indexed map key")
+
+	errs = ValidateSlice(rules, []item{{"", 1}}).(ErrorSlice)
+	assert.Equal(t, []string{"0", "name"}, errs[0].Field(), "Without parent field")
+	assert.Contains(t, errs.ToMap(), "[0].name", "Index without parent field")
+}
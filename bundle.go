@@ -0,0 +1,113 @@
+package xvalid
+
+import (
+	"context"
+	"reflect"
+)
+
+// BundleValidator groups validators so a common concept such as a password can be defined once and added to many
+// fields. Each field gets its own copy of the validators.
+type BundleValidator struct {
+	field      []string
+	validators []Validator
+}
+
+// Field of the field
+func (c *BundleValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *BundleValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.validators {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators in the bundle
+func (c *BundleValidator) SetMessage(msg string) Validator {
+	for _, v := range c.validators {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *BundleValidator) SetLabel(label string) Validator {
+	for _, v := range c.validators {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Validate the value and return the first error
+func (c *BundleValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value with the context and returns the first error
+func (c *BundleValidator) ValidateCtx(ctx context.Context, value any) Error {
+	for _, v := range c.validators {
+		if err := validateValue(ctx, v, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *BundleValidator) CanExport() bool {
+	for _, v := range c.validators {
+		if !v.CanExport() {
+			return false
+		}
+	}
+	return true
+}
+
+// Params of this validator
+func (c *BundleValidator) Params() map[string]any {
+	return map[string]any{"validators": c.validators}
+}
+
+// Validators in this bundle
+func (c *BundleValidator) Validators() []Validator {
+	return c.validators
+}
+
+// Bundle groups validators so they can be reused across fields and structs
+func Bundle(validators ...Validator) *BundleValidator {
+	return &BundleValidator{
+		validators: validators,
+	}
+}
+
+// expandBundles replaces bundles with copies of their validators
+func expandBundles(validators []Validator) []Validator {
+	list := make([]Validator, 0, len(validators))
+	for _, v := range validators {
+		if b, ok := v.(*BundleValidator); ok {
+			for _, bv := range expandBundles(b.validators) {
+				list = append(list, cloneValidator(bv))
+			}
+		} else {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// cloneValidator makes a shallow copy of the validator so it can be bound to another field
+func cloneValidator(validator Validator) Validator {
+	if c, ok := validator.(interface{ clone() Validator }); ok {
+		return c.clone()
+	}
+	v := reflect.ValueOf(validator)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return validator
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(Validator)
+}
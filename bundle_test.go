@@ -0,0 +1,37 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundle(t *testing.T) {
+	password := Bundle(Required(), MinLength(8), Pattern(`\d`))
+	type signup struct {
+		Password string `json:"password"`
+		Confirm  string `json:"confirm"`
+	}
+	type reset struct {
+		NewPassword string `json:"newPassword"`
+	}
+	s := signup{}
+	rules := New(&s).Field(&s.Password, password).Field(&s.Confirm, password)
+	r := reset{}
+	resetRules := New(&r).Field(&r.NewPassword, password)
+
+	errs := rules.Validate(signup{Password: "short", Confirm: "longenough1"}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Bundle on each field")
+	assert.Equal(t, []string{"password"}, errs[0].Field(), "First field")
+	assert.Equal(t, []string{"password"}, errs[1].Field(), "Still first field")
+	errs = rules.Validate(signup{Password: "longenough1"}).(ErrorSlice)
+	assert.Equal(t, []string{"confirm"}, errs[0].Field(), "Second field")
+	assert.Nil(t, resetRules.Validate(reset{NewPassword: "longenough1"}), "Bundle across structs")
+	assert.Len(t, resetRules.Validate(reset{}), 3, "All validators in bundle")
+
+	j, _ := json.Marshal(resetRules)
+	assert.Equal(t,
+		`{"newPassword":[{"rule":"required"},{"rule":"minLength","min":8},{"rule":"pattern","pattern":"\\d"}]}`,
+		string(j), "Export bundled rules")
+}
@@ -0,0 +1,181 @@
+package xvalid
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// defaultCacheSize is the most results a cache keeps unless another size is set
+const defaultCacheSize = 10000
+
+// cacheEntry is a cached result with its expiry time
+type cacheEntry[T any] struct {
+	key     string
+	result  T
+	expires time.Time
+}
+
+// valueCache stores results by value until they expire. Every entry lives for the same ttl, so the order they were
+// added in is also the order they expire in. The oldest entries are dropped once the cache is full.
+type valueCache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func newValueCache[T any](ttl time.Duration, size int) *valueCache[T] {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+	return &valueCache[T]{ttl: ttl, size: size, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// cacheKey of a value, including its type so 1 and "1" are different. Strings are quoted so []string{"a b"} and
+// []string{"a", "b"} are different. Pointers are followed so the key is the value rather than the address.
+func cacheKey(value any) string {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.IsValid() && rv.CanInterface() {
+		value = rv.Interface()
+	}
+	return fmt.Sprintf("%T:%#v", value, value)
+}
+
+// get the result of a value if it hasn't expired
+func (c *valueCache[T]) get(value any) (T, bool) {
+	var zero T
+	if c.ttl <= 0 {
+		return zero, false
+	}
+	key := cacheKey(value)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	e := el.Value.(cacheEntry[T])
+	if time.Now().After(e.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return zero, false
+	}
+	return e.result, true
+}
+
+// set the result of a value, dropping expired entries and the oldest ones over the size
+func (c *valueCache[T]) set(value any, result T) {
+	if c.ttl <= 0 {
+		return
+	}
+	key := cacheKey(value)
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+	}
+	c.entries[key] = c.order.PushBack(cacheEntry[T]{key, result, now.Add(c.ttl)})
+	for el := c.order.Front(); el != nil; el = c.order.Front() {
+		e := el.Value.(cacheEntry[T])
+		if c.order.Len() <= c.size && !now.After(e.expires) {
+			break
+		}
+		c.order.Remove(el)
+		delete(c.entries, e.key)
+	}
+}
+
+// CachedValidator remembers the result of a validator by value
+type CachedValidator struct {
+	validator Validator
+	cache     *valueCache[Error]
+}
+
+// Field of the field
+func (c *CachedValidator) Field() []string {
+	return c.validator.Field()
+}
+
+// SetField of the field
+func (c *CachedValidator) SetField(name ...string) {
+	c.validator.SetField(name...)
+}
+
+// SetMessage set error message
+func (c *CachedValidator) SetMessage(msg string) Validator {
+	c.validator.SetMessage(msg)
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *CachedValidator) SetLabel(label string) Validator {
+	setLabel(c.validator, label)
+	return c
+}
+
+// Validate the value, or return the cached result. Internal errors aren't cached so they can be retried.
+func (c *CachedValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value with the context if the wrapped validator supports it, or returns the cached result
+func (c *CachedValidator) ValidateCtx(ctx context.Context, value any) Error {
+	if err, ok := c.cache.get(value); ok {
+		return err
+	}
+	err := validateValue(ctx, c.validator, value)
+	if err != nil {
+		setRule(ErrorSlice{err}, ruleName(c.validator))
+	}
+	if _, internal := err.(*internalError); !internal {
+		c.cache.set(value, err)
+	}
+	return err
+}
+
+// CanExport for this validator
+func (c *CachedValidator) CanExport() bool {
+	return c.validator.CanExport()
+}
+
+// Params of the wrapped validator
+func (c *CachedValidator) Params() map[string]any {
+	return Params(c.validator)
+}
+
+// MarshalJSON exports the wrapped validator
+func (c *CachedValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.validator)
+}
+
+func (c *CachedValidator) clone() Validator {
+	return &CachedValidator{
+		validator: cloneValidator(c.validator),
+		cache:     newValueCache[Error](c.cache.ttl, c.cache.size),
+	}
+}
+
+// Size sets the most results kept, 10000 by default. The oldest results are dropped first.
+func (c *CachedValidator) Size(size int) *CachedValidator {
+	c.cache = newValueCache[Error](c.cache.ttl, size)
+	return c
+}
+
+// Cached remembers the result of an expensive validator by value for the ttl, e.g. a DNS or database lookup. Up to
+// 10000 results are kept unless another size is set.
+func Cached(validator Validator, ttl time.Duration) *CachedValidator {
+	return &CachedValidator{
+		validator: validator,
+		cache:     newValueCache[Error](ttl, 0),
+	}
+}
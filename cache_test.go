@@ -0,0 +1,67 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCached(t *testing.T) {
+	type domain struct {
+		Host string `json:"host"`
+	}
+	calls := 0
+	lookup := FieldFunc(func(field []string, value any) Error {
+		calls++
+		if value == "example.com" {
+			return nil
+		}
+		return NewError("Unknown host", field...)
+	})
+	d := domain{}
+	rules := New(&d).Field(&d.Host, Cached(lookup, time.Minute))
+	assert.Nil(t, rules.Validate(domain{Host: "example.com"}), "Valid")
+	assert.Nil(t, rules.Validate(domain{Host: "example.com"}), "Cached valid")
+	errs := rules.Validate(domain{Host: "nowhere"}).(ErrorSlice)
+	assert.Equal(t, "Unknown host", errs[0].Error(), "Invalid")
+	assert.Equal(t, "fieldFunc", errs[0].(interface{ Rule() string }).Rule(), "Rule of wrapped validator")
+	errs = rules.Validate(domain{Host: "nowhere"}).(ErrorSlice)
+	assert.Equal(t, "Unknown host", errs[0].Error(), "Cached invalid")
+	assert.Equal(t, 2, calls, "Validator run once per value")
+
+	calls = 0
+	rules = New(&d).Field(&d.Host, Cached(lookup, time.Nanosecond))
+	rules.Validate(domain{Host: "example.com"})
+	time.Sleep(time.Millisecond)
+	rules.Validate(domain{Host: "example.com"})
+	assert.Equal(t, 2, calls, "Expired results run again")
+
+	calls = 0
+	cached := Cached(FieldFunc(func(field []string, value any) Error {
+		return lookup.Validate(*value.(*string))
+	}), time.Minute)
+	first, second := "example.com", "nowhere"
+	assert.Nil(t, cached.Validate(&first), "Pointer")
+	first = "nowhere"
+	assert.NotNil(t, cached.Validate(&first), "Pointer keyed by value")
+	assert.NotNil(t, cached.Validate(&second), "Same value at another address")
+	assert.Equal(t, 2, calls, "Pointers cached by value")
+
+	calls = 0
+	cached = Cached(lookup, time.Minute).Size(2)
+	for _, host := range []string{"a", "b", "c", "a"} {
+		cached.Validate(host)
+	}
+	assert.Equal(t, 4, calls, "Oldest result dropped")
+	assert.Equal(t, 2, cached.cache.order.Len(), "Size")
+
+	type pair struct{ A, B string }
+	assert.NotEqual(t, cacheKey([]string{"a b"}), cacheKey([]string{"a", "b"}), "Slice elements with spaces")
+	assert.NotEqual(t, cacheKey(pair{"a b", ""}), cacheKey(pair{"a", "b "}), "Struct fields with spaces")
+	assert.NotEqual(t, cacheKey(1), cacheKey("1"), "Type")
+
+	j, _ := json.Marshal(New(&d).Field(&d.Host, Cached(MaxLength(5), time.Minute)))
+	assert.Equal(t, `{"host":[{"rule":"maxLength","max":5}]}`, string(j), "Export wrapped validator")
+}
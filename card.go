@@ -0,0 +1,111 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var cardExpiryRegex = regexp.MustCompile(`^(0[1-9]|1[0-2]) ?/ ?([0-9]{2}|[0-9]{4})$`)
+
+// CardExpiryValidator field must be a card expiry date that hasn't passed
+type CardExpiryValidator struct {
+	field    []string
+	message  string
+	label    string
+	optional bool
+	now      func() time.Time
+}
+
+// Field of the field
+func (c *CardExpiryValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *CardExpiryValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *CardExpiryValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *CardExpiryValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *CardExpiryValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Clock replaces time.Now, e.g. to fix the date in tests
+func (c *CardExpiryValidator) Clock(now func() time.Time) *CardExpiryValidator {
+	c.now = now
+	return c
+}
+
+// Validate the value
+func (c *CardExpiryValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please enter %s as MM/YY", fieldLabel(c.field, c.label)))
+	}
+	if c.optional && str == "" {
+		return nil
+	}
+	m := cardExpiryRegex.FindStringSubmatch(str)
+	if m == nil {
+		return createError(c.field, c.message, fmt.Sprintf("Please enter %s as MM/YY", fieldLabel(c.field, c.label)))
+	}
+	month, _ := strconv.Atoi(m[1])
+	year, _ := strconv.Atoi(m[2])
+	if len(m[2]) == 2 {
+		year += 2000
+	}
+	// cards are valid until the end of the month
+	now := c.now()
+	if year < now.Year() || year == now.Year() && month < int(now.Month()) {
+		return createError(c.field, c.message, fmt.Sprintf("Please use a card that hasn't expired for %s", fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *CardExpiryValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *CardExpiryValidator) Params() map[string]any {
+	return map[string]any{"optional": c.optional}
+}
+
+// MarshalJSON for this validator
+func (c *CardExpiryValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule     string `json:"rule"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+	}{"cardExpiry", c.message, c.label, c.optional})
+}
+
+// CardExpiry field must be a card expiry date as MM/YY or MM/YYYY in the current month or later
+func CardExpiry() *CardExpiryValidator {
+	return &CardExpiryValidator{
+		optional: config.OptionalByDefault,
+		now:      time.Now,
+	}
+}
@@ -0,0 +1,31 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardExpiry(t *testing.T) {
+	type payment struct {
+		Expiry string `json:"expiry"`
+	}
+	p := payment{}
+	now := func() time.Time { return time.Date(2025, time.June, 30, 23, 0, 0, 0, time.UTC) }
+	rules := New(&p).Field(&p.Expiry, CardExpiry().Clock(now))
+	assert.Nil(t, rules.Validate(payment{Expiry: "06/25"}), "Current month")
+	assert.Nil(t, rules.Validate(payment{Expiry: "01/2030"}), "Four digit year")
+	assert.Nil(t, rules.Validate(payment{Expiry: "12 / 26"}), "Spaces around the slash")
+	errs := rules.Validate(payment{Expiry: "05/25"}).(ErrorSlice)
+	assert.Equal(t, "Please use a card that hasn't expired for expiry", errs[0].Error(), "Expired")
+	errs = rules.Validate(payment{Expiry: "13/25"}).(ErrorSlice)
+	assert.Equal(t, "Please enter expiry as MM/YY", errs[0].Error(), "Invalid month")
+	assert.Len(t, rules.Validate(payment{Expiry: "6/25"}), 1, "Single digit month")
+	assert.Len(t, rules.Validate(payment{Expiry: ""}), 1, "Empty")
+	assert.Nil(t, New(&p).Field(&p.Expiry, CardExpiry().SetOptional()).Validate(payment{}), "Optional")
+
+	j, _ := json.Marshal(CardExpiry())
+	assert.Equal(t, `{"rule":"cardExpiry"}`, string(j), "Export")
+}
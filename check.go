@@ -0,0 +1,218 @@
+package xvalid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrUnresolvedField is matched by Check errors for fields that don't exist on the struct
+	ErrUnresolvedField = errors.New("unresolved field")
+	// ErrContradiction is matched by Check errors for rules that can never pass together
+	ErrContradiction = errors.New("contradicting rules")
+	// ErrNotExportable is matched by Check errors for rules that can't be exported
+	ErrNotExportable = errors.New("not exportable")
+	// ErrConflict is matched by Check and Rules.Merge errors for fields that have the same kind of limit more than once
+	// with different values, e.g. two MaxLength validators
+	ErrConflict = errors.New("conflicting rules")
+	// ErrNotRegistered is matched by Check errors for rules that need a function that isn't registered, e.g. a
+	// ContentType without a sniffer
+	ErrNotRegistered = errors.New("not registered")
+)
+
+// setupChecker is implemented by validators that can't run until something is registered
+type setupChecker interface {
+	checkSetup() error
+}
+
+// checkError is returned by Check
+type checkError struct {
+	validationError
+	kind error
+}
+
+// Unwrap to the kind of problem
+func (e checkError) Unwrap() error {
+	return e.kind
+}
+
+// newCheckError adds an error of the kind of problem
+func newCheckError(errs *ErrorSlice, kind error, field []string, format string, args ...any) {
+	*errs = append(*errs, &checkError{validationError{message: fmt.Sprintf(format, args...), field: field}, kind})
+}
+
+// Check the rules for mistakes: fields that no longer exist on the struct, contradicting rules such as a Min that is
+// larger than the Max, conflicting rules such as two different MaxLength, rules that can't be exported, and rules that
+// need a function that isn't registered, such as a Remote without a checker. Use errors.Is on each error to tell them
+// apart. Meant to be run in tests or on startup.
+func (r Rules) Check() error {
+	errs := make(ErrorSlice, 0)
+	structType := reflect.TypeOf(r.structPtr)
+	for structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	for _, v := range r.validators {
+		field := v.Field()
+		name := ruleName(v)
+		if len(field) > 0 && structType != nil && !typeHasField(structType, field) {
+			newCheckError(&errs, ErrUnresolvedField, field, "%s: field %s can't be found on %v", name, strings.Join(field, "."), structType)
+		}
+		if !v.CanExport() {
+			newCheckError(&errs, ErrNotExportable, field, "%s: rule for %s can't be exported", name, dataName(field))
+		}
+		if sc, ok := v.(setupChecker); ok {
+			if err := sc.checkSetup(); err != nil {
+				newCheckError(&errs, ErrNotRegistered, field, "%s: %v", name, err)
+			}
+		}
+	}
+	errs = append(errs, r.conflicts()...)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Merge adds the validators and labels of the other rules, e.g. of a shared base and a feature, and reports limits of
+// a field that conflict or contradict each other instead of running both. The merged rules are returned along with
+// the errors, which match ErrConflict or ErrContradiction, so the caller can decide whether to use them.
+func (r Rules) Merge(others ...Rules) (Rules, error) {
+	validators := append(make([]Validator, 0, len(r.validators)), r.validators...)
+	labels := make(map[string]string, len(r.labels))
+	for k, v := range r.labels {
+		labels[k] = v
+	}
+	for _, o := range others {
+		validators = append(validators, o.validators...)
+		for k, v := range o.labels {
+			labels[k] = v
+		}
+		for v, roles := range o.roles {
+			r.setRoles(v, roles)
+		}
+	}
+	r.validators, r.labels = validators, labels
+	if errs := r.conflicts(); len(errs) > 0 {
+		return r, errs
+	}
+	return r, nil
+}
+
+// conflicts returns the limits of a field that are set more than once with different values, or that leave no valid
+// value
+func (r Rules) conflicts() ErrorSlice {
+	errs := make(ErrorSlice, 0)
+	type bounds struct {
+		min, max  int64
+		hasMin    bool
+		hasMax    bool
+		exclusive bool
+	}
+	limits := make(map[string]map[string]*bounds)
+	limit := func(field []string, kind string) *bounds {
+		name := strings.Join(field, ".")
+		if limits[name] == nil {
+			limits[name] = make(map[string]*bounds)
+		}
+		if limits[name][kind] == nil {
+			limits[name][kind] = &bounds{}
+		}
+		return limits[name][kind]
+	}
+	setMin := func(field []string, kind string, min int64, exclusive bool) {
+		b := limit(field, kind)
+		if b.hasMin && b.min != min {
+			newCheckError(&errs, ErrConflict, field, "minimum %s of %s is set to both %d and %d", kind, strings.Join(field, "."), b.min, min)
+		}
+		b.min, b.hasMin = min, true
+		b.exclusive = b.exclusive || exclusive
+	}
+	setMax := func(field []string, kind string, max int64, exclusive bool) {
+		b := limit(field, kind)
+		if b.hasMax && b.max != max {
+			newCheckError(&errs, ErrConflict, field, "maximum %s of %s is set to both %d and %d", kind, strings.Join(field, "."), b.max, max)
+		}
+		b.max, b.hasMax = max, true
+		b.exclusive = b.exclusive || exclusive
+	}
+	for _, v := range r.validators {
+		field := v.Field()
+		switch c := v.(type) {
+		case *MinValidator:
+			setMin(field, "value", c.min, c.exclusive)
+		case *MaxValidator:
+			setMax(field, "value", c.max, c.exclusive)
+		case *MinLengthValidator:
+			setMin(field, "length", c.min, false)
+		case *MaxLengthValidator:
+			setMax(field, "length", c.max, false)
+		case *MinBytesValidator:
+			setMin(field, "bytes", c.min, false)
+		case *MaxBytesValidator:
+			setMax(field, "bytes", c.max, false)
+		case *LengthValidator:
+			setMin(field, "length", c.min, false)
+			setMax(field, "length", c.max, false)
+		case *RangeValidator:
+			// fractional bounds of RangeFloat can't be compared with the integer limits of Min and Max
+			if c.isInt {
+				setMin(field, "value", c.intMin, c.exclusive)
+				setMax(field, "value", c.intMax, c.exclusive)
+			} else if c.min == math.Trunc(c.min) && c.max == math.Trunc(c.max) {
+				setMin(field, "value", int64(c.min), c.exclusive)
+				setMax(field, "value", int64(c.max), c.exclusive)
+			}
+		}
+	}
+	for name, kinds := range limits {
+		for kind, b := range kinds {
+			if b.hasMin && b.hasMax && (b.min > b.max || b.exclusive && b.min == b.max) {
+				newCheckError(&errs, ErrContradiction, strings.Split(name, "."), "minimum %s %d of %s is more than the maximum %d", kind, b.min, name, b.max)
+			}
+		}
+	}
+	// sorted by field and message so the errors don't change between runs
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := strings.Join(errs[i].Field(), "."), strings.Join(errs[j].Field(), ".")
+		if a != b {
+			return a < b
+		}
+		return errs[i].Error() < errs[j].Error()
+	})
+	return errs
+}
+
+// typeHasField returns true if the field path can be found on the struct type
+func typeHasField(t reflect.Type, field []string) bool {
+	for i, p := range field {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+		found := false
+		for j := 0; j < t.NumField(); j++ {
+			sf := t.Field(j)
+			name := strings.Split(sf.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = sf.Name
+			}
+			if name == p {
+				found = true
+				if i < len(field)-1 {
+					t = sf.Type
+				}
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	type Embed struct {
+		Inner string `json:"inner"`
+	}
+	type checkType struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+		Embed
+	}
+	type otherType struct {
+		Other string
+	}
+	c := checkType{}
+	rules := New(&c).
+		Field(&c.Name, Required(), MinLength(3), MaxLength(10)).
+		Field(&c.Age, Min(0), Max(120)).
+		Field(&c.Inner, Required())
+	assert.Nil(t, rules.Check(), "Valid rules")
+
+	errs := New(&c).
+		Field(&c.Name, MinLength(10), MaxLength(5), FieldFunc(func(field []string, value any) Error { return nil })).
+		Field(&c.Age, Min(10), Max(120)).
+		Check()
+	assert.Len(t, errs, 2, "Contradicting and not exportable")
+	assert.ErrorIs(t, errs, ErrContradiction, "Contradiction")
+	assert.ErrorIs(t, errs, ErrNotExportable, "Not exportable")
+	assert.NotErrorIs(t, errs, ErrUnresolvedField, "All fields resolved")
+
+	errs = New(&c).Field(&c.Age, Min(5).Exclusive(), Max(5)).Check()
+	assert.ErrorIs(t, errs, ErrContradiction, "Exclusive bounds leave no value")
+	errs = New(&c).Field(&c.Age, Range(10, 20), Max(5)).Check()
+	assert.ErrorIs(t, errs, ErrContradiction, "Range above the maximum")
+	assert.ErrorIs(t, errs, ErrConflict, "Range and maximum differ")
+	assert.ErrorIs(t, New(&c).Field(&c.Age, Range(5, 5).Exclusive()).Check(), ErrContradiction, "Exclusive range leaves no value")
+	assert.ErrorIs(t, New(&c).Field(&c.Name, Length(10, 20), MaxLength(5)).Check(), ErrContradiction, "Length above the maximum length")
+	assert.Nil(t, New(&c).Field(&c.Age, RangeFloat(0.2, 0.8)).Check(), "Fractional range")
+
+	// missing registrations
+	RegisterSniffer(nil)
+	errs = New(&c).Field(&c.Name, ContentType("text/plain"), Remote("https://example.com/name", RemoteOptions{})).Check()
+	assert.Len(t, errs, 2, "No sniffer or remote checker")
+	assert.ErrorIs(t, errs, ErrNotRegistered, "Not registered")
+	assert.Nil(t, New(&c).Field(&c.Name, Remote("https://example.com/name", RemoteOptions{Checker: stubChecker{}})).Check(), "Own checker")
+
+	// stable order
+	for i := 0; i < 10; i++ {
+		errs = New(&c).Field(&c.Name, MinLength(10), MaxLength(5), MinBytes(10), MaxBytes(5)).Field(&c.Age, Min(10), Max(5)).Check()
+		assert.Equal(t, []string{
+			"minimum value 10 of age is more than the maximum 5",
+			"minimum bytes 10 of name is more than the maximum 5",
+			"minimum length 10 of name is more than the maximum 5",
+		}, []string{errs.(ErrorSlice)[0].Error(), errs.(ErrorSlice)[1].Error(), errs.(ErrorSlice)[2].Error()}, "Sorted by field")
+	}
+
+	// validators bound to another struct
+	o := otherType{}
+	other := New(&o).Field(&o.Other, Required())
+	errs = New(&c).Struct(other.Validators()...).Check()
+	assert.ErrorIs(t, errs, ErrUnresolvedField, "Field not on struct")
+}
+
+func TestMergeRules(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	u := user{}
+	base := New(&u).Field(&u.Name, Required(), MaxLength(50)).Label(&u.Name, "Full name")
+	merged, err := base.Merge(New(&u).Field(&u.Age, Min(18)))
+	assert.Nil(t, err, "No conflicts")
+	assert.Len(t, merged.Validators(), 3, "Validators merged")
+	assert.Len(t, base.Validators(), 2, "Base unchanged")
+
+	merged, err = base.Merge(New(&u).Field(&u.Name, MaxLength(30)).Label(&u.Age, "Age in years"))
+	assert.ErrorIs(t, err, ErrConflict, "Different maximum")
+	assert.Len(t, err, 1, "One conflict")
+	assert.Equal(t, "maximum length of name is set to both 50 and 30", err.(ErrorSlice)[0].Error(), "Conflict message")
+	assert.Equal(t, map[string]string{"name": "Full name", "age": "Age in years"}, merged.Labels(), "Labels merged")
+
+	_, err = base.Merge(New(&u).Field(&u.Name, MinLength(60)))
+	assert.ErrorIs(t, err, ErrContradiction, "Minimum more than maximum")
+	_, err = base.Merge(New(&u).Field(&u.Name, MaxLength(50)))
+	assert.Nil(t, err, "Same limit twice")
+
+	assert.ErrorIs(t, New(&u).Field(&u.Age, Min(1), Min(2)).Check(), ErrConflict, "Check reports conflicts")
+}
@@ -0,0 +1,116 @@
+package xvalid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// ChecksumValidator field must be the checksum of another field
+type ChecksumValidator struct {
+	field      []string
+	message    string
+	label      string
+	contentPtr any
+	content    []string
+	algorithm  string
+}
+
+// Field of the field
+func (c *ChecksumValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ChecksumValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *ChecksumValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ChecksumValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value. The content is in another field, so it can only be checked as part of the rules.
+func (c *ChecksumValidator) Validate(value any) Error {
+	return nil
+}
+
+func (c *ChecksumValidator) resolveFields(structPtr any) {
+	c.content = getField(structPtr, c.contentPtr)
+}
+
+func (c *ChecksumValidator) validateFields(vmap map[string]any) ErrorSlice {
+	digest, _ := fieldValue(vmap, c.field)
+	declared, ok := digest.(string)
+	if !ok || declared == "" {
+		return nil
+	}
+	content, _ := fieldValue(vmap, c.content)
+	if sum, ok := c.sum(content); ok && (strings.EqualFold(declared, hex.EncodeToString(sum)) ||
+		declared == base64.StdEncoding.EncodeToString(sum)) {
+		return nil
+	}
+	return ErrorSlice{createError(c.field, c.message, fmt.Sprintf("Please make sure %s is the %s checksum of %s",
+		fieldLabel(c.field, c.label), c.algorithm, jsonFieldName(c.content)))}
+}
+
+// sum hashes the content, reading readers to the end
+func (c *ChecksumValidator) sum(content any) ([]byte, bool) {
+	r, done, ok := contentReader(content)
+	if !ok {
+		return nil, false
+	}
+	defer done()
+	h := checksumAlgorithms[c.algorithm]()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, false
+	}
+	return h.Sum(nil), true
+}
+
+// CanExport for this validator
+func (c *ChecksumValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *ChecksumValidator) Params() map[string]any {
+	return map[string]any{"content": c.content, "algorithm": c.algorithm}
+}
+
+// MatchesChecksum field must be the checksum of the content field, hashed with "md5", "sha1", "sha256" or "sha512".
+// The checksum can be in hex or base64. The content can be []byte, string, io.ReadSeeker or a value added with
+// RegisterContent. Content is hashed as a stream without being held in memory, and readers are rewound after
+// hashing. Readers that can't seek fail since hashing would consume them. Empty checksums pass, use Required to
+// require one.
+func MatchesChecksum(contentPtr any, algorithm string) *ChecksumValidator {
+	if _, ok := checksumAlgorithms[algorithm]; !ok {
+		panic(fmt.Errorf("checksum algorithm not supported: %s", algorithm))
+	}
+	return &ChecksumValidator{
+		contentPtr: contentPtr,
+		algorithm:  algorithm,
+	}
+}
@@ -0,0 +1,54 @@
+package xvalid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesChecksum(t *testing.T) {
+	type manifest struct {
+		Content []byte `json:"content"`
+		Digest  string `json:"digest"`
+	}
+	m := manifest{}
+	content := []byte("hello world")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+	rules := New(&m).Field(&m.Digest, MatchesChecksum(&m.Content, "sha256"))
+	assert.Nil(t, rules.Validate(manifest{Content: content, Digest: digest}), "Hex")
+	assert.Nil(t, rules.Validate(manifest{Content: content, Digest: strings.ToUpper(digest)}), "Upper case hex")
+	assert.Nil(t, rules.Validate(manifest{Content: content, Digest: base64.StdEncoding.EncodeToString(sum[:])}), "Base64")
+	assert.Nil(t, rules.Validate(manifest{Content: content}), "No digest")
+	errs := rules.Validate(manifest{Content: []byte("tampered"), Digest: digest}).(ErrorSlice)
+	assert.Equal(t, "Please make sure digest is the sha256 checksum of content", errs[0].Error(), "Mismatch")
+	assert.Equal(t, "checksum", errs[0].(interface{ Rule() string }).Rule(), "Rule name")
+	assert.Panics(t, func() { MatchesChecksum(&m.Content, "crc32") }, "Unsupported algorithm")
+
+	type upload struct {
+		Body   io.ReadSeeker `json:"body"`
+		SHA256 string        `json:"sha256"`
+	}
+	u := upload{}
+	r := bytes.NewReader(content)
+	rules = New(&u).Field(&u.SHA256, MatchesChecksum(&u.Body, "sha256"))
+	assert.Nil(t, rules.Validate(upload{Body: r, SHA256: digest}), "Reader")
+	assert.Equal(t, len(content), r.Len(), "Rewound")
+
+	type streamUpload struct {
+		Body   io.Reader `json:"body"`
+		SHA256 string    `json:"sha256"`
+	}
+	s := streamUpload{}
+	stream := struct{ io.Reader }{bytes.NewReader(content)}
+	rules = New(&s).Field(&s.SHA256, MatchesChecksum(&s.Body, "sha256"))
+	assert.NotNil(t, rules.Validate(streamUpload{Body: stream, SHA256: digest}), "Stream fails")
+	rest, _ := io.ReadAll(stream)
+	assert.Equal(t, content, rest, "Stream not read")
+}
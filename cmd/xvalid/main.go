@@ -0,0 +1,265 @@
+// Command xvalid validates JSON documents against rules exported with Rules.MarshalJSON.
+//
+// Usage:
+//
+//	xvalid -rules rules.json [document.json ...]
+//
+// Each document file can hold a single JSON object, or many objects one after another as in NDJSON. Documents are
+// read from stdin if no files are given. A result is printed as a line of JSON for every document, and the exit code
+// is 1 if any document is invalid.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	// remote rules post to their URL
+	_ "github.com/AgentCosmic/xvalid/v2/xvalidhttp"
+)
+
+// result of validating a document
+type result struct {
+	File   string       `json:"file"`
+	Index  int          `json:"index"`
+	Valid  bool         `json:"valid"`
+	Errors []fieldError `json:"errors,omitempty"`
+}
+
+// fieldError is an error with the full field path
+type fieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run the command and return the exit code
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("xvalid", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rulesFile := flags.String("rules", "", "JSON file of exported rules")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *rulesFile == "" {
+		fmt.Fprintln(stderr, "xvalid: -rules is required")
+		return 2
+	}
+	data, err := os.ReadFile(*rulesFile)
+	if err != nil {
+		fmt.Fprintln(stderr, "xvalid:", err)
+		return 2
+	}
+	s, err := parseSchema(data)
+	if err != nil {
+		fmt.Fprintf(stderr, "xvalid: %s: %v\n", *rulesFile, err)
+		return 2
+	}
+
+	code := 0
+	encoder := json.NewEncoder(stdout)
+	validateFile := func(name string, r io.Reader) error {
+		decoder := json.NewDecoder(r)
+		decoder.UseNumber()
+		for i := 0; ; i++ {
+			var doc map[string]any
+			err := decoder.Decode(&doc)
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			res := result{File: name, Index: i, Valid: true}
+			if res.Errors, err = s.validate(convertNumbers(doc).(map[string]any)); err != nil {
+				return err
+			}
+			if len(res.Errors) > 0 {
+				res.Valid = false
+				code = 1
+			}
+			if err := encoder.Encode(res); err != nil {
+				return err
+			}
+		}
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		var err error
+		if name == "-" {
+			err = validateFile(name, stdin)
+		} else if f, openErr := os.Open(name); openErr != nil {
+			err = openErr
+		} else {
+			err = validateFile(name, f)
+			f.Close()
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "xvalid: %s: %v\n", name, err)
+			return 2
+		}
+	}
+	return code
+}
+
+// convertNumbers converts json.Number into int64 if possible so integer rules work, otherwise float64
+func convertNumbers(v any) any {
+	switch v2 := v.(type) {
+	case json.Number:
+		if i, err := v2.Int64(); err == nil {
+			return i
+		}
+		f, _ := v2.Float64()
+		return f
+	case map[string]any:
+		for k, e := range v2 {
+			v2[k] = convertNumbers(e)
+		}
+	case []any:
+		for i, e := range v2 {
+			v2[i] = convertNumbers(e)
+		}
+	}
+	return v
+}
+
+// schema loads exported rules into a struct with a field for each field of the rules. The fields are typed like the
+// values of each document, so numbers are compared exactly and options match, and the rules are loaded once for
+// every combination of types.
+type schema struct {
+	data    []byte
+	names   []string
+	decoded map[string]bool
+	rules   map[reflect.Type]xvalid.Rules
+}
+
+// exportedRule is the part of an exported rule needed to find the fields of the document
+type exportedRule struct {
+	Rule  string            `json:"rule"`
+	Field string            `json:"field"`
+	Then  []json.RawMessage `json:"then"`
+	Else  []json.RawMessage `json:"else"`
+}
+
+var (
+	anyType = reflect.TypeOf((*any)(nil)).Elem()
+	rawType = reflect.TypeOf(json.RawMessage{})
+)
+
+// parseSchema loads exported rules. They are loaded once with fields of any type so broken rules are found before
+// any document is read.
+func parseSchema(data []byte) (*schema, error) {
+	var fields map[string][]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	s := &schema{data: data, decoded: make(map[string]bool), rules: make(map[reflect.Type]xvalid.Rules)}
+	known := make(map[string]bool)
+	var scan func(field string, rules []json.RawMessage) error
+	scan = func(field string, rules []json.RawMessage) error {
+		if !known[field] {
+			known[field] = true
+			s.names = append(s.names, field)
+		}
+		for _, data := range rules {
+			var r exportedRule
+			if err := json.Unmarshal(data, &r); err != nil {
+				return fmt.Errorf("field %q: %w", field, err)
+			}
+			switch r.Rule {
+			case "decode":
+				s.decoded[field] = true
+			case "if":
+				// the condition can be on a field without rules of its own
+				if err := scan(r.Field, nil); err != nil {
+					return err
+				}
+				if err := scan(field, append(r.Then, r.Else...)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for field, rules := range fields {
+		if err := scan(field, rules); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(s.names)
+	if _, _, err := s.load(map[string]any{}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load the rules for the types of the document, and return them with the document as a struct
+func (s *schema) load(doc map[string]any) (xvalid.Rules, reflect.Value, error) {
+	fields := make([]reflect.StructField, len(s.names))
+	values := make([]any, len(s.names))
+	for i, name := range s.names {
+		t, value := anyType, doc[name]
+		if s.decoded[name] {
+			// decode validates the raw JSON of the field
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return xvalid.Rules{}, reflect.Value{}, err
+			}
+			t, value = rawType, json.RawMessage(raw)
+		} else if value != nil {
+			t = reflect.TypeOf(value)
+		}
+		fields[i] = reflect.StructField{Name: fmt.Sprintf("F%d", i), Type: t, Tag: reflect.StructTag(fmt.Sprintf(`json:%q`, name))}
+		values[i] = value
+	}
+	structType := reflect.StructOf(fields)
+	rules, ok := s.rules[structType]
+	if !ok {
+		var err error
+		if rules, err = xvalid.ParseRules(reflect.New(structType).Interface(), s.data); err != nil {
+			return rules, reflect.Value{}, err
+		}
+		s.rules[structType] = rules
+	}
+	subject := reflect.New(structType).Elem()
+	for i, value := range values {
+		if value != nil {
+			subject.Field(i).Set(reflect.ValueOf(value))
+		}
+	}
+	return rules, subject, nil
+}
+
+// validate a document
+func (s *schema) validate(doc map[string]any) ([]fieldError, error) {
+	rules, subject, err := s.load(doc)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]fieldError, 0)
+	verrs, _ := rules.Validate(subject.Interface()).(xvalid.ErrorSlice)
+	for _, e := range verrs {
+		rule := ""
+		if r, ok := e.(interface{ Rule() string }); ok {
+			rule = r.Rule()
+		}
+		errs = append(errs, fieldError{strings.Join(e.Field(), "."), rule, e.Error()})
+	}
+	// fields are loaded in random order
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+	return errs, nil
+}
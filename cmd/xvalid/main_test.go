@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type user struct {
+		Name    string          `json:"name"`
+		Age     int             `json:"age"`
+		Role    string          `json:"role"`
+		Nick    string          `json:"nick"`
+		Email   string          `json:"email"`
+		Address json.RawMessage `json:"address"`
+	}
+	u := user{}
+	a := address{}
+	rules := xvalid.New(&u).
+		Field(&u.Name, xvalid.Required(), xvalid.MaxLength(5).SetMessage("Too long")).
+		Field(&u.Age, xvalid.Min(18)).
+		Field(&u.Nick, xvalid.MinLength(3).SetOptional()).
+		Field(&u.Role, xvalid.Options("admin", "user")).
+		Field(&u.Email, xvalid.If(&u.Role, xvalid.Options("admin")).Then(xvalid.Required(), xvalid.Email())).
+		Field(&u.Address, xvalid.Decode(xvalid.New(&a).Field(&a.City, xvalid.Required())))
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "rules.json")
+	data, _ := json.Marshal(rules)
+	os.WriteFile(rulesFile, data, 0o644)
+	docs := filepath.Join(dir, "users.ndjson")
+	os.WriteFile(docs, []byte(`{"name":"Jo","age":20,"role":"user","address":{"city":"Paris"}}
+{"name":"Johnny","age":17,"role":"admin","address":{}}
+`), 0o644)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-rules", rulesFile, docs}, nil, &stdout, &stderr)
+	assert.Equal(t, 1, code, "Invalid document found")
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	assert.Len(t, lines, 2, "Result per document")
+	assert.JSONEq(t, `{"file":"`+docs+`","index":0,"valid":true}`, lines[0], "Valid document")
+	assert.JSONEq(t, `{"file":"`+docs+`","index":1,"valid":false,"errors":[
+		{"field":"address.city","rule":"required","message":"Please enter the city"},
+		{"field":"age","rule":"min","message":"Please increase age to be 18 or more"},
+		{"field":"email","rule":"required","message":"Please enter the email"},
+		{"field":"email","rule":"email","message":"Please use a valid email address for email"},
+		{"field":"name","rule":"maxLength","message":"Too long"}
+	]}`, lines[1], "Invalid document")
+
+	stdout.Reset()
+	code = run([]string{"-rules", rulesFile}, strings.NewReader(`{"name":"Al","age":30,"role":"user"}`), &stdout, &stderr)
+	assert.Equal(t, 0, code, "Valid stdin")
+	assert.Contains(t, stdout.String(), `"file":"-"`, "Read from stdin")
+
+	// large integers are compared exactly
+	type account struct {
+		ID int64 `json:"id"`
+	}
+	acc := account{}
+	data, _ = json.Marshal(xvalid.New(&acc).Field(&acc.ID, xvalid.Min(1<<53+1)))
+	os.WriteFile(rulesFile, data, 0o644)
+	stdout.Reset()
+	code = run([]string{"-rules", rulesFile}, strings.NewReader(`{"id":9007199254740992}`), &stdout, &stderr)
+	assert.Equal(t, 1, code, "Large integer below min")
+
+	assert.Equal(t, 2, run([]string{}, nil, &stdout, &stderr), "Missing rules")
+	os.WriteFile(rulesFile, []byte(`{"name":[{"rule":"unknown"}]}`), 0o644)
+	stderr.Reset()
+	assert.Equal(t, 2, run([]string{"-rules", rulesFile}, nil, &stdout, &stderr), "Unsupported rule")
+	assert.Contains(t, stderr.String(), "rule not supported: unknown", "Error message")
+}
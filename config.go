@@ -0,0 +1,92 @@
+package xvalid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MismatchPolicy decides what happens when a validator gets a value of a type it doesn't support
+type MismatchPolicy int
+
+const (
+	// MismatchDefault keeps the behavior of each validator
+	MismatchDefault MismatchPolicy = iota
+	// MismatchSkip passes values of the wrong type
+	MismatchSkip
+	// MismatchFail fails values of the wrong type
+	MismatchFail
+	// MismatchPanic panics on values of the wrong type
+	MismatchPanic
+)
+
+// ZeroTimePolicy decides whether a zero time.Time counts as unset for Required
+type ZeroTimePolicy int
+
+const (
+	// ZeroTimeExact treats only time.Time{} as unset
+	ZeroTimeExact ZeroTimePolicy = iota
+	// ZeroTimeInstant treats any time at the zero instant as unset, including "0001-01-01T00:00:00+00:00" decoded from JSON
+	ZeroTimeInstant
+	// ZeroTimeSet treats zero times as set
+	ZeroTimeSet
+)
+
+// Config for application wide behavior
+type Config struct {
+	// OptionalByDefault makes validators that support SetOptional optional when they are created
+	OptionalByDefault bool
+	// TrimStrings removes leading and trailing white space from string fields before validating
+	TrimStrings bool
+	// MismatchPolicy for values of the wrong type
+	MismatchPolicy MismatchPolicy
+	// ZeroTimePolicy used by Required for time.Time values
+	ZeroTimePolicy ZeroTimePolicy
+	// JoinMessages combines the messages of ErrorSlice and ErrorMap into the string returned by Error, e.g. with
+	// Joiner. The messages are joined as sentences with ". " and a trailing period if it is nil.
+	JoinMessages func(messages []string) string
+	// Translator for the messages of Rules.ValidateWithLocale, e.g. a Catalog
+	Translator Translator
+	// ErrorRules adds the rule of each error to its JSON, e.g. {"message": "...", "field": "name", "rule": "required"},
+	// so UnmarshalJSON can give it back
+	ErrorRules bool
+}
+
+var config Config
+
+// Configure sets the application wide behavior. It should be called once on startup before any rules are created.
+func Configure(c Config) {
+	config = c
+}
+
+// CurrentConfig returns the configuration set with Configure
+func CurrentConfig() Config {
+	return config
+}
+
+// Joiner returns a JoinMessages function that puts the separator between messages and the terminal after the last
+// one, e.g. Joiner("。", "。") for Japanese or Joiner("\n", "") for one message per line
+func Joiner(separator string, terminal string) func(messages []string) string {
+	return func(messages []string) string {
+		if len(messages) == 0 {
+			return ""
+		}
+		return strings.Join(messages, separator) + terminal
+	}
+}
+
+// passMismatch returns true if a value of the wrong type should pass. The fallback is used if no policy is configured
+// or the value is nil.
+func passMismatch(value any, fallback bool) bool {
+	if value == nil {
+		return fallback
+	}
+	switch config.MismatchPolicy {
+	case MismatchSkip:
+		return true
+	case MismatchFail:
+		return false
+	case MismatchPanic:
+		panic(fmt.Errorf("type not supported: %T", value))
+	}
+	return fallback
+}
@@ -0,0 +1,55 @@
+package xvalid
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigure(t *testing.T) {
+	defer Configure(CurrentConfig())
+	type configType struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	c := configType{}
+
+	Configure(Config{OptionalByDefault: true})
+	rules := New(&c).Field(&c.Name, MinLength(3), Email())
+	assert.Nil(t, rules.Validate(configType{}), "Optional by default")
+	assert.Len(t, rules.Validate(configType{Name: "x"}), 2, "Still validated when not zero")
+
+	Configure(Config{TrimStrings: true})
+	rules = New(&c).Field(&c.Name, Required(), MaxLength(3))
+	assert.Len(t, rules.Validate(configType{Name: "   "}), 1, "Trimmed to empty")
+	assert.Nil(t, rules.Validate(configType{Name: " abc "}), "Trimmed to max")
+
+	rules = New(&c).Field(&c.Count, MinLength(3), MaxLength(3), Pattern(`x`))
+	Configure(Config{MismatchPolicy: MismatchSkip})
+	assert.Nil(t, rules.Validate(configType{Count: 1}), "Skip mismatch")
+	Configure(Config{MismatchPolicy: MismatchFail})
+	assert.Len(t, rules.Validate(configType{Count: 1}), 3, "Fail mismatch")
+	Configure(Config{MismatchPolicy: MismatchPanic})
+	assert.ErrorIs(t, rules.Validate(configType{Count: 1}), ErrInternal, "Panic mismatch")
+
+	rules = New(&c).Field(&c.Name, Min(1), Max(1))
+	Configure(Config{MismatchPolicy: MismatchSkip})
+	assert.Nil(t, rules.Validate(configType{Name: "x"}), "Skip number mismatch")
+	Configure(Config{MismatchPolicy: MismatchFail})
+	assert.Len(t, rules.Validate(configType{Name: "x"}), 2, "Fail number mismatch")
+
+	Configure(Config{ZeroTimePolicy: ZeroTimeSet})
+	assert.Nil(t, Required().Validate(time.Time{}), "Zero time policy")
+
+	errs := ErrorSlice{NewError("Please enter a name"), NewError("Please enter an email")}
+	Configure(Config{})
+	assert.Equal(t, "Please enter a name. Please enter an email.", errs.Error(), "Sentences by default")
+	Configure(Config{JoinMessages: Joiner("\n", "")})
+	assert.Equal(t, "Please enter a name\nPlease enter an email", errs.Error(), "Custom joiner")
+	assert.Equal(t, "Please enter a name", ErrorMap{"name": errs[0]}.Error(), "Map joiner")
+	assert.Equal(t, "", ErrorSlice{}.Error(), "No messages")
+	Configure(Config{JoinMessages: func(messages []string) string { return "- " + strings.Join(messages, "\n- ") }})
+	assert.Equal(t, "- Please enter a name\n- Please enter an email", errs.Error(), "Custom function")
+}
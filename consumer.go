@@ -0,0 +1,39 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Rejection describes why a message was rejected, e.g. to attach it to the message sent to a dead letter queue
+type Rejection struct {
+	// Reason is "decode" if the message isn't valid JSON for the type, or "invalid" if it failed the rules
+	Reason string `json:"reason"`
+	// Error message
+	Error string `json:"error"`
+	// Errors of the rules when the reason is "invalid"
+	Errors ErrorSlice `json:"errors,omitempty"`
+}
+
+// ConsumeJSON returns a handler for messages of a queue such as Kafka, NATS or SQS. The handler decodes the message
+// into a T, validates it with the rules and passes it to handle. Messages that can't be decoded or are invalid are
+// passed to deadLetter with the Rejection instead, and the handler returns the error of deadLetter, so a nil error
+// means the message can be acknowledged. Errors of handle are returned as is, e.g. to retry the message, and so are
+// validation errors matching ErrInternal or ErrTimeout since the message may pass once the validator recovers.
+func ConsumeJSON[T any](rules Rules, handle func(ctx context.Context, msg T) error, deadLetter func(ctx context.Context, data []byte, rejection Rejection) error) func(ctx context.Context, data []byte) error {
+	return func(ctx context.Context, data []byte) error {
+		var msg T
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return deadLetter(ctx, data, Rejection{Reason: "decode", Error: err.Error()})
+		}
+		if err := rules.ValidateCtx(ctx, msg); err != nil {
+			var errs ErrorSlice
+			if !errors.As(err, &errs) || errors.Is(err, ErrInternal) || errors.Is(err, ErrTimeout) {
+				return err
+			}
+			return deadLetter(ctx, data, Rejection{Reason: "invalid", Error: err.Error(), Errors: errs})
+		}
+		return handle(ctx, msg)
+	}
+}
@@ -0,0 +1,55 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumeJSON(t *testing.T) {
+	type order struct {
+		ID       string `json:"id"`
+		Quantity int    `json:"quantity"`
+	}
+	o := order{}
+	rules := New(&o).Field(&o.ID, Required()).Field(&o.Quantity, Min(1))
+	handled := make([]order, 0)
+	rejected := make([]Rejection, 0)
+	consume := ConsumeJSON(rules, func(ctx context.Context, msg order) error {
+		handled = append(handled, msg)
+		if msg.ID == "retry" {
+			return errors.New("retry later")
+		}
+		return nil
+	}, func(ctx context.Context, data []byte, rejection Rejection) error {
+		rejected = append(rejected, rejection)
+		return nil
+	})
+
+	assert.Nil(t, consume(context.Background(), []byte(`{"id":"a","quantity":2}`)), "Valid message")
+	assert.Equal(t, []order{{"a", 2}}, handled, "Handled")
+	assert.Nil(t, consume(context.Background(), []byte(`{"quantity":0}`)), "Invalid message acknowledged")
+	assert.Nil(t, consume(context.Background(), []byte(`{"id":`)), "Malformed message acknowledged")
+	assert.EqualError(t, consume(context.Background(), []byte(`{"id":"retry","quantity":1}`)), "retry later", "Handler error")
+	assert.Len(t, handled, 2, "Rejected messages not handled")
+
+	assert.Equal(t, "invalid", rejected[0].Reason, "Invalid reason")
+	assert.Len(t, rejected[0].Errors, 2, "Rule errors")
+	assert.Equal(t, "decode", rejected[1].Reason, "Decode reason")
+	j, _ := json.Marshal(rejected[1])
+	assert.Equal(t, `{"reason":"decode","error":"unexpected end of JSON input"}`, string(j), "Rejection payload")
+
+	calls := 0
+	broken := New(&o).Field(&o.ID, Remote("", RemoteOptions{Checker: vatChecker{&calls}}))
+	consume = ConsumeJSON(broken, func(ctx context.Context, msg order) error { return nil },
+		func(ctx context.Context, data []byte, rejection Rejection) error {
+			rejected = append(rejected, rejection)
+			return nil
+		})
+	err := consume(context.Background(), []byte(`{"id":"broken","quantity":1}`))
+	assert.ErrorIs(t, err, ErrInternal, "Internal error returned to retry")
+	assert.Len(t, rejected, 2, "Internal error not dead lettered")
+}
@@ -0,0 +1,302 @@
+package xvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	contentOpeners []func(value any) (io.ReadCloser, bool)
+	contentMutex   sync.RWMutex
+)
+
+// RegisterContent lets the content validators read another type of value. Open returns false for values of other
+// types. Importing xvalidhttp registers *multipart.FileHeader, the type of uploaded files. It should be called on
+// startup.
+func RegisterContent(open func(value any) (io.ReadCloser, bool)) {
+	contentMutex.Lock()
+	defer contentMutex.Unlock()
+	contentOpeners = append(contentOpeners, open)
+}
+
+// openContent opens the value with the first registered opener that supports it
+func openContent(value any) (io.ReadCloser, bool) {
+	contentMutex.RLock()
+	defer contentMutex.RUnlock()
+	for _, open := range contentOpeners {
+		if r, ok := open(value); ok {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// contentReader returns a reader of the content of []byte, string, io.ReadSeeker and registered values, such as
+// upload fields. Done must be called once reading is finished: it rewinds readers and closes opened files. Readers
+// that can't seek are not supported since reading them would leave the handler without the content.
+func contentReader(value any) (r io.Reader, done func(), ok bool) {
+	if f, ok := openContent(value); ok {
+		return f, func() { f.Close() }, true
+	}
+	switch v := value.(type) {
+	case []byte:
+		return bytes.NewReader(v), func() {}, true
+	case string:
+		return strings.NewReader(v), func() {}, true
+	case io.ReadSeeker:
+		pos, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, nil, false
+		}
+		return v, func() { v.Seek(pos, io.SeekStart) }, true
+	}
+	return nil, nil, false
+}
+
+// isStream returns true for readers, which fail content validators if contentReader can't read them without
+// consuming them
+func isStream(value any) bool {
+	_, ok := value.(io.Reader)
+	return ok
+}
+
+// isEmptyContent returns true for values that have no content to validate
+func isEmptyContent(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case []byte:
+		return len(v) == 0
+	case string:
+		return v == ""
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return rv.IsNil()
+	}
+	return false
+}
+
+var (
+	contentSniffer func(head []byte) string
+	sniffMutex     sync.RWMutex
+)
+
+// RegisterSniffer sets how ContentType finds the media type of content from its first 512 bytes. Importing
+// xvalidhttp registers http.DetectContentType. It should be called on startup.
+func RegisterSniffer(sniff func(head []byte) string) {
+	sniffMutex.Lock()
+	defer sniffMutex.Unlock()
+	contentSniffer = sniff
+}
+
+var errNoSniffer = errors.New("content type needs a sniffer, call RegisterSniffer or import xvalidhttp")
+
+// ContentTypeValidator field content must be one of the media types
+type ContentTypeValidator struct {
+	field   []string
+	message string
+	label   string
+	types   []string
+}
+
+// Field of the field
+func (c *ContentTypeValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ContentTypeValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *ContentTypeValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ContentTypeValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value. Empty values pass, use Required to require content.
+func (c *ContentTypeValidator) Validate(value any) Error {
+	if isEmptyContent(value) {
+		return nil
+	}
+	sniffMutex.RLock()
+	sniff := contentSniffer
+	sniffMutex.RUnlock()
+	if sniff == nil {
+		return &internalError{validationError{
+			message: fmt.Sprintf("Something went wrong while validating %s", dataName(c.field)),
+			field:   c.field,
+		}, errNoSniffer}
+	}
+	r, done, ok := contentReader(value)
+	if !ok {
+		if !isStream(value) && passMismatch(value, true) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please upload a file of type %s for %s", strings.Join(c.types, " or "), fieldLabel(c.field, c.label)))
+	}
+	// sniffers look at no more than the first 512 bytes
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(r, head)
+	done()
+	mediaType, _, _ := mime.ParseMediaType(sniff(head[:n]))
+	for _, t := range c.types {
+		if t == mediaType || strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, t[:len(t)-1]) {
+			return nil
+		}
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please upload a file of type %s for %s", strings.Join(c.types, " or "), fieldLabel(c.field, c.label)))
+}
+
+// checkSetup reports a missing sniffer
+func (c *ContentTypeValidator) checkSetup() error {
+	sniffMutex.RLock()
+	defer sniffMutex.RUnlock()
+	if contentSniffer == nil {
+		return errNoSniffer
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *ContentTypeValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *ContentTypeValidator) Params() map[string]any {
+	return map[string]any{"types": c.types}
+}
+
+// MarshalJSON for this validator
+func (c *ContentTypeValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule    string   `json:"rule"`
+		Types   []string `json:"types"`
+		Message string   `json:"message,omitempty"`
+		Label   string   `json:"label,omitempty"`
+	}{"contentType", c.types, c.message, c.label})
+}
+
+// ContentType field content must be one of the media types, e.g. "application/pdf" or "image/*". The type is sniffed
+// from the magic number at the start of the content by the function added with RegisterSniffer, so the file name and
+// the type sent by the client are not trusted. Importing xvalidhttp registers http.DetectContentType. Without a
+// sniffer every check fails with an internal error, which Rules.Check reports. Works on []byte, string, io.ReadSeeker
+// and values added with RegisterContent. Readers are rewound after sniffing, and readers that can't seek fail since
+// sniffing would remove the start of the content.
+func ContentType(types ...string) *ContentTypeValidator {
+	return &ContentTypeValidator{
+		types: types,
+	}
+}
+
+// MaxSizeValidator field content must not be larger than a number of bytes
+type MaxSizeValidator struct {
+	field   []string
+	message string
+	label   string
+	max     int64
+}
+
+// Field of the field
+func (c *MaxSizeValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *MaxSizeValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *MaxSizeValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *MaxSizeValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value
+func (c *MaxSizeValidator) Validate(value any) Error {
+	if isEmptyContent(value) {
+		return nil
+	}
+	r, done, ok := contentReader(value)
+	if !ok {
+		if !isStream(value) && passMismatch(value, true) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please reduce %s to %s or less", fieldLabel(c.field, c.label), formatSize(c.max)))
+	}
+	// one byte more than the limit is enough to know it is too large
+	size, _ := io.Copy(io.Discard, io.LimitReader(r, c.max+1))
+	done()
+	if size > c.max {
+		return createError(c.field, c.message, fmt.Sprintf("Please reduce %s to %s or less", fieldLabel(c.field, c.label), formatSize(c.max)))
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *MaxSizeValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *MaxSizeValidator) Params() map[string]any {
+	return map[string]any{"max": c.max}
+}
+
+// MarshalJSON for this validator
+func (c *MaxSizeValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule    string `json:"rule"`
+		Max     int64  `json:"max"`
+		Message string `json:"message,omitempty"`
+		Label   string `json:"label,omitempty"`
+	}{"maxSize", c.max, c.message, c.label})
+}
+
+// MaxSize field content must be at most max bytes. Works on []byte, string, io.ReadSeeker and values added with
+// RegisterContent, such as the uploaded files of xvalidhttp. Readers are read no further than the limit, so large payloads aren't loaded into memory, and rewound
+// afterwards. Readers that can't seek fail since measuring them would consume the content.
+func MaxSize(max int64) *MaxSizeValidator {
+	return &MaxSizeValidator{
+		max: max,
+	}
+}
+
+// formatSize formats bytes in the largest unit that keeps the number at least 1 with up to one decimal place,
+// e.g. "1.5 MB"
+func formatSize(size int64) string {
+	units := []string{"bytes", "KB", "MB", "GB", "TB"}
+	value := float64(size)
+	i := 0
+	for value >= 1024 && i < len(units)-1 {
+		value /= 1024
+		i++
+	}
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64) + " " + units[i]
+}
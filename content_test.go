@@ -0,0 +1,95 @@
+package xvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxSize(t *testing.T) {
+	type payload struct {
+		Blob []byte    `json:"blob"`
+		Text string    `json:"text"`
+		Body io.Reader `json:"body"`
+	}
+	p := payload{}
+	rules := New(&p).Field(&p.Blob, MaxSize(4)).Field(&p.Text, MaxSize(4)).Field(&p.Body, MaxSize(1536))
+	assert.Nil(t, rules.Validate(payload{Blob: []byte("abcd"), Text: "abcd", Body: strings.NewReader("abc")}), "Within limit")
+	errs := rules.Validate(payload{Blob: []byte("abcde"), Text: "abcde", Body: strings.NewReader(strings.Repeat("a", 2000))}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Over limit")
+	assert.Equal(t, "Please reduce blob to 4 bytes or less", errs[0].Error(), "Bytes message")
+	assert.Equal(t, "Please reduce body to 1.5 KB or less", errs[2].Error(), "Unit message")
+
+	// readers are read no further than the limit and rewound
+	counter := &countingReader{r: strings.NewReader(strings.Repeat("a", 1<<20))}
+	assert.Len(t, New(&p).Field(&p.Body, MaxSize(10)).Validate(payload{Body: counter}), 1, "Large reader")
+	assert.Equal(t, 11, counter.n, "Read up to the limit")
+	assert.Equal(t, 1<<20, counter.r.Len(), "Rewound")
+
+	// readers that can't seek aren't consumed
+	stream := struct{ io.Reader }{strings.NewReader("abc")}
+	errs = New(&p).Field(&p.Body, MaxSize(10)).Validate(payload{Body: stream}).(ErrorSlice)
+	assert.Equal(t, "Please reduce body to 10 bytes or less", errs[0].Error(), "Stream fails")
+	rest, _ := io.ReadAll(stream)
+	assert.Equal(t, "abc", string(rest), "Stream not read")
+	assert.Equal(t, "976.6 KB", formatSize(1000000), "Rounded size")
+
+	j, _ := json.Marshal(MaxSize(1024))
+	assert.Equal(t, `{"rule":"maxSize","max":1024}`, string(j), "Export")
+}
+
+type countingReader struct {
+	r *strings.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func (c *countingReader) Seek(offset int64, whence int) (int64, error) {
+	return c.r.Seek(offset, whence)
+}
+
+func TestContentType(t *testing.T) {
+	RegisterSniffer(nil)
+	type document struct {
+		File []byte `json:"file"`
+	}
+	d := document{}
+	errs := New(&d).Field(&d.File, ContentType("application/pdf")).Validate(document{File: []byte("%PDF-1.7")}).(ErrorSlice)
+	assert.ErrorIs(t, errs[0], ErrInternal, "No sniffer")
+	RegisterSniffer(http.DetectContentType)
+	defer RegisterSniffer(nil)
+
+	pdf := []byte("%PDF-1.7\n1 0 obj")
+	rules := New(&d).Field(&d.File, ContentType("application/pdf", "image/*"))
+	assert.Nil(t, rules.Validate(document{File: pdf}), "PDF")
+	assert.Nil(t, rules.Validate(document{File: encodeImage(t, "png", 1, 1)}), "Image wildcard")
+	assert.Nil(t, rules.Validate(document{}), "Empty")
+	errs = rules.Validate(document{File: []byte("<html><script>alert(1)</script>")}).(ErrorSlice)
+	assert.Equal(t, "Please upload a file of type application/pdf or image/* for file", errs[0].Error(), "HTML sniffed")
+	assert.Nil(t, New(&d).Field(&d.File, ContentType("text/plain")).Validate(document{File: []byte("hello")}), "Parameters ignored")
+
+	type upload struct {
+		File io.Reader `json:"file"`
+	}
+	u := upload{}
+	r := bytes.NewReader(pdf)
+	assert.Nil(t, New(&u).Field(&u.File, ContentType("application/pdf")).Validate(upload{File: r}), "Reader")
+	assert.Equal(t, len(pdf), r.Len(), "Rewound")
+	stream := struct{ io.Reader }{bytes.NewReader(pdf)}
+	assert.NotNil(t, New(&u).Field(&u.File, ContentType("application/pdf")).Validate(upload{File: stream}), "Stream fails")
+	rest, _ := io.ReadAll(stream)
+	assert.Equal(t, pdf, rest, "Stream not read")
+
+	j, _ := json.Marshal(ContentType("application/pdf"))
+	assert.Equal(t, `{"rule":"contentType","types":["application/pdf"]}`, string(j), "Export")
+}
@@ -0,0 +1,322 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+//
+// ==================== Before ====================
+//
+
+// BeforeValidator field must be a time before another
+type BeforeValidator struct {
+	field   []string
+	message string
+	label   string
+	time    time.Time
+	now     func() time.Time
+	layout  string
+}
+
+// Field of the field
+func (c *BeforeValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *BeforeValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *BeforeValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *BeforeValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Layout of string fields, time.RFC3339 by default
+func (c *BeforeValidator) Layout(layout string) *BeforeValidator {
+	c.layout = layout
+	return c
+}
+
+// Validate the value
+func (c *BeforeValidator) Validate(value any) Error {
+	t, ok := parseTime(value, c.layout)
+	if !ok {
+		return createError(c.field, c.message, fmt.Sprintf("Please enter a valid date for %s", fieldLabel(c.field, c.label)))
+	}
+	if t.IsZero() {
+		return nil
+	}
+	if c.now != nil {
+		if !t.Before(c.now()) {
+			return createError(c.field, c.message, fmt.Sprintf("Please change %s to be in the past", fieldLabel(c.field, c.label)))
+		}
+		return nil
+	}
+	if !t.Before(c.time) {
+		return createError(c.field, c.message, fmt.Sprintf("Please change %s to be before %s", fieldLabel(c.field, c.label), c.time.Format(c.layout)))
+	}
+	return nil
+}
+
+// MarshalJSON for this validator
+func (c *BeforeValidator) MarshalJSON() ([]byte, error) {
+	return marshalTimeRule("before", c.time, c.now != nil, c.layout, c.message, c.label)
+}
+
+// CanExport for this validator
+func (c *BeforeValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *BeforeValidator) Params() map[string]any {
+	return map[string]any{"time": c.time, "now": c.now != nil, "layout": c.layout}
+}
+
+// Before field must be a time before t. Strings are parsed as time.RFC3339 unless another layout is set. Empty values
+// pass, use Required to require one.
+func Before(t time.Time) *BeforeValidator {
+	return &BeforeValidator{
+		time:   t,
+		layout: time.RFC3339,
+	}
+}
+
+// BeforeNow field must be a time in the past when it's validated
+func BeforeNow() *BeforeValidator {
+	return &BeforeValidator{
+		now:    time.Now,
+		layout: time.RFC3339,
+	}
+}
+
+//
+// ==================== After ====================
+//
+
+// AfterValidator field must be a time after another
+type AfterValidator struct {
+	field   []string
+	message string
+	label   string
+	time    time.Time
+	now     func() time.Time
+	layout  string
+}
+
+// Field of the field
+func (c *AfterValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *AfterValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *AfterValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *AfterValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Layout of string fields, time.RFC3339 by default
+func (c *AfterValidator) Layout(layout string) *AfterValidator {
+	c.layout = layout
+	return c
+}
+
+// Validate the value
+func (c *AfterValidator) Validate(value any) Error {
+	t, ok := parseTime(value, c.layout)
+	if !ok {
+		return createError(c.field, c.message, fmt.Sprintf("Please enter a valid date for %s", fieldLabel(c.field, c.label)))
+	}
+	if t.IsZero() {
+		return nil
+	}
+	if c.now != nil {
+		if !t.After(c.now()) {
+			return createError(c.field, c.message, fmt.Sprintf("Please change %s to be in the future", fieldLabel(c.field, c.label)))
+		}
+		return nil
+	}
+	if !t.After(c.time) {
+		return createError(c.field, c.message, fmt.Sprintf("Please change %s to be after %s", fieldLabel(c.field, c.label), c.time.Format(c.layout)))
+	}
+	return nil
+}
+
+// MarshalJSON for this validator
+func (c *AfterValidator) MarshalJSON() ([]byte, error) {
+	return marshalTimeRule("after", c.time, c.now != nil, c.layout, c.message, c.label)
+}
+
+// CanExport for this validator
+func (c *AfterValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *AfterValidator) Params() map[string]any {
+	return map[string]any{"time": c.time, "now": c.now != nil, "layout": c.layout}
+}
+
+// After field must be a time after t. Strings are parsed as time.RFC3339 unless another layout is set. Empty values
+// pass, use Required to require one.
+func After(t time.Time) *AfterValidator {
+	return &AfterValidator{
+		time:   t,
+		layout: time.RFC3339,
+	}
+}
+
+// AfterNow field must be a time in the future when it's validated, e.g. the start date of a booking
+func AfterNow() *AfterValidator {
+	return &AfterValidator{
+		now:    time.Now,
+		layout: time.RFC3339,
+	}
+}
+
+//
+// ==================== DateFormat ====================
+//
+
+// DateFormatValidator field must be a date in a layout
+type DateFormatValidator struct {
+	field   []string
+	message string
+	label   string
+	layout  string
+}
+
+// Field of the field
+func (c *DateFormatValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *DateFormatValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *DateFormatValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *DateFormatValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value
+func (c *DateFormatValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, false) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please enter a valid date for %s", fieldLabel(c.field, c.label)))
+	}
+	if str == "" {
+		return nil
+	}
+	if _, err := time.Parse(c.layout, str); err != nil {
+		return createError(c.field, c.message, fmt.Sprintf("Please enter a valid date for %s", fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+// MarshalJSON for this validator
+func (c *DateFormatValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule    string `json:"rule"`
+		Layout  string `json:"layout"`
+		Message string `json:"message,omitempty"`
+		Label   string `json:"label,omitempty"`
+	}{"dateFormat", c.layout, c.message, c.label})
+}
+
+// CanExport for this validator
+func (c *DateFormatValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *DateFormatValidator) Params() map[string]any {
+	return map[string]any{"layout": c.layout}
+}
+
+// DateFormat field must be a string in the layout of time.Parse, e.g. "2006-01-02". Empty strings pass, use Required
+// to require one.
+func DateFormat(layout string) *DateFormatValidator {
+	return &DateFormatValidator{
+		layout: layout,
+	}
+}
+
+//
+// ====================
+//
+
+// parseTime returns the time of a time.Time, *time.Time or string in the layout. Empty values give the zero time.
+// False is returned if the string isn't in the layout, or the value has the wrong type and the mismatch policy fails it.
+func parseTime(value any, layout string) (time.Time, bool) {
+	if str, ok := value.(string); ok {
+		if str == "" {
+			return time.Time{}, true
+		}
+		t, err := time.Parse(layout, str)
+		return t, err == nil
+	}
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return time.Time{}, true
+	}
+	if t, ok := timeValue(rv); ok {
+		return t, true
+	}
+	return time.Time{}, passMismatch(value, false)
+}
+
+// marshalTimeRule exports a time as RFC3339, or now if it's compared to the time of validation. The layout is left out
+// if it's RFC3339.
+func marshalTimeRule(rule string, t time.Time, now bool, layout string, message string, label string) ([]byte, error) {
+	var exported string
+	if !now {
+		exported = t.Format(time.RFC3339)
+	}
+	if layout == time.RFC3339 {
+		layout = ""
+	}
+	return json.Marshal(struct {
+		Rule    string `json:"rule"`
+		Time    string `json:"time,omitempty"`
+		Now     bool   `json:"now,omitempty"`
+		Layout  string `json:"layout,omitempty"`
+		Message string `json:"message,omitempty"`
+		Label   string `json:"label,omitempty"`
+	}{rule, exported, now, layout, message, label})
+}
@@ -0,0 +1,72 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeforeAfter(t *testing.T) {
+	limit := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, Before(limit).Validate(limit.Add(-time.Second)), "Before")
+	assert.NotNil(t, Before(limit).Validate(limit), "Before is exclusive")
+	assert.Nil(t, After(limit).Validate(limit.Add(time.Hour)), "After")
+	assert.NotNil(t, After(limit).Validate(limit), "After is exclusive")
+	assert.Nil(t, Before(limit).Validate("2029-12-31T23:00:00Z"), "String")
+	assert.NotNil(t, Before(limit).Validate("2029-12-31T23:00:00-02:00"), "String with offset")
+	assert.NotNil(t, Before(limit).Validate("31/12/2029"), "Wrong layout")
+	assert.Nil(t, Before(limit).Layout("02/01/2006").Validate("31/12/2029"), "Layout")
+	after := limit.Add(time.Hour)
+	assert.NotNil(t, Before(limit).Validate(&after), "Pointer")
+	assert.Nil(t, Before(limit).Validate((*time.Time)(nil)), "Nil pointer")
+	assert.Nil(t, After(limit).Validate(""), "Empty string")
+	assert.Nil(t, After(limit).Validate(time.Time{}), "Zero time")
+	assert.NotNil(t, After(limit).Validate(5), "Mismatch")
+	assert.Nil(t, AfterNow().Validate(time.Now().Add(time.Hour)), "Future")
+	assert.NotNil(t, AfterNow().Validate(time.Now().Add(-time.Hour)), "Not future")
+	assert.Nil(t, BeforeNow().Validate(time.Now().Add(-time.Hour)), "Past")
+
+	type booking struct {
+		Start time.Time `json:"start"`
+		End   string    `json:"end"`
+	}
+	b := booking{}
+	rules := New(&b).Field(&b.Start, AfterNow()).Field(&b.End, Before(limit))
+	errs := rules.Validate(booking{Start: time.Now().Add(-time.Hour), End: "2031-01-01T00:00:00Z"}).(ErrorSlice)
+	assert.Equal(t, "Please change start to be in the future", errs[0].Error(), "Now message")
+	assert.Equal(t, "Please change end to be before 2030-01-01T00:00:00Z", errs[1].Error(), "Message")
+	assert.Equal(t, "before", errs[1].(interface{ Rule() string }).Rule(), "Rule name")
+
+	j, _ := json.Marshal(After(time.Date(2030, 1, 1, 8, 0, 0, 0, time.FixedZone("", 8*3600))))
+	assert.Equal(t, `{"rule":"after","time":"2030-01-01T08:00:00+08:00"}`, string(j), "Export")
+	j, _ = json.Marshal(BeforeNow().Layout("2006-01-02"))
+	assert.Equal(t, `{"rule":"before","now":true,"layout":"2006-01-02"}`, string(j), "Export now")
+
+	exported, _ := rules.Export()
+	loaded, err := New(&b).Load([]byte(exported))
+	assert.Nil(t, err, "Load")
+	assert.Len(t, loaded.Validate(booking{Start: time.Now().Add(-time.Hour), End: "2031-01-01T00:00:00Z"}), 2, "Loaded")
+	assert.Nil(t, loaded.Validate(booking{Start: time.Now().Add(time.Hour), End: "2029-01-01T00:00:00Z"}), "Loaded passes")
+}
+
+func TestDateFormat(t *testing.T) {
+	assert.Nil(t, DateFormat("2006-01-02").Validate("2024-02-29"), "Valid")
+	assert.NotNil(t, DateFormat("2006-01-02").Validate("2023-02-29"), "Not a date")
+	assert.NotNil(t, DateFormat("2006-01-02").Validate("29/02/2024"), "Wrong layout")
+	assert.Nil(t, DateFormat("2006-01-02").Validate(""), "Empty")
+
+	type person struct {
+		Birthday string `json:"birthday"`
+	}
+	p := person{}
+	rules := New(&p).Field(&p.Birthday, DateFormat("2006-01-02"))
+	errs := rules.Validate(person{Birthday: "tomorrow"}).(ErrorSlice)
+	assert.Equal(t, "Please enter a valid date for birthday", errs[0].Error(), "Message")
+	exported, _ := rules.Export()
+	assert.JSONEq(t, `{"birthday":[{"rule":"dateFormat","layout":"2006-01-02"}]}`, string(exported), "Export")
+	loaded, err := New(&p).Load([]byte(exported))
+	assert.Nil(t, err, "Load")
+	assert.NotNil(t, loaded.Validate(person{Birthday: "tomorrow"}), "Loaded")
+}
@@ -0,0 +1,126 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// DecodeValidator decodes a raw JSON field into the struct of the given rules and validates it. Errors are reported on
+// the field with the field of the inner error appended, e.g. ["config", "host"].
+type DecodeValidator struct {
+	field   []string
+	message string
+	label   string
+	rules   Rules
+}
+
+// Field of the field
+func (c *DecodeValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *DecodeValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message for invalid JSON
+func (c *DecodeValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *DecodeValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value and return the first error
+func (c *DecodeValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value with the context and returns the first error
+func (c *DecodeValidator) ValidateCtx(ctx context.Context, value any) Error {
+	if errs := c.decode(ctx, value); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (c *DecodeValidator) resolveFields(structPtr any) {}
+
+func (c *DecodeValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *DecodeValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	value, ok := fieldValue(vmap, c.field)
+	if !ok {
+		return nil
+	}
+	return c.decode(ctx, value)
+}
+
+// decode the raw JSON and validate it. Empty values pass.
+func (c *DecodeValidator) decode(ctx context.Context, value any) ErrorSlice {
+	var data []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		data = v
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		if passMismatch(value, true) {
+			return nil
+		}
+		return ErrorSlice{c.invalid()}
+	}
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	subject := reflect.New(reflect.TypeOf(c.rules.structPtr).Elem())
+	if err := json.Unmarshal(data, subject.Interface()); err != nil {
+		return ErrorSlice{c.invalid()}
+	}
+	errs, _ := c.rules.ValidateCtx(ctx, subject.Elem().Interface()).(ErrorSlice)
+	return nestErrors(c.field, errs)
+}
+
+// invalid is the error for data that can't be decoded
+func (c *DecodeValidator) invalid() Error {
+	return createError(c.field, c.message, fmt.Sprintf("Please enter valid data for %s", fieldLabel(c.field, c.label)))
+}
+
+// CanExport for this validator
+func (c *DecodeValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *DecodeValidator) Params() map[string]any {
+	return map[string]any{"rules": c.rules}
+}
+
+// MarshalJSON for this validator
+func (c *DecodeValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule    string `json:"rule"`
+		Rules   Rules  `json:"rules"`
+		Message string `json:"message,omitempty"`
+		Label   string `json:"label,omitempty"`
+	}{"decode", c.rules, c.message, c.label})
+}
+
+// Decode validates a json.RawMessage field by decoding it into the struct of the rules. Combine with If to pick the
+// rules based on another field.
+func Decode(rules Rules) *DecodeValidator {
+	return &DecodeValidator{
+		rules: rules,
+	}
+}
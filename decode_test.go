@@ -0,0 +1,82 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	type smtp struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	type webhook struct {
+		URL string `json:"url"`
+	}
+	type integration struct {
+		Type   string          `json:"type"`
+		Config json.RawMessage `json:"config"`
+	}
+	s := smtp{}
+	w := webhook{}
+	i := integration{}
+	rules := New(&i).
+		Field(&i.Config,
+			If(&i.Type, Options("smtp")).Then(Decode(New(&s).Field(&s.Host, Required()).Field(&s.Port, Min(1)))),
+			If(&i.Type, Options("webhook")).Then(Decode(New(&w).Field(&w.URL, Required()))))
+
+	errs := rules.Validate(integration{Type: "smtp", Config: json.RawMessage(`{"port":0}`)}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Decoded and validated")
+	assert.Equal(t, []string{"config", "host"}, errs[0].Field(), "Inner field appended")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Inner rule name kept")
+	errs = rules.Validate(integration{Type: "webhook", Config: json.RawMessage(`{"port":0}`)}).(ErrorSlice)
+	assert.Equal(t, []string{"config", "url"}, errs[0].Field(), "Rules picked by sibling field")
+	assert.Nil(t, rules.Validate(integration{Type: "smtp", Config: json.RawMessage(`{"host":"mail","port":25}`)}), "Valid config")
+	assert.Nil(t, rules.Validate(integration{Type: "smtp"}), "Empty config passes")
+
+	errs = rules.Validate(integration{Type: "smtp", Config: json.RawMessage(`{"port":"x"}`)}).(ErrorSlice)
+	assert.Equal(t, "Please enter valid data for config", errs[0].Error(), "Invalid JSON")
+	assert.Equal(t, "decode", errs[0].(interface{ Rule() string }).Rule(), "Decode rule name")
+
+	j, _ := json.Marshal(Decode(New(&w).Field(&w.URL, Required())))
+	assert.Equal(t, `{"rule":"decode","rules":{"url":[{"rule":"required"}]}}`, string(j), "Export")
+}
+
+func TestDecodeMismatch(t *testing.T) {
+	defer Configure(CurrentConfig())
+	type webhook struct {
+		URL string `json:"url"`
+	}
+	type integration struct {
+		Config any `json:"config"`
+	}
+	w := webhook{}
+	i := integration{}
+	rules := New(&i).Field(&i.Config, Decode(New(&w).Field(&w.URL, Required())))
+	assert.Nil(t, rules.Validate(integration{Config: 1}), "Wrong type skipped by default")
+	Configure(Config{MismatchPolicy: MismatchFail})
+	errs := rules.Validate(integration{Config: 1}).(ErrorSlice)
+	assert.Equal(t, "Please enter valid data for config", errs[0].Error(), "Wrong type fails")
+}
+
+func TestDecodeContext(t *testing.T) {
+	type account struct {
+		VAT string `json:"vat"`
+	}
+	type signup struct {
+		Account json.RawMessage `json:"account"`
+	}
+	a := account{}
+	s := signup{}
+	calls := 0
+	rules := New(&s).Field(&s.Account, Decode(New(&a).Field(&a.VAT, Remote("", RemoteOptions{Checker: vatChecker{&calls}}))))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := rules.ValidateCtx(ctx, signup{Account: json.RawMessage(`{"vat":"slow"}`)})
+	assert.True(t, errors.Is(err, ErrTimeout), "Request context used")
+}
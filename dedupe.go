@@ -0,0 +1,43 @@
+package xvalid
+
+import (
+	"strings"
+)
+
+// DedupeMode decides which errors are kept when a field has more than one error
+type DedupeMode int
+
+const (
+	// DedupeNone keeps all errors
+	DedupeNone DedupeMode = iota
+	// DedupeMessages keeps one error of each message per field
+	DedupeMessages
+	// DedupeFields keeps only the first error of each field. Validators added earlier have higher priority.
+	DedupeFields
+)
+
+// Dedupe removes overlapping errors of the same field, e.g. when Required and MinLength both fail on an empty string
+func (r Rules) Dedupe(mode DedupeMode) Rules {
+	r.dedupe = mode
+	return r
+}
+
+// dedupe removes errors according to the mode, keeping the order of the remaining errors
+func dedupe(errs ErrorSlice, mode DedupeMode) ErrorSlice {
+	if mode == DedupeNone {
+		return errs
+	}
+	seen := make(map[string]bool)
+	result := make(ErrorSlice, 0, len(errs))
+	for _, err := range errs {
+		key := strings.Join(err.Field(), ".")
+		if mode == DedupeMessages {
+			key += "\x00" + err.Error()
+		}
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, err)
+		}
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupe(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+		Nick string `json:"nick"`
+	}
+	u := user{}
+	rules := New(&u).
+		Field(&u.Name, Required(), MinLength(3), Pattern("^[a-z]+$").SetMessage("Please enter the name")).
+		Field(&u.Nick, MinLength(2), MaxLength(1))
+	assert.Len(t, rules.Validate(user{Nick: "abc"}), 4, "No dedupe by default")
+
+	errs := rules.Dedupe(DedupeMessages).Validate(user{Nick: "abc"}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Same message removed")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "First error kept")
+
+	errs = rules.Dedupe(DedupeFields).Validate(user{Nick: "abc"}).(ErrorSlice)
+	assert.Len(t, errs, 2, "One error for each field")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "First error of field kept")
+	assert.Equal(t, []string{"nick"}, errs[1].Field(), "Other field kept")
+}
@@ -0,0 +1,143 @@
+package xvalid
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// WordProvider decides whether a word is denied. Words are given in lower case with common leetspeak replaced, e.g.
+// "h4ck3r" is given as "hacker".
+type WordProvider interface {
+	Denied(word string) bool
+}
+
+// wordSet is a WordProvider of a fixed list of words, also kept with repeated letters squeezed
+type wordSet struct {
+	words    map[string]struct{}
+	squeezed map[string]struct{}
+}
+
+// Denied checks the word as is and, if it repeats letters, with them squeezed on both sides, so "baad" matches "bad"
+// and "aass" matches "ass"
+func (s wordSet) Denied(word string) bool {
+	if _, ok := s.words[word]; ok {
+		return true
+	}
+	squeezed := squeezeRepeats(word, 1)
+	if squeezed == word {
+		return false
+	}
+	_, ok := s.squeezed[squeezed]
+	return ok
+}
+
+// WordList is a WordProvider that denies the words, which are normalized the same way as the text
+func WordList(words ...string) WordProvider {
+	s := wordSet{make(map[string]struct{}, len(words)), make(map[string]struct{}, len(words))}
+	for _, w := range words {
+		w = normalizeWord(w)
+		s.words[w] = struct{}{}
+		s.squeezed[squeezeRepeats(w, 1)] = struct{}{}
+	}
+	return s
+}
+
+var leetspeak = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i")
+
+// normalizeWord lowers the case, replaces leetspeak and shortens letters repeated more than twice
+func normalizeWord(word string) string {
+	return squeezeRepeats(leetspeak.Replace(strings.ToLower(word)), 2)
+}
+
+// squeezeRepeats shortens runs of the same letter to at most max letters
+func squeezeRepeats(word string, max int) string {
+	var b strings.Builder
+	var last rune
+	repeat := 0
+	for _, r := range word {
+		if r == last {
+			repeat++
+		} else {
+			last, repeat = r, 0
+		}
+		if repeat < max {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// splitWords splits text on anything that isn't a letter or a character used in leetspeak
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("@$!", r)
+	})
+}
+
+// DenyWordsValidator field must not contain denied words
+type DenyWordsValidator struct {
+	field    []string
+	message  string
+	label    string
+	provider WordProvider
+}
+
+// Field of the field
+func (c *DenyWordsValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *DenyWordsValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *DenyWordsValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *DenyWordsValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value
+func (c *DenyWordsValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, true) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please remove inappropriate words from %s", fieldLabel(c.field, c.label)))
+	}
+	for _, word := range splitWords(str) {
+		// symbols at the ends are more likely punctuation than leetspeak
+		if c.provider.Denied(normalizeWord(word)) || c.provider.Denied(normalizeWord(strings.Trim(word, "@$!"))) {
+			return createError(c.field, c.message, fmt.Sprintf("Please remove inappropriate words from %s", fieldLabel(c.field, c.label)))
+		}
+	}
+	return nil
+}
+
+// CanExport for this validator. Word lists stay on the server.
+func (c *DenyWordsValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *DenyWordsValidator) Params() map[string]any {
+	return map[string]any{"provider": c.provider}
+}
+
+// DenyWords field must not contain any word denied by the provider, e.g. for moderating free text. Words are compared
+// in lower case with leetspeak replaced and long runs of a letter shortened, so "B4DDDD" is checked as "badd".
+// WordList also matches words with all repeated letters squeezed, so "badd" is denied by "bad".
+func DenyWords(provider WordProvider) *DenyWordsValidator {
+	return &DenyWordsValidator{
+		provider: provider,
+	}
+}
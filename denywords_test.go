@@ -0,0 +1,35 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type prefixProvider string
+
+func (p prefixProvider) Denied(word string) bool {
+	return len(word) >= len(p) && word[:len(p)] == string(p)
+}
+
+func TestDenyWords(t *testing.T) {
+	type post struct {
+		Body string `json:"body"`
+	}
+	p := post{}
+	rules := New(&p).Field(&p.Body, DenyWords(WordList("badword", "Spam", "ass")))
+	assert.Nil(t, rules.Validate(post{Body: "A perfectly fine comment"}), "Clean text")
+	errs := rules.Validate(post{Body: "What a badword!"}).(ErrorSlice)
+	assert.Equal(t, "Please remove inappropriate words from body", errs[0].Error(), "Denied word")
+	assert.Len(t, rules.Validate(post{Body: "buy SP4M now"}), 1, "Case and leetspeak")
+	assert.Len(t, rules.Validate(post{Body: "b@dw0rd"}), 1, "Leetspeak symbols")
+	assert.Len(t, rules.Validate(post{Body: "spaaaaam"}), 1, "Repeated letters")
+	assert.Nil(t, rules.Validate(post{Body: "as bass class"}), "Only whole words")
+	assert.Len(t, rules.Validate(post{Body: "a$$"}), 1, "Symbols as letters")
+	assert.Len(t, rules.Validate(post{Body: "aassss"}), 1, "Repeated letters of a word with double letters")
+	assert.Len(t, rules.Validate(post{Body: "baddword"}), 1, "Repeated letters of a word without double letters")
+	assert.False(t, DenyWords(WordList()).CanExport(), "Not exportable")
+
+	rules = New(&p).Field(&p.Body, DenyWords(prefixProvider("scam")))
+	assert.Len(t, rules.Validate(post{Body: "total SCAMMER"}), 1, "Custom provider")
+}
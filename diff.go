@@ -0,0 +1,100 @@
+package xvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Kinds of RuleChange
+const (
+	RuleAdded   = "added"
+	RuleRemoved = "removed"
+	RuleChanged = "changed"
+)
+
+// RuleChange is a difference between two sets of rules
+type RuleChange struct {
+	// Field name as exported
+	Field string `json:"field"`
+	// Rule name as exported
+	Rule string `json:"rule"`
+	// Kind of change: RuleAdded, RuleRemoved or RuleChanged
+	Kind string `json:"kind"`
+	// Old exported rule, empty if added
+	Old json.RawMessage `json:"old,omitempty"`
+	// New exported rule, empty if removed
+	New json.RawMessage `json:"new,omitempty"`
+}
+
+// Diff compares the exported form of two sets of rules and reports the rules that were added, removed or changed for
+// each field. Rules that can't be exported are not compared. Changes are sorted by field and rule.
+func Diff(oldRules Rules, newRules Rules) ([]RuleChange, error) {
+	before, err := exportedRules(oldRules)
+	if err != nil {
+		return nil, err
+	}
+	after, err := exportedRules(newRules)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]RuleChange, 0)
+	for key, o := range before {
+		n, ok := after[key]
+		if !ok {
+			changes = append(changes, RuleChange{Field: key.field, Rule: key.rule, Kind: RuleRemoved, Old: o})
+		} else if !bytes.Equal(o, n) {
+			changes = append(changes, RuleChange{Field: key.field, Rule: key.rule, Kind: RuleChanged, Old: o, New: n})
+		}
+	}
+	for key, n := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, RuleChange{Field: key.field, Rule: key.rule, Kind: RuleAdded, New: n})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Field != changes[j].Field {
+			return changes[i].Field < changes[j].Field
+		}
+		return changes[i].Rule < changes[j].Rule
+	})
+	return changes, nil
+}
+
+// ruleKey identifies an exported rule. Repeated rules on the same field are numbered, e.g. "pattern#2".
+type ruleKey struct {
+	field string
+	rule  string
+}
+
+// exportedRules returns the exported JSON of each rule
+func exportedRules(rules Rules) (map[ruleKey]json.RawMessage, error) {
+	b, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string][]json.RawMessage)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	result := make(map[ruleKey]json.RawMessage)
+	for field, list := range fields {
+		count := make(map[string]int)
+		for _, raw := range list {
+			var r struct {
+				Rule string `json:"rule"`
+			}
+			if err := json.Unmarshal(raw, &r); err != nil {
+				return nil, err
+			}
+			count[r.Rule]++
+			name := r.Rule
+			if count[r.Rule] > 1 {
+				name = fmt.Sprintf("%s#%d", r.Rule, count[r.Rule])
+			}
+			result[ruleKey{field, name}] = raw
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type diffType struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Age   int    `json:"age"`
+	}
+	d := diffType{}
+	oldRules := New(&d).
+		Field(&d.Name, Required(), MaxLength(20)).
+		Field(&d.Email, MinLength(3)).
+		Field(&d.Age, Min(18), Pattern(`a`), Pattern(`b`))
+	newRules := New(&d).
+		Field(&d.Name, Required(), MaxLength(10)).
+		Field(&d.Email, Required()).
+		Field(&d.Age, Min(18), Pattern(`a`), Pattern(`c`))
+	changes, err := Diff(oldRules, newRules)
+	assert.Nil(t, err, "Diffed")
+	j, _ := json.Marshal(changes)
+	assert.Equal(t,
+		`[{"field":"age","rule":"pattern#2","kind":"changed","old":{"rule":"pattern","pattern":"b"},"new":{"rule":"pattern","pattern":"c"}},`+
+			`{"field":"email","rule":"minLength","kind":"removed","old":{"rule":"minLength","min":3}},`+
+			`{"field":"email","rule":"required","kind":"added","new":{"rule":"required"}},`+
+			`{"field":"name","rule":"maxLength","kind":"changed","old":{"rule":"maxLength","max":20},"new":{"rule":"maxLength","max":10}}]`,
+		string(j), "Changes")
+
+	changes, _ = Diff(oldRules, oldRules)
+	assert.Empty(t, changes, "No changes")
+}
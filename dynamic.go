@@ -0,0 +1,116 @@
+package xvalid
+
+import (
+	"reflect"
+)
+
+// DynamicValidator validates an interface-typed field using the rules registered for the concrete type of its value.
+// Errors are reported on the field with the field of the inner error appended, e.g. ["payload", "amount"].
+type DynamicValidator struct {
+	field    []string
+	rules    map[reflect.Type]Rules
+	fallback []Validator
+}
+
+// Field of the field
+func (c *DynamicValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *DynamicValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.fallback {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of the fallback validators
+func (c *DynamicValidator) SetMessage(msg string) Validator {
+	for _, v := range c.fallback {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// Fallback validators are used when the value doesn't match any of the registered types. They receive the
+// concrete value, so FieldFunc can switch on its type.
+func (c *DynamicValidator) Fallback(validators ...Validator) *DynamicValidator {
+	for _, v := range validators {
+		v.SetField(c.field...)
+	}
+	c.fallback = append(c.fallback, validators...)
+	return c
+}
+
+// Validate the value and return the first error
+func (c *DynamicValidator) Validate(value any) Error {
+	if errs := c.validateValue(value); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (c *DynamicValidator) resolveFields(structPtr any) {}
+
+func (c *DynamicValidator) validateFields(vmap map[string]any) ErrorSlice {
+	value, ok := fieldValue(vmap, c.field)
+	if !ok {
+		return nil
+	}
+	return c.validateValue(value)
+}
+
+// validateValue dispatches to the rules of the concrete type, or the fallback validators if there are none
+func (c *DynamicValidator) validateValue(value any) ErrorSlice {
+	errs := make(ErrorSlice, 0)
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return errs
+	}
+	rules, ok := c.rules[v.Type()]
+	if !ok && v.Kind() == reflect.Ptr {
+		rules, ok = c.rules[v.Type().Elem()]
+	}
+	if !ok {
+		for _, validator := range c.fallback {
+			if err := validator.Validate(value); err != nil {
+				errs = append(errs, setRule(ErrorSlice{err}, ruleName(validator))...)
+			}
+		}
+		return errs
+	}
+	inner, _ := rules.Validate(value).(ErrorSlice)
+	return append(errs, nestErrors(c.field, inner)...)
+}
+
+// CanExport for this validator
+func (c *DynamicValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *DynamicValidator) Params() map[string]any {
+	return map[string]any{"rules": c.rules, "fallback": c.fallback}
+}
+
+func (c *DynamicValidator) clone() Validator {
+	clone := *c
+	clone.fallback = make([]Validator, len(c.fallback))
+	for i, v := range c.fallback {
+		clone.fallback[i] = cloneValidator(v)
+	}
+	return &clone
+}
+
+// Dynamic validates an interface-typed field with the rules registered for the concrete type of its value. Values of
+// other types pass unless Fallback validators are given.
+func Dynamic(rules ...Rules) *DynamicValidator {
+	c := &DynamicValidator{
+		rules: make(map[reflect.Type]Rules, len(rules)),
+	}
+	for _, r := range rules {
+		c.rules[reflect.TypeOf(r.structPtr).Elem()] = r
+	}
+	return c
+}
@@ -0,0 +1,49 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamic(t *testing.T) {
+	type card struct {
+		Number string `json:"number"`
+	}
+	type transfer struct {
+		Amount int `json:"amount"`
+	}
+	type payment struct {
+		Payload any `json:"payload"`
+	}
+	c := card{}
+	tr := transfer{}
+	p := payment{}
+	var seen any
+	rules := New(&p).Field(&p.Payload, Dynamic(
+		New(&c).Field(&c.Number, Required()),
+		New(&tr).Field(&tr.Amount, Min(1)),
+	).Fallback(FieldFunc(func(field []string, value any) Error {
+		seen = value
+		if _, ok := value.(string); ok {
+			return nil
+		}
+		return NewError("Unsupported payload", field...)
+	})))
+
+	errs := rules.Validate(payment{Payload: card{}}).(ErrorSlice)
+	assert.Len(t, errs, 1, "Rules of concrete type")
+	assert.Equal(t, []string{"payload", "number"}, errs[0].Field(), "Inner field appended")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Rule name kept")
+	errs = rules.Validate(payment{Payload: &transfer{}}).(ErrorSlice)
+	assert.Equal(t, []string{"payload", "amount"}, errs[0].Field(), "Pointer to concrete type")
+	assert.Nil(t, rules.Validate(payment{Payload: card{Number: "4242"}}), "Valid concrete value")
+	assert.Nil(t, rules.Validate(payment{}), "Nil interface passes")
+
+	assert.Nil(t, rules.Validate(payment{Payload: "text"}), "Fallback passes")
+	assert.Equal(t, "text", seen, "Fallback receives concrete value")
+	errs = rules.Validate(payment{Payload: 3}).(ErrorSlice)
+	assert.Equal(t, "Unsupported payload", errs[0].Error(), "Fallback fails")
+	assert.Equal(t, []string{"payload"}, errs[0].Field(), "Fallback field")
+	assert.False(t, Dynamic().CanExport(), "Not exportable")
+}
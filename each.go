@@ -0,0 +1,140 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EachValidator applies validators to every element of a slice or array field. Errors are reported on the field with
+// the index appended, e.g. ["tags", "3"], which is encoded as "tags[3]" in JSON.
+type EachValidator struct {
+	field      []string
+	validators []Validator
+}
+
+// Field of the field
+func (c *EachValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *EachValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.validators {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators
+func (c *EachValidator) SetMessage(msg string) Validator {
+	for _, v := range c.validators {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *EachValidator) SetLabel(label string) Validator {
+	for _, v := range c.validators {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Validate the value and return the first error
+func (c *EachValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value with the context and returns the first error
+func (c *EachValidator) ValidateCtx(ctx context.Context, value any) Error {
+	if errs := c.validateElements(ctx, value); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (c *EachValidator) resolveFields(structPtr any) {}
+
+func (c *EachValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *EachValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	value, ok := fieldValue(vmap, c.field)
+	if !ok {
+		return nil
+	}
+	return c.validateElements(ctx, value)
+}
+
+// validateElements validates each element in order
+func (c *EachValidator) validateElements(ctx context.Context, value any) ErrorSlice {
+	errs := make(ErrorSlice, 0)
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return errs
+	}
+	for i := 0; i < v.Len(); i++ {
+		element := elementValue(v.Index(i))
+		for _, validator := range c.validators {
+			if err := validateValue(ctx, validator, element); err != nil {
+				err = withField(err, elementField(c.field, strconv.Itoa(i), err.Field()))
+				errs = append(errs, setRule(ErrorSlice{err}, ruleName(validator))...)
+			}
+		}
+	}
+	return errs
+}
+
+// elementField is the field of the element with the key appended, followed by any field the error reported below the
+// parent, e.g. ["items", "0", "name"] for an error on ["items", "name"]
+func elementField(parent []string, key string, errField []string) []string {
+	field := append(append(make([]string, 0, len(parent)+1), parent...), key)
+	if len(errField) > len(parent) && strings.Join(errField[:len(parent)], ".") == strings.Join(parent, ".") {
+		field = append(field, errField[len(parent):]...)
+	}
+	return field
+}
+
+// CanExport for this validator
+func (c *EachValidator) CanExport() bool {
+	for _, v := range c.validators {
+		if !v.CanExport() {
+			return false
+		}
+	}
+	return true
+}
+
+// Params of this validator
+func (c *EachValidator) Params() map[string]any {
+	return map[string]any{"validators": c.validators}
+}
+
+// MarshalJSON for this validator
+func (c *EachValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule  string      `json:"rule"`
+		Rules []Validator `json:"rules"`
+	}{"each", c.validators})
+}
+
+func (c *EachValidator) clone() Validator {
+	clone := *c
+	clone.validators = make([]Validator, len(c.validators))
+	for i, v := range c.validators {
+		clone.validators[i] = cloneValidator(v)
+	}
+	return &clone
+}
+
+// Each applies validators to every element of a slice or array field, e.g. Field(&u.Tags, Each(MinLength(2)))
+func Each(validators ...Validator) *EachValidator {
+	return &EachValidator{
+		validators: validators,
+	}
+}
@@ -0,0 +1,65 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEach(t *testing.T) {
+	type eachType struct {
+		Tags   []string  `json:"tags"`
+		Scores [2]int    `json:"scores"`
+		Notes  []*string `json:"notes"`
+	}
+	e := eachType{}
+	rules := New(&e).
+		Field(&e.Tags, MaxItems(3), Each(MinLength(2), MaxLength(5))).
+		Field(&e.Scores, Each(Max(100))).
+		Field(&e.Notes, Each(Required()))
+	assert.Nil(t, rules.Validate(eachType{}), "Empty slices")
+	note := "ok"
+	assert.Nil(t, rules.Validate(eachType{Tags: []string{"go", "json"}, Notes: []*string{&note}}), "Valid elements")
+
+	errs := rules.Validate(eachType{
+		Tags:   []string{"go", "x", "toolong"},
+		Scores: [2]int{50, 101},
+		Notes:  []*string{&note, nil},
+	}).(ErrorSlice)
+	assert.Len(t, errs, 4, "Invalid elements")
+	assert.Equal(t, []string{"tags", "1"}, errs[0].Field(), "Index in field")
+	assert.Equal(t, "Please lengthen tags to 2 characters or more", errs[0].Error(), "Element message")
+	assert.Equal(t, "minLength", errs[0].(interface{ Rule() string }).Rule(), "Element rule")
+	assert.Equal(t, []string{"tags", "2"}, errs[1].Field(), "Index in field")
+	assert.Equal(t, []string{"scores", "1"}, errs[2].Field(), "Array element")
+	assert.Equal(t, []string{"notes", "1"}, errs[3].Field(), "Nil pointer element")
+	assert.Contains(t, errs.ToMap(), "tags[1]", "Indexed JSON path")
+
+	j, _ := json.Marshal(New(&e).Field(&e.Tags, Each(MinLength(2))))
+	assert.Equal(t, `{"tags":[{"rule":"each","rules":[{"rule":"minLength","min":2}]}]}`, string(j), "Export")
+}
+
+func TestEachInnerErrors(t *testing.T) {
+	type gridType struct {
+		Grid [][]string `json:"grid"`
+		IDs  []string   `json:"ids"`
+	}
+	g := gridType{}
+	calls := 0
+	rules := New(&g).
+		Field(&g.Grid, Each(Each(MinLength(2)))).
+		Field(&g.IDs, Each(Remote("", RemoteOptions{Checker: vatChecker{&calls}})))
+
+	errs := rules.Validate(gridType{Grid: [][]string{{"ok"}, {"ok", "x"}}}).(ErrorSlice)
+	assert.Len(t, errs, 1, "Nested element")
+	assert.Equal(t, []string{"grid", "1", "1"}, errs[0].Field(), "Inner index kept")
+	assert.Equal(t, "minLength", errs[0].(interface{ Rule() string }).Rule(), "Inner rule kept")
+	assert.Contains(t, errs.ToMap(), "grid[1][1]", "Nested JSON path")
+
+	errs = rules.Validate(gridType{IDs: []string{"broken"}}).(ErrorSlice)
+	assert.Len(t, errs, 1, "Broken checker")
+	assert.Equal(t, []string{"ids", "0"}, errs[0].Field(), "Index in field")
+	assert.True(t, errors.Is(errs, ErrInternal), "Internal error kept")
+}
@@ -0,0 +1,191 @@
+package xvalid
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+)
+
+// RuleExporter is implemented by validators that export differently in Rules.MarshalJSON than their own JSON
+// encoding, e.g. to add hints for the UI
+type RuleExporter interface {
+	// ExportRule returns the value to encode for this validator
+	ExportRule() (any, error)
+}
+
+// ExportFuncValidator overrides how a validator is exported
+type ExportFuncValidator struct {
+	validator Validator
+	export    func(rule map[string]any) any
+}
+
+// Field of the field
+func (c *ExportFuncValidator) Field() []string {
+	return c.validator.Field()
+}
+
+// SetField of the field
+func (c *ExportFuncValidator) SetField(name ...string) {
+	c.validator.SetField(name...)
+}
+
+// SetMessage set error message
+func (c *ExportFuncValidator) SetMessage(msg string) Validator {
+	c.validator.SetMessage(msg)
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ExportFuncValidator) SetLabel(label string) Validator {
+	setLabel(c.validator, label)
+	return c
+}
+
+// Validate the value with the wrapped validator
+func (c *ExportFuncValidator) Validate(value any) Error {
+	err := c.validator.Validate(value)
+	if err != nil {
+		setRule(ErrorSlice{err}, ruleName(c.validator))
+	}
+	return err
+}
+
+// CanExport for this validator
+func (c *ExportFuncValidator) CanExport() bool {
+	return true
+}
+
+// Params of the wrapped validator
+func (c *ExportFuncValidator) Params() map[string]any {
+	return Params(c.validator)
+}
+
+// ExportRule passes the exported rule of the wrapped validator to the export function
+func (c *ExportFuncValidator) ExportRule() (any, error) {
+	rule := make(map[string]any)
+	if c.validator.CanExport() {
+		b, err := json.Marshal(c.validator)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &rule); err != nil {
+			return nil, err
+		}
+	}
+	return c.export(rule), nil
+}
+
+// MarshalJSON for this validator
+func (c *ExportFuncValidator) MarshalJSON() ([]byte, error) {
+	rule, err := c.ExportRule()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rule)
+}
+
+func (c *ExportFuncValidator) clone() Validator {
+	clone := *c
+	clone.validator = cloneValidator(c.validator)
+	return &clone
+}
+
+// ExportFunc changes how a validator is exported. The function receives the rule the validator would export, which is
+// empty if the validator can't be exported, and returns the value to export instead.
+func ExportFunc(validator Validator, export func(rule map[string]any) any) *ExportFuncValidator {
+	return &ExportFuncValidator{
+		validator: validator,
+		export:    export,
+	}
+}
+
+// ExportOption changes which rules are exported
+type ExportOption func(*exportConfig)
+
+// exportConfig of an export
+type exportConfig struct {
+	only    map[string]bool
+	exclude map[string]bool
+	types   bool
+	compact bool
+}
+
+// OnlyFields exports only the rules of the given fields
+func OnlyFields(names ...string) ExportOption {
+	return func(c *exportConfig) {
+		if c.only == nil {
+			c.only = make(map[string]bool)
+		}
+		for _, n := range names {
+			c.only[n] = true
+		}
+	}
+}
+
+// ExcludeFields doesn't export the rules of the given fields, e.g. internal fields that public clients shouldn't see
+func ExcludeFields(names ...string) ExportOption {
+	return func(c *exportConfig) {
+		if c.exclude == nil {
+			c.exclude = make(map[string]bool)
+		}
+		for _, n := range names {
+			c.exclude[n] = true
+		}
+	}
+}
+
+// WithTypes exports each field as an object with the JSON type of the field and its rules, e.g.
+// {"name": {"type": "string", "rules": [...]}}. The type is one of "string", "number", "boolean", "array" or "object",
+// and is left out if it can't be derived.
+func WithTypes() ExportOption {
+	return func(c *exportConfig) {
+		c.types = true
+	}
+}
+
+// fieldType returns the JSON type of a field
+func (r Rules) fieldType(field []string) string {
+	if len(field) == 0 {
+		return ""
+	}
+	f, ok := settableField(reflect.New(reflect.TypeOf(r.structPtr).Elem()).Elem(), field)
+	if !ok {
+		return ""
+	}
+	return jsonType(f.Type())
+}
+
+// jsonType returns the type of the JSON value a Go type is encoded as
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()) {
+		return "string"
+	}
+	if t.Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) {
+		// can be encoded as anything
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// base64 encoded
+			return "string"
+		}
+		return "array"
+	case reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return ""
+}
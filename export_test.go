@@ -0,0 +1,99 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hintValidator struct {
+	RequiredValidator
+}
+
+func (h *hintValidator) ExportRule() (any, error) {
+	return map[string]any{"rule": "required", "hint": "asterisk"}, nil
+}
+
+func TestExportFunc(t *testing.T) {
+	type user struct {
+		Email string `json:"email"`
+		Code  string `json:"code"`
+	}
+	u := user{}
+	rules := New(&u).
+		Field(&u.Email, ExportFunc(Email(), func(rule map[string]any) any {
+			rule["inputType"] = "email"
+			rule["placeholder"] = "you@example.com"
+			delete(rule, "pattern")
+			return rule
+		})).
+		Field(&u.Code, ExportFunc(FieldFunc(func([]string, any) Error { return nil }), func(rule map[string]any) any {
+			return map[string]any{"rule": "custom", "name": "code"}
+		}), &hintValidator{})
+	j, _ := json.Marshal(rules)
+	assert.JSONEq(t, `{
+		"email":[{"rule":"type","type":"email","inputType":"email","placeholder":"you@example.com"}],
+		"code":[{"rule":"custom","name":"code"},{"rule":"required","hint":"asterisk"}]
+	}`, string(j), "Exported with hooks")
+
+	errs := rules.Validate(user{Email: "bad"}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Wrapped validators still validate")
+	assert.Equal(t, "email", errs[0].(interface{ Rule() string }).Rule(), "Rule of wrapped validator")
+}
+
+func TestExport(t *testing.T) {
+	type user struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+		Notes string `json:"notes"`
+	}
+	u := user{}
+	rules := New(&u).
+		Field(&u.Email, Required()).
+		Field(&u.Name, Required()).
+		Field(&u.Notes, MaxLength(10))
+	j, _ := rules.Export(OnlyFields("email", "name"))
+	assert.JSONEq(t, `{"email":[{"rule":"required"}],"name":[{"rule":"required"}]}`, string(j), "Only fields")
+	j, _ = rules.Export(ExcludeFields("notes", "name"))
+	assert.JSONEq(t, `{"email":[{"rule":"required"}]}`, string(j), "Exclude fields")
+	j, _ = rules.Export(OnlyFields("email", "notes"), ExcludeFields("notes"))
+	assert.JSONEq(t, `{"email":[{"rule":"required"}]}`, string(j), "Combined options")
+	all, _ := json.Marshal(rules)
+	j, _ = rules.Export()
+	assert.JSONEq(t, string(all), string(j), "Same as MarshalJSON without options")
+}
+
+func TestExportTypes(t *testing.T) {
+	type Embed struct {
+		Tags []string `json:"tags"`
+	}
+	type item struct {
+		Embed
+		Name    string            `json:"name"`
+		Price   *float64          `json:"price"`
+		Active  bool              `json:"active"`
+		Created time.Time         `json:"created"`
+		Extra   json.RawMessage   `json:"extra"`
+		Meta    map[string]string `json:"meta"`
+	}
+	i := item{}
+	rules := New(&i).
+		Field(&i.Name, Required(), MaxLength(5)).
+		Field(&i.Price, Required()).
+		Field(&i.Active, Required()).
+		Field(&i.Created, Required()).
+		Field(&i.Extra, Required()).
+		Field(&i.Meta, Required()).
+		Field(&i.Tags, Required())
+	j, _ := rules.Export(WithTypes(), ExcludeFields("meta"))
+	assert.JSONEq(t, `{
+		"name":{"type":"string","rules":[{"rule":"required"},{"rule":"maxLength","max":5}]},
+		"price":{"type":"number","rules":[{"rule":"required"}]},
+		"active":{"type":"boolean","rules":[{"rule":"required"}]},
+		"created":{"type":"string","rules":[{"rule":"required"}]},
+		"extra":{"rules":[{"rule":"required"}]},
+		"tags":{"type":"array","rules":[{"rule":"required"}]}
+	}`, string(j), "Export with types")
+}
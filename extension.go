@@ -0,0 +1,89 @@
+package xvalid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Extension describes a validator of another package, so validator packs can be loaded with Rules.Load and
+// Rules.LoadYAML and exported like the built in validators
+type Extension struct {
+	// Name of the rule, which is the "rule" key of the exported JSON
+	Name string
+	// Param is the key a value is assigned to when the rule is written as "name: value" in YAML
+	Param string
+	// Load creates the validator from its exported JSON. The message, label and optional keys are applied after.
+	Load func(data []byte) (Validator, error)
+	// Validator is an instance of the validator, used to find the extension when exporting. Only needed for Export.
+	Validator Validator
+	// Export returns the value to encode for the validator in Rules.MarshalJSON. The validator is encoded as is if it
+	// is nil.
+	Export func(v Validator) (any, error)
+}
+
+var (
+	extensions      = make(map[string]Extension)
+	extensionTypes  = make(map[reflect.Type]Extension)
+	extensionsMutex sync.RWMutex
+)
+
+// Register adds a validator of another package. It returns an error if a rule of the same name is already registered,
+// so packs can't replace each other's rules by accident. It should be called on startup.
+func Register(ext Extension) error {
+	if ext.Name == "" || ext.Load == nil {
+		return errors.New("extension needs a name and a load function")
+	}
+	if ext.Export != nil && ext.Validator == nil {
+		return fmt.Errorf("extension %s needs a validator to export", ext.Name)
+	}
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+	if _, ok := extensions[ext.Name]; ok {
+		return fmt.Errorf("rule already registered: %s", ext.Name)
+	}
+	extensions[ext.Name] = ext
+	if ext.Validator != nil {
+		extensionTypes[reflect.TypeOf(ext.Validator)] = ext
+	}
+	return nil
+}
+
+// RegisterRule adds or replaces how a rule is loaded by Rules.Load. The function gets the rule as exported, e.g.
+// {"rule": "maxLength", "max": 50}, and returns its validator. Use Register to also set how the rule is written in YAML
+// and exported. It should be called on startup.
+func RegisterRule(name string, load func(data []byte) (Validator, error)) {
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+	ext := extensions[name]
+	ext.Name, ext.Load = name, load
+	extensions[name] = ext
+}
+
+// Extensions returns the registered rules, including the built in ones, sorted by name
+func Extensions() []Extension {
+	extensionsMutex.RLock()
+	defer extensionsMutex.RUnlock()
+	list := make([]Extension, 0, len(extensions))
+	for _, ext := range extensions {
+		list = append(list, ext)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func lookupExtension(name string) (Extension, bool) {
+	extensionsMutex.RLock()
+	defer extensionsMutex.RUnlock()
+	ext, ok := extensions[name]
+	return ext, ok
+}
+
+// extensionExport returns the export function registered for the type of the validator
+func extensionExport(v Validator) func(v Validator) (any, error) {
+	extensionsMutex.RLock()
+	defer extensionsMutex.RUnlock()
+	return extensionTypes[reflect.TypeOf(v)].Export
+}
@@ -0,0 +1,74 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ibanValidator stands in for a validator of another package
+type ibanValidator struct {
+	field   []string
+	message string
+	country string
+}
+
+func (c *ibanValidator) Field() []string         { return c.field }
+func (c *ibanValidator) SetField(name ...string) { c.field = name }
+func (c *ibanValidator) CanExport() bool         { return true }
+func (c *ibanValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+func (c *ibanValidator) Validate(value any) Error {
+	if s, _ := value.(string); !strings.HasPrefix(s, c.country) {
+		return createError(c.field, c.message, "Please enter a valid IBAN")
+	}
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	err := Register(Extension{
+		Name:  "iban",
+		Param: "country",
+		Load: func(data []byte) (Validator, error) {
+			var r struct {
+				Country string `json:"country"`
+			}
+			err := json.Unmarshal(data, &r)
+			return &ibanValidator{country: r.Country}, err
+		},
+		Validator: &ibanValidator{},
+		Export: func(v Validator) (any, error) {
+			return map[string]any{"rule": "iban", "country": v.(*ibanValidator).country}, nil
+		},
+	})
+	assert.Nil(t, err, "Register")
+	assert.EqualError(t, Register(Extension{Name: "iban", Load: func([]byte) (Validator, error) { return nil, nil }}),
+		"rule already registered: iban", "Duplicate name")
+	assert.EqualError(t, Register(Extension{Name: "maxLength", Load: func([]byte) (Validator, error) { return nil, nil }}),
+		"rule already registered: maxLength", "Built in name")
+	assert.NotNil(t, Register(Extension{Name: "noLoad"}), "Missing load")
+	assert.NotNil(t, Register(Extension{Name: "noValidator", Load: func([]byte) (Validator, error) { return nil, nil },
+		Export: func(Validator) (any, error) { return nil, nil }}), "Export without validator")
+
+	type payment struct {
+		Account string `json:"account"`
+	}
+	p := payment{}
+	rules, err := New(&p).LoadYAML([]byte("account:\n  - iban: DE\n    message: Wrong account\n"))
+	assert.Nil(t, err, "Load YAML shorthand")
+	errs := rules.Validate(payment{Account: "FR123"}).(ErrorSlice)
+	assert.Equal(t, "Wrong account", errs[0].Error(), "Loaded extension")
+	j, _ := json.Marshal(rules)
+	assert.Equal(t, `{"account":[{"country":"DE","rule":"iban"}]}`, string(j), "Export extension")
+
+	names := make([]string, 0)
+	for _, ext := range Extensions() {
+		names = append(names, ext.Name)
+	}
+	assert.Contains(t, names, "iban", "List extension")
+	assert.Contains(t, names, "maxLength", "List built in rule")
+}
@@ -0,0 +1,107 @@
+package xvalid
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var fakeFirstNames = []string{"James", "Mary", "Wei", "Aisha", "Carlos", "Yuki", "Olivia", "Arjun", "Fatima", "Lucas"}
+var fakeLastNames = []string{"Smith", "Tan", "Garcia", "Khan", "Nguyen", "Muller", "Rossi", "Sato", "Okafor", "Silva"}
+var fakeCities = []string{"London", "Singapore", "Lagos", "Tokyo", "Lima", "Berlin", "Toronto", "Mumbai", "Sydney"}
+var fakeStreets = []string{"High Street", "Station Road", "Main Street", "Park Avenue", "Church Lane", "Mill Road"}
+var fakeWords = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua"}
+
+// fakeKinds maps field name keywords to the kind of fake data, checked in order
+var fakeKinds = []struct {
+	keyword string
+	kind    string
+}{
+	{"email", "email"},
+	{"firstname", "firstName"},
+	{"lastname", "lastName"},
+	{"surname", "lastName"},
+	{"username", "username"},
+	{"name", "name"},
+	{"city", "city"},
+	{"address", "address"},
+	{"street", "address"},
+	{"description", "text"},
+	{"comment", "text"},
+	{"bio", "text"},
+	{"text", "text"},
+}
+
+// Fake fills the struct with realistic looking data that passes the rules, e.g. names for name fields and emails for
+// email fields. Useful for creating fixtures that stay consistent with the rules.
+func Fake(structPtr any, rules Rules) error {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("struct is not pointer")
+	}
+	value, err := rules.generate(rand.New(rand.NewSource(rand.Int63())), true)
+	if err != nil {
+		return err
+	}
+	if !reflect.TypeOf(value).AssignableTo(v.Elem().Type()) {
+		return errors.New("struct doesn't match the rules")
+	}
+	v.Elem().Set(reflect.ValueOf(value))
+	return nil
+}
+
+// fakeKind guesses the kind of fake data from the field name. Returns an empty string if fake is false.
+func fakeKind(field []string, fake bool) string {
+	if !fake {
+		return ""
+	}
+	name := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(jsonFieldName(field)))
+	for _, k := range fakeKinds {
+		if strings.Contains(name, k.keyword) {
+			return k.kind
+		}
+	}
+	return ""
+}
+
+// fakeString returns fake data of the kind within the length limits, or an empty string if none can be found
+func fakeString(rnd *rand.Rand, kind string, minLen int64, maxLen int64) string {
+	pick := func(list []string) string {
+		return list[rnd.Intn(len(list))]
+	}
+	for i := 0; i < maxAttempts; i++ {
+		var s string
+		switch kind {
+		case "firstName":
+			s = pick(fakeFirstNames)
+		case "lastName":
+			s = pick(fakeLastNames)
+		case "name":
+			s = pick(fakeFirstNames) + " " + pick(fakeLastNames)
+		case "username":
+			s = strings.ToLower(pick(fakeFirstNames) + pick(fakeLastNames))
+		case "email":
+			s = strings.ToLower(pick(fakeFirstNames)+"."+pick(fakeLastNames)) + "@example.com"
+		case "city":
+			s = pick(fakeCities)
+		case "address":
+			s = strconv.Itoa(1+rnd.Intn(200)) + " " + pick(fakeStreets)
+		case "text":
+			words := make([]string, 3+rnd.Intn(10))
+			for j := range words {
+				words[j] = pick(fakeWords)
+			}
+			s = strings.ToUpper(words[0][:1]) + strings.Join(words, " ")[1:] + "."
+		default:
+			return ""
+		}
+		length := int64(len([]rune(s)))
+		if length >= minLen && (maxLen < 0 || length <= maxLen) {
+			return s
+		}
+	}
+	return ""
+}
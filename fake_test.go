@@ -0,0 +1,42 @@
+package xvalid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	type user struct {
+		FirstName string `json:"first_name"`
+		Name      string `json:"name"`
+		Email     string `json:"email"`
+		City      string `json:"city"`
+		Bio       string `json:"bio"`
+		Code      string `json:"code"`
+		Age       int    `json:"age"`
+	}
+	u := user{}
+	rules := New(&u).
+		Field(&u.FirstName, Required(), MaxLength(20)).
+		Field(&u.Name, Required(), MaxLength(40)).
+		Field(&u.Email, Required(), Email()).
+		Field(&u.City, Required()).
+		Field(&u.Bio, MaxLength(200)).
+		Field(&u.Code, MinLength(4), MaxLength(4)).
+		Field(&u.Age, Min(18), Max(99))
+	for i := 0; i < 20; i++ {
+		fake := user{}
+		assert.Nil(t, Fake(&fake, rules), "Faked")
+		assert.Nil(t, rules.Validate(fake), "Valid fake")
+		assert.Contains(t, fakeFirstNames, fake.FirstName, "First name")
+		assert.Contains(t, fake.Name, " ", "Full name")
+		assert.True(t, strings.HasSuffix(fake.Email, "@example.com"), "Email")
+		assert.Contains(t, fakeCities, fake.City, "City")
+		assert.Len(t, fake.Code, 4, "Random string within limits")
+	}
+	assert.NotNil(t, Fake(user{}, rules), "Not a pointer")
+	type other struct{}
+	assert.NotNil(t, Fake(&other{}, rules), "Different struct")
+}
@@ -0,0 +1,466 @@
+package xvalid
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+)
+
+// maxAttempts to generate a valid instance before giving up
+const maxAttempts = 100
+
+// Generate creates a random instance of the struct that passes the rules. The returned value has the same type as the
+// struct the rules were created with. Custom validators can't be generated for, so generation fails if they keep
+// rejecting the generated values.
+func (r Rules) Generate(rnd *rand.Rand) (any, error) {
+	return r.generate(rnd, false)
+}
+
+// generate a valid instance, optionally with realistic looking strings
+func (r Rules) generate(rnd *rand.Rand, fake bool) (any, error) {
+	structType := reflect.TypeOf(r.structPtr).Elem()
+	var err error
+	for i := 0; i < maxAttempts; i++ {
+		v := reflect.New(structType).Elem()
+		for _, field := range r.fields() {
+			if f, ok := settableField(v, field); ok {
+				generateValue(rnd, f, r.fieldValidators(field), fakeKind(field, fake))
+			}
+		}
+		subject := v.Interface()
+		if err = r.Validate(subject); err == nil {
+			return subject, nil
+		}
+	}
+	return nil, fmt.Errorf("can't generate a valid %v: %w", structType, err)
+}
+
+// GenerateInvalid creates instances of the struct that are valid except for a single boundary value, e.g. a string
+// that is one character shorter than the MinLength. One instance is returned for every validator that a boundary
+// value can be found for.
+func (r Rules) GenerateInvalid(rnd *rand.Rand) ([]any, error) {
+	valid, err := r.Generate(rnd)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]any, 0)
+	for _, validator := range r.validators {
+		if len(validator.Field()) == 0 {
+			continue
+		}
+		v := reflect.New(reflect.TypeOf(valid)).Elem()
+		v.Set(reflect.ValueOf(valid))
+		f, ok := settableField(v, validator.Field())
+		if !ok {
+			continue
+		}
+		// prefer values that only break this validator
+		var fallback any
+		for _, candidate := range boundaryValues(validator, f.Type()) {
+			if !candidate.Type().ConvertibleTo(f.Type()) {
+				continue
+			}
+			candidate = candidate.Convert(f.Type())
+			if validator.Validate(candidate.Interface()) == nil {
+				continue
+			}
+			f.Set(candidate)
+			if errs, ok := r.Validate(v.Interface()).(ErrorSlice); ok && len(errs) == 1 {
+				fallback = nil
+				list = append(list, v.Interface())
+				break
+			} else if fallback == nil {
+				fallback = v.Interface()
+			}
+		}
+		if fallback != nil {
+			list = append(list, fallback)
+		}
+	}
+	return list, nil
+}
+
+// fields with validators in the order they were added
+func (r Rules) fields() [][]string {
+	seen := make(map[string]bool)
+	fields := make([][]string, 0)
+	for _, v := range r.validators {
+		key := strings.Join(v.Field(), ".")
+		if len(v.Field()) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		fields = append(fields, v.Field())
+	}
+	return fields
+}
+
+// fieldValidators returns the validators of a field
+func (r Rules) fieldValidators(field []string) []Validator {
+	key := strings.Join(field, ".")
+	list := make([]Validator, 0)
+	for _, v := range r.validators {
+		if strings.Join(v.Field(), ".") == key {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// settableField finds the field in the struct value, allocating embedded pointers along the way
+func settableField(v reflect.Value, field []string) (reflect.Value, bool) {
+	for _, p := range field {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return v, false
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			name := strings.Split(sf.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = sf.Name
+			}
+			if name == p {
+				v, found = v.Field(i), true
+				break
+			}
+		}
+		if !found {
+			return v, false
+		}
+	}
+	if v.Type().Implements(optionalFieldType) && v.CanSet() {
+		// generate the value of an Optional
+		v.FieldByName("Set").SetBool(true)
+		v = v.FieldByName("Value")
+	}
+	return v, v.CanSet()
+}
+
+// generateValue sets a random value that satisfies the validators where possible. Strings are generated from the word
+// list of the fake kind if there is one that fits.
+func generateValue(rnd *rand.Rand, f reflect.Value, validators []Validator, fake string) {
+	var (
+		required       bool
+		minLen, maxLen int64 = 0, -1
+		minNum, maxNum int64 = 0, 100
+		hasMin, hasMax bool
+		pattern        *syntax.Regexp
+		email          bool
+		options        []any
+		minItems       int64
+		maxItems       int64 = -1
+	)
+	for _, v := range validators {
+		switch c := v.(type) {
+		case *RequiredValidator:
+			required = true
+		case *MinLengthValidator:
+			minLen = max(minLen, c.min)
+		case *MinBytesValidator:
+			minLen = max(minLen, c.min)
+		case *MaxLengthValidator:
+			maxLen = c.max
+		case *MaxBytesValidator:
+			maxLen = c.max
+		case *MinItemsValidator:
+			minItems = max(minItems, c.min)
+		case *MaxItemsValidator:
+			maxItems = c.max
+		case *MinValidator:
+			minNum, hasMin = c.min, true
+			if c.exclusive {
+				minNum++
+			}
+		case *MaxValidator:
+			maxNum, hasMax = c.max, true
+			if c.exclusive {
+				maxNum--
+			}
+		case *LengthValidator:
+			minLen, maxLen = max(minLen, c.min), c.max
+			minItems, maxItems = max(minItems, c.min), c.max
+		case *RangeValidator:
+			minNum, maxNum, hasMin, hasMax = int64(math.Ceil(c.min)), int64(math.Floor(c.max)), true, true
+			if c.isInt {
+				minNum, maxNum = c.intMin, c.intMax
+			}
+			if c.exclusive {
+				minNum, maxNum = int64(math.Floor(c.min))+1, int64(math.Ceil(c.max))-1
+				if c.isInt {
+					minNum, maxNum = c.intMin+1, c.intMax-1
+				}
+			}
+		case *PatternValidator:
+			if re, err := syntax.Parse(c.re.String(), syntax.Perl); err == nil {
+				pattern = re.Simplify()
+			}
+		case *EmailValidator:
+			email = true
+		case *OptionsValidator:
+			options = c.getOptions()
+		case interface{ optionValues() []any }:
+			options = c.optionValues()
+		}
+	}
+	if hasMin && !hasMax {
+		maxNum = minNum + 100
+	} else if hasMax && !hasMin {
+		minNum = min(0, maxNum)
+	}
+	if len(options) > 0 {
+		opt := reflect.ValueOf(options[rnd.Intn(len(options))])
+		if opt.IsValid() && opt.Type().ConvertibleTo(f.Type()) {
+			f.Set(opt.Convert(f.Type()))
+		}
+		return
+	}
+	switch f.Kind() {
+	case reflect.String:
+		if required && minLen == 0 {
+			minLen = 1
+		}
+		var s string
+		if email {
+			s = fakeString(rnd, "email", minLen, maxLen)
+			if s == "" {
+				s = randomEmail(rnd, minLen, maxLen)
+			}
+		} else if pattern != nil {
+			s = randomPattern(rnd, pattern)
+		} else if s = fakeString(rnd, fake, minLen, maxLen); s == "" {
+			if maxLen < 0 {
+				maxLen = minLen + 10
+			}
+			// keep huge limits from overflowing the random length
+			maxLen = min(maxLen, minLen+maxBoundarySize)
+			s = randomString(rnd, minLen+rnd.Int63n(max(maxLen-minLen, 0)+1))
+		}
+		f.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := minNum + rnd.Int63n(max(maxNum-minNum, 0)+1)
+		if required && n == 0 {
+			n = maxNum
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := max(minNum, 0) + rnd.Int63n(max(maxNum-max(minNum, 0), 0)+1)
+		if required && n == 0 {
+			n = max(maxNum, 1)
+		}
+		f.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		n := float64(minNum) + rnd.Float64()*float64(max(maxNum-minNum, 0))
+		if required && n == 0 {
+			n = float64(maxNum)
+		}
+		f.SetFloat(n)
+	case reflect.Bool:
+		f.SetBool(required || rnd.Intn(2) == 0)
+	case reflect.Slice, reflect.Array:
+		if f.Kind() == reflect.Slice {
+			n := minItems
+			if required && n == 0 {
+				n = 1
+			}
+			if maxItems >= 0 && n > maxItems {
+				n = maxItems
+			}
+			f.Set(reflect.MakeSlice(f.Type(), int(n), int(n)))
+		}
+		for i := 0; i < f.Len(); i++ {
+			e := f.Index(i)
+			if e.Kind() == reflect.Ptr {
+				e.Set(reflect.New(e.Type().Elem()))
+				e = e.Elem()
+			}
+			generateValue(rnd, e, []Validator{Required()}, fake)
+		}
+	}
+}
+
+// maxBoundarySize limits the strings and slices made for boundary values. Limits beyond it, e.g. a MaxLength of
+// math.MaxInt64, have no boundary value.
+const maxBoundarySize = 1 << 16
+
+// boundarySize returns the size as an int if a string or slice of that size can be made
+func boundarySize(n int64) (int, bool) {
+	if n < 0 || n > maxBoundarySize {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// boundaryValues that are likely to fail the validator
+func boundaryValues(validator Validator, t reflect.Type) []reflect.Value {
+	list := make([]any, 0)
+	// strings and slices just outside the limits, where they can be made
+	repeat := func(n int64) {
+		if size, ok := boundarySize(n); ok {
+			list = append(list, strings.Repeat("a", size))
+		}
+	}
+	sliceValues := make([]reflect.Value, 0)
+	makeSlice := func(n int64) {
+		if size, ok := boundarySize(n); ok {
+			sliceValues = append(sliceValues, reflect.MakeSlice(t, size, size))
+		}
+	}
+	switch c := validator.(type) {
+	case *RequiredValidator:
+		return []reflect.Value{reflect.Zero(t)}
+	case *MinLengthValidator:
+		if c.min > 0 {
+			repeat(c.min - 1)
+		}
+	case *MaxLengthValidator:
+		repeat(c.max + 1)
+	case *MinBytesValidator:
+		if c.min > 0 {
+			repeat(c.min - 1)
+		}
+	case *MaxBytesValidator:
+		repeat(c.max + 1)
+	case *MinItemsValidator:
+		if c.min > 0 && t.Kind() == reflect.Slice {
+			makeSlice(c.min - 1)
+			return sliceValues
+		}
+	case *MaxItemsValidator:
+		if t.Kind() == reflect.Slice {
+			makeSlice(c.max + 1)
+			return sliceValues
+		}
+	case *MinValidator:
+		list = append(list, c.min-1, float64(c.min)-0.5)
+		if c.exclusive {
+			list = append(list, c.min)
+		}
+	case *MaxValidator:
+		list = append(list, c.max+1, float64(c.max)+0.5)
+		if c.exclusive {
+			list = append(list, c.max)
+		}
+	case *LengthValidator:
+		if t.Kind() == reflect.Slice {
+			makeSlice(c.max + 1)
+			if c.min > 0 {
+				makeSlice(c.min - 1)
+			}
+			return sliceValues
+		}
+		repeat(c.max + 1)
+		if c.min > 0 {
+			repeat(c.min - 1)
+		}
+	case *RangeValidator:
+		if c.isInt {
+			list = append(list, c.intMin-1, c.min-0.5, c.intMax+1, c.max+0.5)
+		} else {
+			list = append(list, int64(math.Floor(c.min))-1, c.min-0.5, int64(math.Ceil(c.max))+1, c.max+0.5)
+		}
+		if c.exclusive {
+			list = append(list, c.min, c.max)
+		}
+	case *PatternValidator, *EmailValidator, *OptionsValidator, interface{ optionValues() []any }:
+		list = append(list, "!", " ", "invalid", -1, 0)
+	}
+	values := make([]reflect.Value, len(list))
+	for i, v := range list {
+		values[i] = reflect.ValueOf(v)
+	}
+	return values
+}
+
+const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+func randomString(rnd *rand.Rand, length int64) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = alphanumeric[rnd.Intn(len(alphanumeric))]
+	}
+	return string(b)
+}
+
+func randomEmail(rnd *rand.Rand, minLen int64, maxLen int64) string {
+	// shortest email is a@b.co
+	length := max(minLen, 6) + rnd.Int63n(max(maxLen-max(minLen, 6), 0)+1)
+	if maxLen >= 0 && length > maxLen {
+		length = maxLen
+	}
+	local := max(length-5, 1)
+	return strings.ToLower(randomString(rnd, local)) + "@" + strings.ToLower(randomString(rnd, 1)) + ".co"
+}
+
+// randomPattern generates a string that matches the regular expression
+func randomPattern(rnd *rand.Rand, re *syntax.Regexp) string {
+	var sb strings.Builder
+	writePattern(rnd, re, &sb)
+	return sb.String()
+}
+
+// maxRepeat limits unbounded repetitions such as * and +
+const maxRepeat = 5
+
+func writePattern(rnd *rand.Rand, re *syntax.Regexp, sb *strings.Builder) {
+	repeat := func(min int, max int) {
+		if max < 0 {
+			max = min + maxRepeat
+		}
+		n := min + rnd.Intn(max-min+1)
+		for i := 0; i < n; i++ {
+			writePattern(rnd, re.Sub[0], sb)
+		}
+	}
+	switch re.Op {
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		sb.WriteRune(randomRune(rnd, re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteByte(alphanumeric[rnd.Intn(len(alphanumeric))])
+	case syntax.OpCapture:
+		writePattern(rnd, re.Sub[0], sb)
+	case syntax.OpStar:
+		repeat(0, -1)
+	case syntax.OpPlus:
+		repeat(1, -1)
+	case syntax.OpQuest:
+		repeat(0, 1)
+	case syntax.OpRepeat:
+		repeat(re.Min, re.Max)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writePattern(rnd, sub, sb)
+		}
+	case syntax.OpAlternate:
+		writePattern(rnd, re.Sub[rnd.Intn(len(re.Sub))], sb)
+	}
+}
+
+// randomRune picks a rune from a character class, preferring printable ASCII
+func randomRune(rnd *rand.Rand, ranges []rune) rune {
+	ascii := make([]rune, 0)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := max(ranges[i], ' '); r <= min(ranges[i+1], '~'); r++ {
+			ascii = append(ascii, r)
+		}
+	}
+	if len(ascii) > 0 {
+		return ascii[rnd.Intn(len(ascii))]
+	}
+	if len(ranges) < 2 {
+		return 'a'
+	}
+	i := rnd.Intn(len(ranges)/2) * 2
+	return ranges[i] + rune(rnd.Intn(int(ranges[i+1]-ranges[i])+1))
+}
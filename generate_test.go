@@ -0,0 +1,97 @@
+package xvalid
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	type Embed struct {
+		Code string `json:"code"`
+	}
+	type generateType struct {
+		Name   string  `json:"name"`
+		Email  string  `json:"email"`
+		Age    int     `json:"age"`
+		Score  float64 `json:"score"`
+		Color  string  `json:"color"`
+		Level  int     `json:"level"`
+		Ignore string  `json:"ignore"`
+		Embed
+	}
+	g := generateType{}
+	rules := New(&g).
+		Field(&g.Name, Required(), MinLength(3), MaxLength(8)).
+		Field(&g.Email, Required(), Email(), MaxLength(30)).
+		Field(&g.Age, Min(18), Max(65)).
+		Field(&g.Score, Required(), Max(10)).
+		Field(&g.Color, Options("red", "green")).
+		Field(&g.Level, OptionsOf(1, 2, 3)).
+		Field(&g.Code, Pattern(`^[A-Z]{2}-\d{3,4}$`))
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		v, err := rules.Generate(rnd)
+		assert.Nil(t, err, "Generated")
+		assert.IsType(t, generateType{}, v, "Same type")
+		assert.Nil(t, rules.Validate(v), "Valid instance")
+	}
+
+	invalid, err := rules.GenerateInvalid(rnd)
+	assert.Nil(t, err, "Generated")
+	assert.Len(t, invalid, 13, "One per validator")
+	for _, v := range invalid {
+		assert.NotNil(t, rules.Validate(v), "Invalid instance")
+	}
+	assert.Len(t, rules.Validate(invalid[1]), 1, "Only MinLength broken")
+
+	// custom validators that never pass
+	rules = New(&g).Field(&g.Name, FieldFunc(func(field []string, value any) Error {
+		return NewError("never", field...)
+	}))
+	_, err = rules.Generate(rnd)
+	assert.NotNil(t, err, "Can't generate")
+}
+
+func TestGenerateCollections(t *testing.T) {
+	type collectionType struct {
+		Tags  []string  `json:"tags"`
+		Refs  []*int    `json:"refs"`
+		Codes [3]string `json:"codes"`
+	}
+	c := collectionType{}
+	rules := New(&c).
+		Field(&c.Tags, MinItems(2), MaxItems(4)).
+		Field(&c.Refs, Required()).
+		Field(&c.Codes, Required())
+	rnd := rand.New(rand.NewSource(1))
+	v, err := rules.Generate(rnd)
+	assert.Nil(t, err, "Generated")
+	assert.Nil(t, rules.Validate(v), "Valid instance")
+	invalid, err := rules.GenerateInvalid(rnd)
+	assert.Nil(t, err, "Generated")
+	assert.Len(t, invalid, 4, "One per validator")
+	for _, v := range invalid {
+		assert.NotNil(t, rules.Validate(v), "Invalid instance")
+	}
+}
+
+func TestGenerateHugeLimits(t *testing.T) {
+	type hugeType struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	h := hugeType{}
+	rules := New(&h).
+		Field(&h.Name, MinLength(1), MaxLength(math.MaxInt64)).
+		Field(&h.Tags, MaxItems(math.MaxInt64), Length(0, 1<<40))
+	rnd := rand.New(rand.NewSource(1))
+	v, err := rules.Generate(rnd)
+	assert.Nil(t, err, "Generated")
+	assert.Nil(t, rules.Validate(v), "Valid instance")
+	invalid, err := rules.GenerateInvalid(rnd)
+	assert.Nil(t, err, "Generated without boundary values above the limit")
+	assert.Len(t, invalid, 1, "Only the minimum length")
+}
@@ -0,0 +1,108 @@
+//go:build !js
+
+package xvalid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sync"
+)
+
+// ErrorWriter writes validation errors as a JSON response
+type ErrorWriter struct {
+	// Status code of the response
+	Status int
+	// Envelope wraps the errors before they are encoded. The errors are encoded as is if nil.
+	Envelope func(ErrorSlice) any
+}
+
+// DefaultErrorWriter is used by WriteErrors
+var DefaultErrorWriter = ErrorWriter{
+	Status: http.StatusUnprocessableEntity,
+	Envelope: func(errs ErrorSlice) any {
+		return map[string]ErrorSlice{"errors": errs}
+	},
+}
+
+// Write the errors to the response
+func (e ErrorWriter) Write(w http.ResponseWriter, errs ErrorSlice) error {
+	var body any = errs
+	if e.Envelope != nil {
+		body = e.Envelope(errs)
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	status := e.Status
+	if status == 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, err = w.Write(b)
+	return err
+}
+
+// WriteErrors writes the errors to the response with DefaultErrorWriter
+func WriteErrors(w http.ResponseWriter, errs ErrorSlice) error {
+	return DefaultErrorWriter.Write(w, errs)
+}
+
+// RulesHandler serves exported rules as JSON. The last segment of the request path is used to look up the rules,
+// e.g. GET /validation/user serves the rules registered as "user".
+type RulesHandler struct {
+	mu    sync.RWMutex
+	rules map[string]Rules
+}
+
+// NewRulesHandler creates an empty handler
+func NewRulesHandler() *RulesHandler {
+	return &RulesHandler{
+		rules: make(map[string]Rules),
+	}
+}
+
+// Register rules under a name
+func (h *RulesHandler) Register(name string, rules Rules) *RulesHandler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rules[name] = rules
+	return h
+}
+
+// ServeHTTP writes the rules with an ETag so clients can cache them
+func (h *RulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	h.mu.RLock()
+	rules, ok := h.rules[path.Base(r.URL.Path)]
+	h.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	b, err := json.Marshal(rules)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(b)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(b)
+	}
+}
@@ -0,0 +1,61 @@
+//go:build !js
+
+package xvalid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteErrors(t *testing.T) {
+	errs := ErrorSlice{NewError("Please enter the name", "name")}
+	rec := httptest.NewRecorder()
+	assert.Nil(t, WriteErrors(rec, errs))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, "Default status")
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"), "Content type")
+	assert.JSONEq(t, `{"errors":[{"message":"Please enter the name","field":"name"}]}`, rec.Body.String(), "Default envelope")
+
+	writer := ErrorWriter{
+		Status: http.StatusBadRequest,
+		Envelope: func(errs ErrorSlice) any {
+			return map[string]any{"ok": false, "fields": errs.ToMap()}
+		},
+	}
+	rec = httptest.NewRecorder()
+	assert.Nil(t, writer.Write(rec, errs))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "Custom status")
+	assert.JSONEq(t, `{"ok":false,"fields":{"name":"Please enter the name"}}`, rec.Body.String(), "Custom envelope")
+}
+
+func TestRulesHandler(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	u := user{}
+	handler := NewRulesHandler().Register("user", New(&u).Field(&u.Name, Required()))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validation/user", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, "Found")
+	assert.JSONEq(t, `{"name":[{"rule":"required"}]}`, rec.Body.String(), "Rules body")
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag, "ETag set")
+
+	req := httptest.NewRequest(http.MethodGet, "/validation/user", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code, "Not modified")
+	assert.Empty(t, rec.Body.String(), "No body when not modified")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validation/store", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "Not registered")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validation/user", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code, "Method not allowed")
+}
@@ -0,0 +1,207 @@
+package xvalid
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Translator translates the default error messages of validators. Set it with Configure and validate with
+// Rules.ValidateWithLocale, or with Rules.ValidateCtx and a context from WithLocale.
+type Translator interface {
+	// Translate returns the message for the key in the locale, or false to keep the default message. The key is the
+	// rule name of the error, e.g. "minLength". The params are those of the validator returned by Params, with the
+	// label of the field as "field".
+	Translate(locale string, key string, params map[string]any) (string, bool)
+}
+
+// localeKey of the context value set by WithLocale
+type localeKey struct{}
+
+// WithLocale returns a context that makes ValidateCtx translate error messages into the locale, e.g. "de" or "pt-BR"
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale returns the locale set with WithLocale, or an empty string if there is none
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// ValidateWithLocale validates the subject and translates the default error messages into the locale with the
+// translator set with Configure. Custom messages, errors of the validators of Each, Keys and Values, and messages
+// the translator doesn't have are kept as is.
+func (r Rules) ValidateWithLocale(subject any, locale string) error {
+	return r.ValidateCtx(WithLocale(context.Background(), locale), subject)
+}
+
+// translateErrors replaces the default messages of the errors of the validator with translated ones
+func (r Rules) translateErrors(ctx context.Context, validator Validator, errs ErrorSlice) ErrorSlice {
+	locale := Locale(ctx)
+	if len(errs) == 0 || locale == "" || config.Translator == nil || hasCustomMessage(validator) {
+		return errs
+	}
+	rule := ruleName(validator)
+	params := messageParams(validator)
+	params["field"] = fieldLabel(validator.Field(), r.labels[strings.Join(validator.Field(), ".")])
+	translated := make(ErrorSlice, len(errs))
+	for i, err := range errs {
+		translated[i] = err
+		// errors of nested validators and internal errors aren't the message of this rule
+		e, ok := err.(*validationError)
+		if !ok || e.rule != rule {
+			continue
+		}
+		if message, ok := config.Translator.Translate(locale, rule, params); ok {
+			translated[i], _ = withMessage(err, message)
+		}
+	}
+	return translated
+}
+
+// messageParams returns the params of the validator formatted as they are in the default messages
+func messageParams(validator Validator) map[string]any {
+	params := make(map[string]any)
+	for k, v := range Params(validator) {
+		params[k] = v
+	}
+	if params["duration"] == true {
+		for _, k := range []string{"min", "max"} {
+			if n, ok := params[k].(int64); ok {
+				params[k] = formatDuration(n)
+			}
+		}
+	}
+	for k, v := range params {
+		switch v := v.(type) {
+		case float64:
+			params[k] = formatNumber(v)
+		case []string:
+			params[k] = strings.Join(v, " or ")
+		case time.Time:
+			layout, _ := params["layout"].(string)
+			params[k] = v.Format(layout)
+		}
+	}
+	switch c := validator.(type) {
+	case *LengthValidator:
+		params["exact"] = c.min == c.max
+	case *MaxSizeValidator:
+		params["max"] = formatSize(c.max)
+	}
+	return params
+}
+
+// EnglishMessages are the default messages of the built in validators as templates for a Catalog. Parameters are
+// written in braces, e.g. "{min}". A key ending in ".exclusive" is used for exclusive limits, ".exact" for lengths
+// with the same min and max, and ".now" for times compared to the time of validation.
+var EnglishMessages = map[string]string{
+	"required":        "Please enter the {field}",
+	"minLength":       "Please lengthen {field} to {min} characters or more",
+	"maxLength":       "Please shorten {field} to {max} characters or less",
+	"minBytes":        "Please lengthen {field} to {min} bytes or more",
+	"maxBytes":        "Please shorten {field} to {max} bytes or less",
+	"minItems":        "Please add at least {min} items to {field}",
+	"maxItems":        "Please remove items from {field} to have {max} or less",
+	"min":             "Please increase {field} to be {min} or more",
+	"min.exclusive":   "Please increase {field} to be more than {min}",
+	"max":             "Please decrease {field} to be {max} or less",
+	"max.exclusive":   "Please decrease {field} to be less than {max}",
+	"pattern":         "Please correct {field} into a valid format",
+	"email":           "Please use a valid email address for {field}",
+	"normalized":      "Please correct the special characters in {field}",
+	"options":         "Please select one of the valid options for {field}",
+	"optionsOf":       "Please select one of the valid options for {field}",
+	"notOptions":      "Please choose a different {field}",
+	"url":             "Please use a valid URL for {field}",
+	"phone":           "Please enter a valid phone number for {field}",
+	"length":          "Please change {field} to have a length of {min} to {max}",
+	"length.exact":    "Please change {field} to have a length of exactly {min}",
+	"range":           "Please change {field} to be between {min} and {max}",
+	"range.exclusive": "Please change {field} to be more than {min} and less than {max}",
+	"before":          "Please change {field} to be before {time}",
+	"before.now":      "Please change {field} to be in the past",
+	"after":           "Please change {field} to be after {time}",
+	"after.now":       "Please change {field} to be in the future",
+	"dateFormat":      "Please enter a valid date for {field}",
+	"taxId":           "Please enter a valid tax number for {field}",
+	"cardExpiry":      "Please use a card that hasn't expired for {field}",
+	"image":           "Please upload a valid image for {field}",
+	"contentType":     "Please upload a file of type {types} for {field}",
+	"maxSize":         "Please reduce {field} to {max} or less",
+	"remote":          "Please enter a valid {field}",
+	"decode":          "Please enter valid data for {field}",
+}
+
+// Catalog is a Translator of message templates by locale. It starts with EnglishMessages for "en".
+type Catalog struct {
+	mutex    sync.RWMutex
+	messages map[string]map[string]string
+}
+
+// NewCatalog creates a catalog with the English messages
+func NewCatalog() *Catalog {
+	return (&Catalog{messages: make(map[string]map[string]string)}).Add("en", EnglishMessages)
+}
+
+// Add message templates for a locale, replacing those with the same key. A locale with a region, e.g. "pt-BR", falls
+// back to the messages of its language, e.g. "pt".
+func (c *Catalog) Add(locale string, messages map[string]string) *Catalog {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	locale = strings.ReplaceAll(locale, "_", "-")
+	m := make(map[string]string, len(c.messages[locale])+len(messages))
+	for k, v := range c.messages[locale] {
+		m[k] = v
+	}
+	for k, v := range messages {
+		m[k] = v
+	}
+	c.messages[locale] = m
+	return c
+}
+
+// templateVariants are the params that select the template of the key with the param as suffix, e.g. "min.exclusive"
+var templateVariants = []string{"exclusive", "exact", "now"}
+
+// templateParam matches a parameter of a message template
+var templateParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// Translate the message. Messages with parameters that aren't given are not translated.
+func (c *Catalog) Translate(locale string, key string, params map[string]any) (string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	locale = strings.ReplaceAll(locale, "_", "-")
+	messages, ok := c.messages[locale]
+	if !ok {
+		language, _, _ := strings.Cut(locale, "-")
+		if messages, ok = c.messages[language]; !ok {
+			return "", false
+		}
+	}
+	template, ok := messages[key]
+	for _, variant := range templateVariants {
+		if set, _ := params[variant].(bool); set {
+			if t, found := messages[key+"."+variant]; found {
+				template, ok = t, true
+			}
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	complete := true
+	message := templateParam.ReplaceAllStringFunc(template, func(p string) string {
+		v, found := params[p[1:len(p)-1]]
+		if !found {
+			complete = false
+			return p
+		}
+		return fmt.Sprint(v)
+	})
+	return message, complete
+}
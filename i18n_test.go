@@ -0,0 +1,130 @@
+package xvalid
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWithLocale(t *testing.T) {
+	defer Configure(CurrentConfig())
+	type form struct {
+		Name    string        `json:"name"`
+		Age     int           `json:"age"`
+		Code    string        `json:"code"`
+		Timeout time.Duration `json:"timeout"`
+	}
+	f := form{}
+	rules := New(&f).
+		Field(&f.Name, Required(), MinLength(3)).
+		Field(&f.Age, Min(18).Exclusive()).
+		Field(&f.Code, Pattern(`^[A-Z]+$`).SetMessage("Bad code")).
+		Field(&f.Timeout, MinDuration(time.Minute)).
+		Label(&f.Name, "Name")
+	subject := form{Name: "ab", Age: 16, Code: "x", Timeout: time.Second}
+
+	catalog := NewCatalog().Add("de", map[string]string{
+		"minLength":     "{field} muss mindestens {min} Zeichen lang sein",
+		"min":           "{field} muss mindestens {min} sein",
+		"min.exclusive": "{field} muss größer als {min} sein",
+		"pattern":       "Bitte {field} korrigieren",
+	})
+	Configure(Config{Translator: catalog})
+
+	errs := rules.ValidateWithLocale(subject, "de-CH").(ErrorSlice)
+	assert.Equal(t, "Name muss mindestens 3 Zeichen lang sein", errs[0].Error(), "Translated with label")
+	assert.Equal(t, "age muss größer als 18 sein", errs[1].Error(), "Exclusive variant")
+	assert.Equal(t, "Bad code", errs[2].Error(), "Custom message kept")
+	assert.Equal(t, "timeout muss mindestens 1m sein", errs[3].Error(), "Duration")
+	assert.Equal(t, "minLength", errs[0].(interface{ Rule() string }).Rule(), "Rule kept")
+
+	errs = rules.ValidateWithLocale(form{Age: 18, Code: "X", Timeout: time.Minute}, "de").(ErrorSlice)
+	assert.Equal(t, "Please enter the Name", errs[0].Error(), "Missing key keeps default")
+
+	errs = rules.ValidateWithLocale(subject, "en").(ErrorSlice)
+	assert.Equal(t, "Please lengthen Name to 3 characters or more", errs[0].Error(), "English defaults")
+	assert.Equal(t, "Please increase age to be more than 18", errs[1].Error(), "English exclusive")
+
+	errs = rules.ValidateWithLocale(subject, "fr").(ErrorSlice)
+	assert.Equal(t, "Please lengthen Name to 3 characters or more", errs[0].Error(), "Unknown locale")
+
+	errs = rules.ValidateCtx(WithLocale(context.Background(), "de"), subject).(ErrorSlice)
+	assert.Equal(t, "Name muss mindestens 3 Zeichen lang sein", errs[0].Error(), "Context locale")
+
+	shared := NewError("Name is taken", "name")
+	catalog.Add("de", map[string]string{"fieldFunc": "Name ist vergeben"})
+	taken := New(&f).Field(&f.Name, FieldFunc(func(field []string, value any) Error { return shared }))
+	errs = taken.ValidateWithLocale(subject, "de").(ErrorSlice)
+	assert.Equal(t, "Name ist vergeben", errs[0].Error(), "Shared error translated")
+	assert.Equal(t, "Name is taken", shared.Error(), "Shared error not changed")
+
+	Configure(Config{})
+	errs = rules.ValidateWithLocale(subject, "de").(ErrorSlice)
+	assert.Equal(t, "Please lengthen Name to 3 characters or more", errs[0].Error(), "No translator")
+}
+
+func TestCatalog(t *testing.T) {
+	catalog := NewCatalog().Add("pt_BR", map[string]string{"required": "Informe {field}"})
+	message, ok := catalog.Translate("pt-BR", "required", map[string]any{"field": "nome"})
+	assert.True(t, ok)
+	assert.Equal(t, "Informe nome", message, "Underscore locale")
+
+	_, ok = catalog.Translate("en", "minLength", map[string]any{"field": "nome"})
+	assert.False(t, ok, "Missing param")
+
+	catalog.Add("pt-BR", map[string]string{"email": "E-mail inválido em {field}"})
+	_, ok = catalog.Translate("pt-BR", "required", map[string]any{"field": "nome"})
+	assert.True(t, ok, "Add merges")
+}
+
+func TestEnglishMessages(t *testing.T) {
+	examples := map[string]string{
+		"type":        `{"type":"email"}`,
+		"taxId":       `{"kind":"VAT:DE"}`,
+		"before":      `{"time":"2030-01-01T00:00:00Z"}`,
+		"after":       `{"now":true}`,
+		"dateFormat":  `{"layout":"2006-01-02"}`,
+		"contentType": `{"types":["image/png"]}`,
+		"length":      `{"min":2,"max":2}`,
+		"range":       `{"min":1.5,"max":10}`,
+		"normalized":  `{"form":"NFC"}`,
+		"each":        `{"rules":[]}`,
+		"keys":        `{"rules":[]}`,
+		"values":      `{"rules":[]}`,
+		"if":          `{"condition":{"rule":"required"}}`,
+		"nested":      `{"rules":{}}`,
+		"decode":      `{"rules":{}}`,
+		"remote":      `{"url":"https://example.com"}`,
+	}
+	catalog := NewCatalog()
+	for _, ext := range Extensions() {
+		if ext.Validator != nil {
+			// packs of other packages bring their own messages
+			continue
+		}
+		data, ok := examples[ext.Name]
+		if !ok {
+			data = "{}"
+		}
+		v, err := ext.Load([]byte(data))
+		if !assert.Nil(t, err, ext.Name) {
+			continue
+		}
+		switch v.(type) {
+		case *EachValidator, *MapValidator, *ConditionalValidator, *NestedValidator:
+			// the errors are those of the validators of the elements
+			continue
+		}
+		params := messageParams(v)
+		params["field"] = "name"
+		message, ok := catalog.Translate("en", ruleName(v), params)
+		assert.True(t, ok, ext.Name)
+		assert.NotContains(t, message, "{", ext.Name)
+	}
+	message, _ := catalog.Translate("en", "length", messageParams(ExactLength(2)))
+	assert.Contains(t, message, "exactly 2", "Exact variant")
+	message, _ = catalog.Translate("en", "maxSize", map[string]any{"field": "avatar", "max": messageParams(MaxSize(2 << 20))["max"]})
+	assert.Equal(t, "Please reduce avatar to 2 MB or less", message, "Size")
+}
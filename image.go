@@ -0,0 +1,125 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"  // register the decoder
+	_ "image/jpeg" // register the decoder
+	_ "image/png"  // register the decoder
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ImageValidator field must be an image within the limits. Only the header is decoded.
+type ImageValidator struct {
+	field         []string
+	message       string
+	label         string
+	formats       []string
+	maxWidth      int
+	maxHeight     int
+	maxMegapixels float64
+}
+
+// Field of the field
+func (c *ImageValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ImageValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *ImageValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ImageValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Formats limits the image to the formats, e.g. "png", "jpeg" and "gif". Other formats need their decoder registered
+// with the image package.
+func (c *ImageValidator) Formats(formats ...string) *ImageValidator {
+	c.formats = formats
+	return c
+}
+
+// MaxDims limits the width and height in pixels
+func (c *ImageValidator) MaxDims(width int, height int) *ImageValidator {
+	c.maxWidth = width
+	c.maxHeight = height
+	return c
+}
+
+// MaxMegapixels limits the width times the height in millions of pixels
+func (c *ImageValidator) MaxMegapixels(mp float64) *ImageValidator {
+	c.maxMegapixels = mp
+	return c
+}
+
+// Validate the value. Empty values pass, use Required to require an upload.
+func (c *ImageValidator) Validate(value any) Error {
+	if isEmptyContent(value) {
+		return nil
+	}
+	r, done, ok := contentReader(value)
+	if !ok {
+		if !isStream(value) && passMismatch(value, true) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please upload a valid image for %s", fieldLabel(c.field, c.label)))
+	}
+	cfg, format, err := image.DecodeConfig(r)
+	done()
+	if err != nil {
+		return createError(c.field, c.message, fmt.Sprintf("Please upload a valid image for %s", fieldLabel(c.field, c.label)))
+	}
+	if len(c.formats) > 0 && !slices.Contains(c.formats, format) {
+		return createError(c.field, c.message, fmt.Sprintf("Please upload a %s image for %s", strings.Join(c.formats, " or "), fieldLabel(c.field, c.label)))
+	}
+	if c.maxWidth > 0 && cfg.Width > c.maxWidth || c.maxHeight > 0 && cfg.Height > c.maxHeight {
+		return createError(c.field, c.message, fmt.Sprintf("Please upload an image of at most %dx%d pixels for %s", c.maxWidth, c.maxHeight, fieldLabel(c.field, c.label)))
+	}
+	if c.maxMegapixels > 0 && float64(cfg.Width)*float64(cfg.Height) > c.maxMegapixels*1e6 {
+		return createError(c.field, c.message, fmt.Sprintf("Please upload an image of at most %g megapixels for %s", c.maxMegapixels, fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *ImageValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *ImageValidator) Params() map[string]any {
+	return map[string]any{"formats": c.formats, "maxWidth": c.maxWidth, "maxHeight": c.maxHeight, "maxMegapixels": c.maxMegapixels}
+}
+
+// MarshalJSON for this validator
+func (c *ImageValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule          string   `json:"rule"`
+		Formats       []string `json:"formats,omitempty"`
+		MaxWidth      int      `json:"maxWidth,omitempty"`
+		MaxHeight     int      `json:"maxHeight,omitempty"`
+		MaxMegapixels float64  `json:"maxMegapixels,omitempty"`
+		Message       string   `json:"message,omitempty"`
+		Label         string   `json:"label,omitempty"`
+	}{"image", c.formats, c.maxWidth, c.maxHeight, c.maxMegapixels, c.message, c.label})
+}
+
+// Image field must be an image, given as []byte, an io.ReadSeeker or a value added with RegisterContent, such as the
+// uploaded files of xvalidhttp. Readers are rewound after the header is read so the upload can still be saved, and
+// readers that can't seek fail.
+func Image() *ImageValidator {
+	return &ImageValidator{}
+}
@@ -0,0 +1,70 @@
+package xvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeImage(t *testing.T, format string, width int, height int) []byte {
+	var b bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	if format == "png" {
+		assert.Nil(t, png.Encode(&b, img))
+	} else {
+		assert.Nil(t, jpeg.Encode(&b, img, nil))
+	}
+	return b.Bytes()
+}
+
+func TestImage(t *testing.T) {
+	type profile struct {
+		Avatar []byte `json:"avatar"`
+	}
+	p := profile{}
+	rules := New(&p).Field(&p.Avatar, Image().Formats("png", "jpeg").MaxDims(100, 50).MaxMegapixels(0.004))
+	assert.Nil(t, rules.Validate(profile{Avatar: encodeImage(t, "png", 80, 40)}), "Valid png")
+	assert.Nil(t, rules.Validate(profile{Avatar: encodeImage(t, "jpeg", 100, 40)}), "Valid jpeg")
+	assert.Nil(t, rules.Validate(profile{}), "Empty")
+	errs := rules.Validate(profile{Avatar: []byte("not an image")}).(ErrorSlice)
+	assert.Equal(t, "Please upload a valid image for avatar", errs[0].Error(), "Not an image")
+	errs = rules.Validate(profile{Avatar: encodeImage(t, "png", 101, 10)}).(ErrorSlice)
+	assert.Equal(t, "Please upload an image of at most 100x50 pixels for avatar", errs[0].Error(), "Too wide")
+	errs = rules.Validate(profile{Avatar: encodeImage(t, "png", 100, 50)}).(ErrorSlice)
+	assert.Equal(t, "Please upload an image of at most 0.004 megapixels for avatar", errs[0].Error(), "Too many pixels")
+	errs = New(&p).Field(&p.Avatar, Image().Formats("png")).Validate(profile{Avatar: encodeImage(t, "jpeg", 1, 1)}).(ErrorSlice)
+	assert.Equal(t, "Please upload a png image for avatar", errs[0].Error(), "Wrong format")
+
+	// readers are rewound
+	type upload struct {
+		File io.ReadSeeker `json:"file"`
+	}
+	u := upload{}
+	png := encodeImage(t, "png", 1, 1)
+	r := bytes.NewReader(png)
+	assert.Nil(t, New(&u).Field(&u.File, Image()).Validate(upload{File: r}), "Reader")
+	pos, _ := r.Seek(0, io.SeekCurrent)
+	assert.Equal(t, int64(0), pos, "Rewound")
+	saved, _ := io.ReadAll(r)
+	assert.Equal(t, png, saved, "Fully readable after validation")
+
+	// readers that can't seek aren't consumed
+	type streamUpload struct {
+		File io.Reader `json:"file"`
+	}
+	s := streamUpload{}
+	stream := struct{ io.Reader }{bytes.NewReader(png)}
+	errs = New(&s).Field(&s.File, Image()).Validate(streamUpload{File: stream}).(ErrorSlice)
+	assert.Equal(t, "Please upload a valid image for file", errs[0].Error(), "Stream fails")
+	saved, _ = io.ReadAll(stream)
+	assert.Equal(t, png, saved, "Stream not read")
+
+	j, _ := json.Marshal(Image().Formats("png").MaxDims(10, 20))
+	assert.Equal(t, `{"rule":"image","formats":["png"],"maxWidth":10,"maxHeight":20}`, string(j), "Export")
+}
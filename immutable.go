@@ -0,0 +1,64 @@
+package xvalid
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ImmutableValidator field must not differ from the same field of the original
+type ImmutableValidator struct {
+	field    []string
+	message  string
+	label    string
+	original any
+}
+
+// Field of the field
+func (c *ImmutableValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ImmutableValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *ImmutableValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ImmutableValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value
+func (c *ImmutableValidator) Validate(value any) Error {
+	original, _ := fieldValue(structToMap(c.original), c.field)
+	if !reflect.DeepEqual(value, original) {
+		return createError(c.field, c.message, fmt.Sprintf("Please don't change %s", fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *ImmutableValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *ImmutableValidator) Params() map[string]any {
+	return map[string]any{"original": c.original}
+}
+
+// Immutable field must be equal to the same field of the original struct, e.g. the stored record in an update endpoint
+// where the email or owner must not change. A pointer to the original is read when validating, so the rules can be
+// created before the original is loaded.
+func Immutable(original any) *ImmutableValidator {
+	return &ImmutableValidator{
+		original: original,
+	}
+}
@@ -0,0 +1,34 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmutable(t *testing.T) {
+	type account struct {
+		Email string   `json:"email"`
+		Owner *int     `json:"owner"`
+		Roles []string `json:"roles"`
+		Name  string   `json:"name"`
+	}
+	owner := 1
+	stored := account{}
+	u := account{}
+	rules := New(&u).
+		Field(&u.Email, Immutable(&stored)).
+		Field(&u.Owner, Immutable(&stored)).
+		Field(&u.Roles, Immutable(&stored))
+
+	// loaded after the rules are created
+	stored = account{Email: "a@b.com", Owner: &owner, Roles: []string{"admin"}, Name: "old"}
+	assert.Nil(t, rules.Validate(account{Email: "a@b.com", Owner: &owner, Roles: []string{"admin"}, Name: "new"}), "Unchanged")
+	other := 2
+	errs := rules.Validate(account{Email: "c@d.com", Owner: &other, Roles: []string{"admin", "user"}}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Changed fields")
+	assert.Equal(t, "Please don't change email", errs[0].Error(), "Message")
+
+	errs = New(&u).Field(&u.Name, Immutable(stored)).Validate(account{Name: "new"}).(ErrorSlice)
+	assert.Equal(t, []string{"name"}, errs[0].Field(), "Original by value")
+}
@@ -0,0 +1,270 @@
+package xvalid
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ExportJS generates a dependency free JavaScript module that validates data with the exported rules, so frontends
+// without a validation framework get the same rules and messages. The module exports validate(data), which returns a
+// list of {field, rule, message} errors. Rules that can't run in the browser, such as remote, decode and taxId, are skipped.
+func (r Rules) ExportJS(options ...ExportOption) ([]byte, error) {
+	// the runtime expects a list of rules for each field
+	options = append(options, func(c *exportConfig) { c.types = false })
+	rules, err := r.Export(options...)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("// Code generated by xvalid. DO NOT EDIT.\n\nconst rules = ")
+	b.Write(rules)
+	b.WriteString(";\nconst phoneRegions = ")
+	regions, err := json.Marshal(phoneRegions)
+	if err != nil {
+		return nil, err
+	}
+	b.Write(regions)
+	b.WriteString(";\n")
+	b.WriteString(jsRuntime)
+	return b.Bytes(), nil
+}
+
+// jsRuntime interprets the exported rules the same way as the validators
+const jsRuntime = `
+function isZero(v) {
+	if (v === undefined || v === null || v === "" || v === 0 || v === false) return true;
+	if (Array.isArray(v)) return v.length === 0;
+	if (typeof v === "object") return Object.keys(v).length === 0;
+	return false;
+}
+
+function regex(pattern) {
+	let flags = "";
+	const m = /^\(\?([a-z]+)\)/.exec(pattern);
+	if (m) {
+		flags = m[1].replace(/[^ims]/g, "");
+		pattern = pattern.slice(m[0].length);
+	}
+	return new RegExp(pattern, flags);
+}
+
+function bytes(s) {
+	return new TextEncoder().encode(s).length;
+}
+
+function asciiEmail(v) {
+	const at = v.lastIndexOf("@");
+	try {
+		return at < 0 ? v : v.slice(0, at + 1) + new URL("http://" + v.slice(at + 1)).hostname;
+	} catch {
+		return v;
+	}
+}
+
+function parseURL(v) {
+	try {
+		return typeof v === "string" ? new URL(v) : null;
+	} catch {
+		return null;
+	}
+}
+
+function isPhone(v, regions) {
+	if (typeof v !== "string") return false;
+	const number = v.replace(/[ .()-]/g, "");
+	if (number.startsWith("+")) {
+		if (!/^\+[1-9][0-9]{1,14}$/.test(number)) return false;
+		if (!regions || regions.length === 0) return true;
+		return regions.some(code => {
+			const r = phoneRegions[code];
+			if (!number.startsWith("+" + r.callingCode)) return false;
+			const length = number.length - 1 - r.callingCode.length;
+			return length >= r.min && length <= r.max;
+		});
+	}
+	if (!/^[0-9]+$/.test(number)) return false;
+	return (regions || []).some(code => {
+		const r = phoneRegions[code];
+		let national = number;
+		if (r.trunk && number.startsWith(r.trunk) && number.length - r.trunk.length >= r.min) {
+			national = number.slice(r.trunk.length);
+		} else if (r.trunk && !r.trunkOptional) {
+			return false;
+		}
+		return national.length >= r.min && national.length <= r.max;
+	});
+}
+
+function formatSize(size) {
+	const units = ["bytes", "KB", "MB", "GB", "TB"];
+	let i = 0;
+	while (size >= 1024 && i < units.length - 1) {
+		size /= 1024;
+		i++;
+	}
+	return Math.round(size * 10) / 10 + " " + units[i];
+}
+
+function count(v) {
+	if (v === undefined || v === null) return 0;
+	if (Array.isArray(v)) return v.length;
+	if (typeof v === "object") return Object.keys(v).length;
+	return NaN;
+}
+
+function check(rule, name, v) {
+	const label = rule.label || name;
+	const isString = typeof v === "string";
+	const isNumber = typeof v === "number";
+	if (rule.trimmed && isString) v = v.trim();
+	const skip = rule.optional && (v === undefined || v === null || v === "" || v === 0);
+	switch (rule.rule) {
+	case "required":
+		return isZero(v) ? "Please enter the " + label : "";
+	case "minLength":
+		if (skip || (isString && [...v].length >= rule.min)) return "";
+		return "Please lengthen " + label + " to " + rule.min + " characters or more";
+	case "maxLength":
+		if (!isString || [...v].length <= rule.max) return "";
+		return "Please shorten " + label + " to " + rule.max + " characters or less";
+	case "length": {
+		const n = isString ? [...v].length : count(v);
+		if ((rule.optional && n === 0) || (n >= rule.min && n <= rule.max)) return "";
+		const unit = v !== null && typeof v === "object" ? " items" : " characters";
+		if (rule.min === rule.max) return "Please change " + label + " to have exactly " + rule.min + unit;
+		return "Please change " + label + " to have " + rule.min + " to " + rule.max + unit;
+	}
+	case "minBytes":
+		if (skip || (isString && bytes(v) >= rule.min)) return "";
+		return "Please lengthen " + label + " to " + rule.min + " bytes or more";
+	case "maxBytes":
+		if (!isString || bytes(v) <= rule.max) return "";
+		return "Please shorten " + label + " to " + rule.max + " bytes or less";
+	case "maxSize": {
+		const size = typeof Blob !== "undefined" && v instanceof Blob ? v.size : isString ? bytes(v) : 0;
+		if (size <= rule.max) return "";
+		return "Please reduce " + label + " to " + formatSize(rule.max) + " or less";
+	}
+	case "minItems":
+		if (count(v) >= rule.min) return "";
+		return "Please add at least " + rule.min + " items to " + label;
+	case "maxItems":
+		if (count(v) <= rule.max) return "";
+		return "Please remove items from " + label + " to have " + rule.max + " or less";
+	case "min":
+		if (skip || (isNumber && (rule.exclusive ? v > rule.min : v >= rule.min))) return "";
+		if (rule.exclusive && rule.duration) return "Please increase " + label + " to be more than " + rule.duration;
+		if (rule.exclusive) return "Please increase " + label + " to be more than " + rule.min;
+		if (rule.duration) return "Please increase " + label + " to be at least " + rule.duration;
+		return "Please increase " + label + " to be " + rule.min + " or more";
+	case "max":
+		if (v === undefined || v === null || (isNumber && (rule.exclusive ? v < rule.max : v <= rule.max))) return "";
+		if (rule.exclusive && rule.duration) return "Please decrease " + label + " to be less than " + rule.duration;
+		if (rule.exclusive) return "Please decrease " + label + " to be less than " + rule.max;
+		if (rule.duration) return "Please decrease " + label + " to be at most " + rule.duration;
+		return "Please decrease " + label + " to be " + rule.max + " or less";
+	case "range":
+		if (skip || (isNumber && (rule.exclusive ? v > rule.min && v < rule.max : v >= rule.min && v <= rule.max))) return "";
+		if (rule.exclusive) return "Please change " + label + " to be more than " + rule.min + " and less than " + rule.max;
+		return "Please change " + label + " to be between " + rule.min + " and " + rule.max;
+	case "before":
+	case "after": {
+		// strings in other layouts are only checked on the server
+		if (v === undefined || v === null || v === "" || rule.layout) return "";
+		const t = typeof v === "string" && /^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$/.test(v) ? Date.parse(v) : NaN;
+		if (isNaN(t)) return "Please enter a valid date for " + label;
+		const limit = rule.now ? Date.now() : Date.parse(rule.time);
+		if (rule.rule === "before" ? t < limit : t > limit) return "";
+		if (rule.now) return "Please change " + label + " to be in the " + (rule.rule === "before" ? "past" : "future");
+		return "Please change " + label + " to be " + rule.rule + " " + rule.time;
+	}
+	case "pattern":
+		if (skip || (isString && regex(rule.pattern).test(v))) return "";
+		return "Please correct " + label + " into a valid format";
+	case "normalized":
+		if (skip || (isString && v === v.normalize(rule.form))) return "";
+		return "Please correct the special characters in " + label;
+	case "type":
+		if (rule.type !== "email" || skip || (isString && regex(rule.pattern).test(rule.mode === "idn" ? asciiEmail(v) : v))) return "";
+		return "Please use a valid email address for " + label;
+	case "url": {
+		if (skip) return "";
+		const u = parseURL(v);
+		if (!u || !u.host) return "Please use a valid URL for " + label;
+		if (rule.schemes && !rule.schemes.includes(u.protocol.slice(0, -1))) return "Please use a " + rule.schemes.join(" or ") + " URL for " + label;
+		return "";
+	}
+	case "cardExpiry": {
+		if (skip) return "";
+		const m = isString ? /^(0[1-9]|1[0-2]) ?\/ ?([0-9]{2}|[0-9]{4})$/.exec(v) : null;
+		if (!m) return "Please enter " + label + " as MM/YY";
+		const year = Number(m[2]) + (m[2].length === 2 ? 2000 : 0);
+		const now = new Date();
+		if (year < now.getFullYear() || (year === now.getFullYear() && Number(m[1]) < now.getMonth() + 1)) {
+			return "Please use a card that hasn't expired for " + label;
+		}
+		return "";
+	}
+	case "phone":
+		if (skip || isPhone(v, rule.regions)) return "";
+		if (!rule.regions) return "Please enter " + label + " with the country code, e.g. +6591234567";
+		return "Please enter a valid phone number for " + label;
+	case "options":
+		for (const o of rule.options) {
+			if (o === v) return "";
+			if (rule.caseInsensitive && isString && typeof o === "string" && o.toLowerCase() === v.toLowerCase()) return "";
+		}
+		return "Please select one of the valid options for " + label;
+	case "notOptions":
+		for (const o of rule.options) {
+			if (o === v || (rule.caseInsensitive && isString && typeof o === "string" && o.toLowerCase() === v.toLowerCase())) {
+				return "Please choose a different " + label;
+			}
+		}
+		return "";
+	}
+	return "";
+}
+
+function apply(list, name, data, errors) {
+	const v = data[name];
+	for (const rule of list) {
+		if (rule.rule === "if") {
+			const branch = check(rule.condition, rule.field, data[rule.field]) === "" ? rule.then : rule.else;
+			apply(branch || [], name, data, errors);
+			continue;
+		}
+		if (rule.rule === "values" || rule.rule === "keys") {
+			if (v === null || typeof v !== "object") continue;
+			for (const key of Object.keys(v).sort()) {
+				const entry = rule.rule === "keys" ? key : v[key];
+				for (const inner of rule.rules) {
+					const message = check(inner, name, entry);
+					if (message) errors.push({field: name + "." + key, rule: inner.rule, message: inner.message || message});
+				}
+			}
+			continue;
+		}
+		if (rule.rule === "each") {
+			if (!Array.isArray(v)) continue;
+			v.forEach((entry, i) => {
+				for (const inner of rule.rules) {
+					const message = check(inner, name, entry);
+					if (message) errors.push({field: name + "[" + i + "]", rule: inner.rule, message: inner.message || message});
+				}
+			});
+			continue;
+		}
+		const message = check(rule, name, v);
+		if (message) errors.push({field: name, rule: rule.rule, message: rule.message || message});
+	}
+}
+
+export function validate(data) {
+	const errors = [];
+	for (const name of Object.keys(rules)) {
+		apply(rules[name], name, data || {}, errors);
+	}
+	return errors;
+}
+`
@@ -0,0 +1,27 @@
+package xvalid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportJS(t *testing.T) {
+	type user struct {
+		Name  string `json:"name"`
+		Notes string `json:"notes"`
+	}
+	u := user{}
+	rules := New(&u).
+		Field(&u.Name, Required(), MaxLength(5)).
+		Field(&u.Notes, MaxLength(100))
+	js, err := rules.ExportJS(WithTypes(), ExcludeFields("notes"))
+	assert.Nil(t, err)
+	code := string(js)
+	assert.True(t, strings.HasPrefix(code, "// Code generated by xvalid. DO NOT EDIT."), "Generated header")
+	assert.Contains(t, code, `"name": [`, "Rules embedded")
+	assert.NotContains(t, code, `"notes"`, "Export options applied")
+	assert.NotContains(t, code, `"type": "string"`, "Types left out")
+	assert.Contains(t, code, "export function validate(data)", "Validate function")
+}
@@ -0,0 +1,132 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// LengthValidator field must have a length within an inclusive range. Strings are counted in characters, and slices,
+// arrays and maps in items.
+type LengthValidator struct {
+	field    []string
+	message  string
+	label    string
+	min      int64
+	max      int64
+	optional bool
+	trimmed  bool
+}
+
+// Field of the field
+func (c *LengthValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *LengthValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *LengthValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *LengthValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is empty
+func (c *LengthValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Trimmed doesn't count leading and trailing white space of strings toward the length
+func (c *LengthValidator) Trimmed() *LengthValidator {
+	c.trimmed = true
+	return c
+}
+
+// Validate the value
+func (c *LengthValidator) Validate(value any) Error {
+	if str, ok := value.(string); ok {
+		if c.trimmed {
+			str = strings.TrimSpace(str)
+		}
+		n := int64(utf8.RuneCountInString(str))
+		if (c.optional && n == 0) || (n >= c.min && n <= c.max) {
+			return nil
+		}
+		return c.newError("characters")
+	}
+	n, ok := countItems(value)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		}
+		return c.newError("characters")
+	}
+	if (c.optional && n == 0) || (n >= c.min && n <= c.max) {
+		return nil
+	}
+	if value == nil {
+		return c.newError("characters")
+	}
+	return c.newError("items")
+}
+
+func (c *LengthValidator) newError(unit string) Error {
+	if c.min == c.max {
+		return createError(c.field, c.message, fmt.Sprintf("Please change %s to have exactly %d %s",
+			fieldLabel(c.field, c.label), c.min, unit))
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please change %s to have %d to %d %s",
+		fieldLabel(c.field, c.label), c.min, c.max, unit))
+}
+
+// MarshalJSON for this validator
+func (c *LengthValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule     string `json:"rule"`
+		Min      int64  `json:"min"`
+		Max      int64  `json:"max"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+		Trimmed  bool   `json:"trimmed,omitempty"`
+	}{"length", c.min, c.max, c.message, c.label, c.optional, c.trimmed})
+}
+
+// CanExport for this validator
+func (c *LengthValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *LengthValidator) Params() map[string]any {
+	return map[string]any{"min": c.min, "max": c.max, "optional": c.optional, "trimmed": c.trimmed}
+}
+
+// Length field must have min to max characters for strings, or items for slices, arrays and maps, both included.
+// Nil slices and maps have no items.
+func Length(min int64, max int64) *LengthValidator {
+	if min < 0 || min > max {
+		panic(fmt.Errorf("length range not valid: %d to %d", min, max))
+	}
+	return &LengthValidator{
+		min:      min,
+		max:      max,
+		optional: config.OptionalByDefault,
+	}
+}
+
+// ExactLength field must have exactly n characters for strings, or items for slices, arrays and maps
+func ExactLength(n int64) *LengthValidator {
+	return Length(n, n)
+}
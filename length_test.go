@@ -0,0 +1,47 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLength(t *testing.T) {
+	assert.Nil(t, Length(2, 4).Validate("日本語"), "Counts runes")
+	assert.NotNil(t, Length(2, 4).Validate("日本語です"), "Too long")
+	assert.NotNil(t, Length(2, 4).Validate("a"), "Too short")
+	assert.Nil(t, Length(2, 4).Validate([]int{1, 2}), "Slice")
+	assert.Nil(t, Length(2, 4).Validate([3]int{}), "Array")
+	assert.NotNil(t, Length(2, 4).Validate(map[string]int{"a": 1}), "Map")
+	assert.NotNil(t, Length(2, 4).Validate([]int(nil)), "Nil slice")
+	assert.Nil(t, Length(2, 4).SetOptional().Validate(""), "Optional")
+	assert.Nil(t, Length(2, 4).SetOptional().Validate([]int{}), "Optional slice")
+	assert.Nil(t, Length(2, 4).Validate(" a  "), "Spaces counted")
+	assert.NotNil(t, Length(2, 4).Trimmed().Validate(" a  "), "Trimmed")
+	assert.Nil(t, ExactLength(3).Validate("abc"), "Exact")
+	assert.NotNil(t, ExactLength(3).Validate([]string{"a"}), "Exact items")
+	assert.NotNil(t, Length(2, 4).Validate(3), "Mismatch")
+	assert.Panics(t, func() { Length(4, 2) }, "Min more than max")
+
+	type account struct {
+		PIN   string   `json:"pin"`
+		Codes []string `json:"codes"`
+	}
+	a := account{}
+	rules := New(&a).Field(&a.PIN, ExactLength(4)).Field(&a.Codes, Length(1, 3))
+	errs := rules.Validate(account{PIN: "12345", Codes: []string{"a", "b", "c", "d"}}).(ErrorSlice)
+	assert.Equal(t, "Please change pin to have exactly 4 characters", errs[0].Error(), "Exact message")
+	assert.Equal(t, "Please change codes to have 1 to 3 items", errs[1].Error(), "Range message")
+	assert.Equal(t, "length", errs[0].(interface{ Rule() string }).Rule(), "Rule name")
+
+	j, _ := json.Marshal(ExactLength(4).Trimmed())
+	assert.Equal(t, `{"rule":"length","min":4,"max":4,"trimmed":true}`, string(j), "Export")
+
+	exported, _ := rules.Export()
+	loaded, err := New(&a).Load([]byte(exported))
+	assert.Nil(t, err, "Load")
+	assert.Len(t, loaded.Validate(account{PIN: "12345", Codes: []string{}}), 2, "Loaded")
+	_, err = New(&a).Load([]byte(`{"pin":[{"rule":"length","min":4,"max":3}]}`))
+	assert.NotNil(t, err, "Load min more than max")
+}
@@ -0,0 +1,642 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// loadedRule holds the keys of exported rules that are read by the built in loaders
+type loadedRule struct {
+	Rule             string          `json:"rule"`
+	Min              int64           `json:"min"`
+	Max              int64           `json:"max"`
+	Exclusive        bool            `json:"exclusive"`
+	Duration         string          `json:"duration"`
+	Trimmed          bool            `json:"trimmed"`
+	Pattern          string          `json:"pattern"`
+	Type             string          `json:"type"`
+	Mode             string          `json:"mode"`
+	Form             string          `json:"form"`
+	Options          []any           `json:"options"`
+	CaseInsensitive  bool            `json:"caseInsensitive"`
+	Schemes          []string        `json:"schemes"`
+	DenyPrivateHosts bool            `json:"denyPrivateHosts"`
+	Regions          []string        `json:"regions"`
+	Kind             string          `json:"kind"`
+	Types            []string        `json:"types"`
+	Formats          []string        `json:"formats"`
+	MaxWidth         int             `json:"maxWidth"`
+	MaxHeight        int             `json:"maxHeight"`
+	MaxMegapixels    float64         `json:"maxMegapixels"`
+	URL              string          `json:"url"`
+	Field            string          `json:"field"`
+	Condition        json.RawMessage `json:"condition"`
+	Then             json.RawMessage `json:"then"`
+	Else             json.RawMessage `json:"else"`
+	Rules            json.RawMessage `json:"rules"`
+	Time             string          `json:"time"`
+	Now              bool            `json:"now"`
+	Layout           string          `json:"layout"`
+	Message          string          `json:"message"`
+	Label            string          `json:"label"`
+	Optional         bool            `json:"optional"`
+}
+
+// builtInRule registers a built in rule with the key its value is assigned to in YAML
+func builtInRule(name string, param string, load func(r loadedRule) (Validator, error)) {
+	extensions[name] = Extension{
+		Name:  name,
+		Param: param,
+		Load: func(data []byte) (Validator, error) {
+			var r loadedRule
+			if err := json.Unmarshal(data, &r); err != nil {
+				return nil, err
+			}
+			return load(r)
+		},
+	}
+}
+
+func init() {
+	builtInRule("required", "", func(r loadedRule) (Validator, error) { return Required(), nil })
+	builtInRule("minLength", "min", func(r loadedRule) (Validator, error) {
+		v := MinLength(r.Min)
+		if r.Trimmed {
+			v.Trimmed()
+		}
+		return v, nil
+	})
+	builtInRule("maxLength", "max", func(r loadedRule) (Validator, error) {
+		v := MaxLength(r.Max)
+		if r.Trimmed {
+			v.Trimmed()
+		}
+		return v, nil
+	})
+	builtInRule("length", "", func(r loadedRule) (Validator, error) {
+		if r.Min < 0 || r.Min > r.Max {
+			return nil, fmt.Errorf("length range not valid: %d to %d", r.Min, r.Max)
+		}
+		v := Length(r.Min, r.Max)
+		if r.Trimmed {
+			v.Trimmed()
+		}
+		return v, nil
+	})
+	builtInRule("minBytes", "min", func(r loadedRule) (Validator, error) { return MinBytes(r.Min), nil })
+	builtInRule("maxBytes", "max", func(r loadedRule) (Validator, error) { return MaxBytes(r.Max), nil })
+	builtInRule("minItems", "min", func(r loadedRule) (Validator, error) { return MinItems(r.Min), nil })
+	builtInRule("maxItems", "max", func(r loadedRule) (Validator, error) { return MaxItems(r.Max), nil })
+	builtInRule("min", "min", func(r loadedRule) (Validator, error) {
+		v := Min(r.Min)
+		if r.Duration != "" {
+			v = MinDuration(time.Duration(r.Min))
+		}
+		if r.Exclusive {
+			v.Exclusive()
+		}
+		return v, nil
+	})
+	builtInRule("max", "max", func(r loadedRule) (Validator, error) {
+		v := Max(r.Max)
+		if r.Duration != "" {
+			v = MaxDuration(time.Duration(r.Max))
+		}
+		if r.Exclusive {
+			v.Exclusive()
+		}
+		return v, nil
+	})
+	builtInRule("pattern", "pattern", func(r loadedRule) (Validator, error) { return Pattern(r.Pattern), nil })
+	builtInRule("type", "type", func(r loadedRule) (Validator, error) {
+		if r.Type != "email" {
+			return nil, fmt.Errorf("type not supported: %s", r.Type)
+		}
+		mode, ok := map[string]EmailMode{"": EmailRegex, "rfc5322": EmailRFC5322, "idn": EmailIDN}[r.Mode]
+		if !ok {
+			return nil, fmt.Errorf("email mode not supported: %s", r.Mode)
+		}
+		return Email().Mode(mode), nil
+	})
+	builtInRule("normalized", "form", func(r loadedRule) (Validator, error) {
+		form, ok := map[string]norm.Form{"NFC": norm.NFC, "NFD": norm.NFD, "NFKC": norm.NFKC, "NFKD": norm.NFKD}[r.Form]
+		if !ok {
+			return nil, fmt.Errorf("form not supported: %s", r.Form)
+		}
+		return Normalized(form), nil
+	})
+	builtInRule("options", "options", func(r loadedRule) (Validator, error) {
+		v := Options(r.Options...)
+		if r.CaseInsensitive {
+			v.CaseInsensitive()
+		}
+		return v, nil
+	})
+	builtInRule("notOptions", "options", func(r loadedRule) (Validator, error) {
+		v := NotOptions(r.Options...)
+		if r.CaseInsensitive {
+			v.CaseInsensitive()
+		}
+		return v, nil
+	})
+	builtInRule("url", "schemes", func(r loadedRule) (Validator, error) {
+		v := URL().AllowSchemes(r.Schemes...)
+		if r.DenyPrivateHosts {
+			v.DenyPrivateHosts()
+		}
+		return v, nil
+	})
+	builtInRule("phone", "regions", func(r loadedRule) (v Validator, err error) {
+		// Region panics on unknown regions
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("%v", p)
+			}
+		}()
+		return Phone().Region(r.Regions...), nil
+	})
+	builtInRule("taxId", "kind", func(r loadedRule) (Validator, error) {
+		if _, ok := taxIDCheck(r.Kind); !ok {
+			return nil, fmt.Errorf("tax ID not supported: %s", r.Kind)
+		}
+		return TaxID(r.Kind), nil
+	})
+	builtInRule("cardExpiry", "", func(r loadedRule) (Validator, error) { return CardExpiry(), nil })
+	builtInRule("contentType", "types", func(r loadedRule) (Validator, error) { return ContentType(r.Types...), nil })
+	builtInRule("maxSize", "max", func(r loadedRule) (Validator, error) { return MaxSize(r.Max), nil })
+	extensions["range"] = Extension{
+		Name: "range",
+		Load: func(data []byte) (Validator, error) {
+			var r struct {
+				Min       json.Number `json:"min"`
+				Max       json.Number `json:"max"`
+				Exclusive bool        `json:"exclusive"`
+			}
+			if err := json.Unmarshal(data, &r); err != nil {
+				return nil, err
+			}
+			// integer bounds stay int64 so large ones keep their precision
+			var v *RangeValidator
+			minInt, minErr := r.Min.Int64()
+			maxInt, maxErr := r.Max.Int64()
+			if minErr == nil && maxErr == nil {
+				if minInt > maxInt {
+					return nil, fmt.Errorf("min %v is more than max %v", minInt, maxInt)
+				}
+				v = Range(minInt, maxInt)
+			} else {
+				min, err := r.Min.Float64()
+				if err != nil {
+					return nil, err
+				}
+				max, err := r.Max.Float64()
+				if err != nil {
+					return nil, err
+				}
+				if min > max {
+					return nil, fmt.Errorf("min %v is more than max %v", min, max)
+				}
+				v = RangeFloat(min, max)
+			}
+			if r.Exclusive {
+				v.Exclusive()
+			}
+			return v, nil
+		},
+	}
+	builtInRule("before", "time", func(r loadedRule) (Validator, error) {
+		if r.Now {
+			return BeforeNow().Layout(layoutOrDefault(r.Layout)), nil
+		}
+		t, err := time.Parse(time.RFC3339, r.Time)
+		return Before(t).Layout(layoutOrDefault(r.Layout)), err
+	})
+	builtInRule("after", "time", func(r loadedRule) (Validator, error) {
+		if r.Now {
+			return AfterNow().Layout(layoutOrDefault(r.Layout)), nil
+		}
+		t, err := time.Parse(time.RFC3339, r.Time)
+		return After(t).Layout(layoutOrDefault(r.Layout)), err
+	})
+	builtInRule("dateFormat", "layout", func(r loadedRule) (Validator, error) { return DateFormat(r.Layout), nil })
+	builtInRule("image", "formats", func(r loadedRule) (Validator, error) {
+		v := Image().Formats(r.Formats...).MaxMegapixels(r.MaxMegapixels)
+		v.maxWidth, v.maxHeight = r.MaxWidth, r.MaxHeight
+		return v, nil
+	})
+	builtInRule("remote", "url", func(r loadedRule) (Validator, error) {
+		if r.URL == "" {
+			return nil, errors.New("missing url")
+		}
+		return Remote(r.URL, RemoteOptions{}), nil
+	})
+	builtInRule("if", "", func(r loadedRule) (Validator, error) {
+		if len(r.Condition) == 0 {
+			return nil, errors.New("missing condition")
+		}
+		predicate, err := loadRule(r.Condition)
+		if err != nil {
+			return nil, err
+		}
+		v := If(nil, predicate)
+		v.fieldName = r.Field
+		if len(r.Then) > 0 {
+			if v.then, err = loadRules(r.Then); err != nil {
+				return nil, err
+			}
+		}
+		if len(r.Else) > 0 {
+			if v.otherwise, err = loadRules(r.Else); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	})
+	builtInRule("nested", "rules", func(r loadedRule) (Validator, error) {
+		return &NestedValidator{loaded: r.Rules}, nil
+	})
+	builtInRule("decode", "rules", func(r loadedRule) (Validator, error) {
+		rules, err := decodedRules(r.Rules)
+		return Decode(rules), err
+	})
+	builtInRule("each", "rules", func(r loadedRule) (Validator, error) {
+		validators, err := loadRules(r.Rules)
+		return Each(validators...), err
+	})
+	builtInRule("keys", "rules", func(r loadedRule) (Validator, error) {
+		validators, err := loadRules(r.Rules)
+		return Keys(validators...), err
+	})
+	builtInRule("values", "rules", func(r loadedRule) (Validator, error) {
+		validators, err := loadRules(r.Rules)
+		return Values(validators...), err
+	})
+}
+
+// layoutOrDefault returns time.RFC3339 for rules exported without a layout
+func layoutOrDefault(layout string) string {
+	if layout == "" {
+		return time.RFC3339
+	}
+	return layout
+}
+
+// loadRules creates the validators of a list of exported rules
+func loadRules(data json.RawMessage) ([]Validator, error) {
+	var list []json.RawMessage
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	validators := make([]Validator, len(list))
+	for i, rule := range list {
+		v, err := loadRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		validators[i] = v
+	}
+	return validators, nil
+}
+
+// loadRule creates the validator of an exported rule
+func loadRule(data []byte) (Validator, error) {
+	// only the common keys, since the type of the parameters depends on the rule
+	var r struct {
+		Rule     string `json:"rule"`
+		Message  string `json:"message"`
+		Label    string `json:"label"`
+		Optional bool   `json:"optional"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	ext, ok := lookupExtension(r.Rule)
+	if !ok {
+		return nil, fmt.Errorf("rule not supported: %s", r.Rule)
+	}
+	v, err := ext.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", r.Rule, err)
+	}
+	if r.Message != "" {
+		v.SetMessage(r.Message)
+	}
+	if r.Label != "" {
+		setLabel(v, r.Label)
+	}
+	if o, ok := v.(interface{ SetOptional() Validator }); ok && r.Optional {
+		o.SetOptional()
+	}
+	return v, nil
+}
+
+// Load replaces the rules of the fields in the JSON, which has the same format as the exported rules. Fields that
+// aren't in the JSON keep their rules, so the JSON only needs the rules that should change, e.g. a tighter maxLength.
+// Fields are found by their JSON name, or a dotted path like "address.city" if fields of nested structs share the
+// name. Rules of other packages are loaded once added with Register or RegisterRule.
+func (r Rules) Load(data []byte) (Rules, error) {
+	var fields map[string][]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return r, err
+	}
+	loaded := make(map[string][]Validator, len(fields))
+	for name, rules := range fields {
+		field, t, err := findFieldPath(reflect.TypeOf(r.structPtr), name)
+		if err != nil {
+			return r, err
+		}
+		validators := make([]Validator, len(rules))
+		for i, data := range rules {
+			v, err := loadRule(data)
+			if err != nil {
+				return r, fmt.Errorf("%s: %w", name, err)
+			}
+			v.SetField(field...)
+			if sl, ok := v.(structLoader); ok {
+				if err := sl.loadStruct(reflect.TypeOf(r.structPtr), t); err != nil {
+					return r, fmt.Errorf("%s: %w", name, err)
+				}
+			}
+			switch o := v.(type) {
+			case *OptionsValidator:
+				o.options = convertOptions(o.options, t)
+			case *NotOptionsValidator:
+				o.options = convertOptions(o.options, t)
+			}
+			if label, ok := r.labels[strings.Join(field, ".")]; ok {
+				setLabel(v, label)
+			}
+			validators[i] = v
+		}
+		loaded[name] = validators
+	}
+	// loaded fields take the place of the first validator of the field
+	validators := make([]Validator, 0, len(r.validators))
+	for _, v := range r.validators {
+		name := jsonFieldName(v.Field())
+		if list, ok := loaded[name]; !ok {
+			if _, replaced := fields[name]; !replaced {
+				validators = append(validators, v)
+			}
+		} else {
+			validators = append(validators, list...)
+			delete(loaded, name)
+		}
+	}
+	names := make([]string, 0, len(loaded))
+	for name := range loaded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		validators = append(validators, loaded[name]...)
+	}
+	r.validators = validators
+	return r, nil
+}
+
+// ParseRules creates rules for the struct from exported JSON, e.g. rules received from another service. Unlike Load,
+// the rules are only those in the JSON.
+func ParseRules(structPtr any, data []byte) (Rules, error) {
+	return New(structPtr).Load(data)
+}
+
+// UnmarshalJSON replaces the rules with the exported JSON. The rules must be created with New first so the fields can
+// be found, e.g. rules := New(&u) before json.Unmarshal(data, &rules).
+func (r *Rules) UnmarshalJSON(data []byte) error {
+	if r.structPtr == nil {
+		return errors.New("rules need a struct, create them with New before decoding")
+	}
+	base := *r
+	base.validators = make([]Validator, 0)
+	loaded, err := base.Load(data)
+	if err != nil {
+		return err
+	}
+	*r = loaded
+	return nil
+}
+
+// structLoader is implemented by loaded validators that need the struct they are loaded for, e.g. to find the fields
+// they refer to. The type of the field of the validator is given as well.
+type structLoader interface {
+	loadStruct(structType reflect.Type, fieldType reflect.Type) error
+}
+
+// decodedRules loads the exported rules of a decoded document into a struct with a field of any type for each field,
+// since the struct the rules were exported from isn't known. Nested fields are expected at the top level.
+func decodedRules(data json.RawMessage) (Rules, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Rules{}, err
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	structFields := make([]reflect.StructField, len(names))
+	for i, name := range names {
+		structFields[i] = reflect.StructField{
+			Name: fmt.Sprintf("F%d", i),
+			Type: reflect.TypeOf((*any)(nil)).Elem(),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:%q`, name)),
+		}
+	}
+	return ParseRules(reflect.New(reflect.StructOf(structFields)).Interface(), data)
+}
+
+// convertOptions converts JSON numbers to the type of the field, so they are equal to the values of the field
+func convertOptions(options []any, t reflect.Type) []any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i, o := range options {
+		if f, ok := o.(float64); ok && t.Kind() != reflect.Interface && reflect.TypeOf(f).ConvertibleTo(t) {
+			options[i] = reflect.ValueOf(f).Convert(t).Interface()
+		}
+	}
+	return options
+}
+
+// findFieldPath returns the path and type of the field with the JSON name, searching nested structs. The shallowest
+// field with the name is found, and it's an error if there are more than one at that depth. A dotted path like
+// "address.city" picks the field of a nested struct.
+func findFieldPath(t reflect.Type, name string) ([]string, reflect.Type, error) {
+	if strings.Contains(name, ".") {
+		path := strings.Split(name, ".")
+		if sf, ok := structField(t, path); ok {
+			return path, sf.Type, nil
+		}
+	}
+	var paths [][]string
+	var types []reflect.Type
+	findFieldPaths(t, name, nil, make(map[reflect.Type]bool), func(path []string, ft reflect.Type) {
+		if len(paths) > 0 && len(path) > len(paths[0]) {
+			return
+		}
+		if len(paths) > 0 && len(path) < len(paths[0]) {
+			paths, types = nil, nil
+		}
+		paths = append(paths, path)
+		types = append(types, ft)
+	})
+	switch len(paths) {
+	case 0:
+		return nil, nil, fmt.Errorf("can't find field: %s", name)
+	case 1:
+		return paths[0], types[0], nil
+	}
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = strings.Join(p, ".")
+	}
+	return nil, nil, fmt.Errorf("field %s is ambiguous, use its path: %s", name, strings.Join(names, ", "))
+}
+
+// findFieldPaths calls found with the path and type of every field with the JSON name in the struct and its nested
+// structs
+func findFieldPaths(t reflect.Type, name string, path []string, visited map[reflect.Type]bool, found func([]string, reflect.Type)) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if tag == "" {
+			tag = sf.Name
+		}
+		p := append(append([]string{}, path...), tag)
+		if tag == name {
+			found(p, sf.Type)
+		}
+		findFieldPaths(sf.Type, name, p, visited, found)
+	}
+}
+
+// RulesFile keeps rules loaded from a JSON or YAML file up to date, so limits can be changed without a deploy. The
+// rules are swapped atomically, so validation never sees a partly loaded file.
+type RulesFile struct {
+	path     string
+	base     Rules
+	rules    atomic.Pointer[Rules]
+	mutex    sync.Mutex
+	modified time.Time
+	size     int64
+	done     chan struct{}
+	stop     sync.Once
+}
+
+// WatchRules loads the file with Rules.Load on top of the base rules, then checks the file for changes every interval
+// until Close is called. Files ending in .yaml or .yml are loaded with Rules.LoadYAML instead. If a changed file can't
+// be loaded, the previous rules are kept and onError is called if it isn't nil. An error is returned if the file can't
+// be loaded the first time. An interval of zero doesn't watch the file, so it's only loaded again with Reload.
+func WatchRules(path string, base Rules, interval time.Duration, onError func(error)) (*RulesFile, error) {
+	f := &RulesFile{
+		path: path,
+		base: base,
+		done: make(chan struct{}),
+	}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	if interval > 0 {
+		go f.watch(interval, onError)
+	}
+	return f, nil
+}
+
+// Rules that are currently loaded
+func (f *RulesFile) Rules() Rules {
+	return *f.rules.Load()
+}
+
+// Validate the subject with the rules that are currently loaded
+func (f *RulesFile) Validate(subject any) error {
+	return f.Rules().Validate(subject)
+}
+
+// Reload the file now. The current rules are kept if it fails.
+func (f *RulesFile) Reload() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	return f.load(info)
+}
+
+// load the file, remembering its modification time and size so it isn't loaded again until it changes
+func (f *RulesFile) load(info os.FileInfo) error {
+	f.modified, f.size = info.ModTime(), info.Size()
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	if ext := strings.ToLower(filepath.Ext(f.path)); ext == ".yaml" || ext == ".yml" {
+		if data, err = yamlRules(data); err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+	}
+	rules, err := f.base.Load(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.path, err)
+	}
+	f.rules.Store(&rules)
+	return nil
+}
+
+// Close stops watching the file
+func (f *RulesFile) Close() {
+	f.stop.Do(func() { close(f.done) })
+}
+
+// watch reloads the file when its modification time or size changes
+func (f *RulesFile) watch(interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+		}
+		if err := f.reloadChanged(); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// reloadChanged loads the file if its modification time or size changed
+func (f *RulesFile) reloadChanged() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(f.modified) && info.Size() == f.size {
+		return nil
+	}
+	return f.load(info)
+}
@@ -0,0 +1,232 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestLoad(t *testing.T) {
+	type profile struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Level int    `json:"level"`
+	}
+	p := profile{}
+	base := New(&p).
+		Field(&p.Name, Required(), MaxLength(50)).
+		Field(&p.Email, Email())
+
+	rules, err := base.Load([]byte(`{"name":[{"rule":"required"},{"rule":"maxLength","max":5,"message":"Too long"}]}`))
+	assert.Nil(t, err, "Load")
+	j, _ := json.Marshal(rules)
+	assert.Contains(t, string(j),
+		`{"name":[{"rule":"required"},{"rule":"maxLength","max":5,"message":"Too long"}],"email":[{"rule":"type"`,
+		"Replace rules of field")
+	assert.Equal(t, "Too long", rules.Validate(profile{Name: "toolong"}).(ErrorSlice)[0].Error(), "Loaded message")
+	assert.Nil(t, base.Validate(profile{Name: "toolong", Email: "a@b.com"}), "Base unchanged")
+
+	rules, err = base.Load([]byte(`{"level":[{"rule":"options","options":[1,2]}],"email":[]}`))
+	assert.Nil(t, err, "Load new field")
+	assert.Nil(t, rules.Validate(profile{Name: "a", Email: "invalid", Level: 2}), "Options converted to field type and email rules removed")
+	assert.Len(t, rules.Validate(profile{Name: "a", Level: 3}), 1, "Loaded options")
+
+	_, err = base.Load([]byte(`{"missing":[{"rule":"required"}]}`))
+	assert.EqualError(t, err, "can't find field: missing", "Unknown field")
+
+	type place struct {
+		City string `json:"city"`
+	}
+	type trip struct {
+		From place `json:"from"`
+		To   place `json:"to"`
+	}
+	tr := trip{}
+	_, err = New(&tr).Load([]byte(`{"city":[{"rule":"required"}]}`))
+	assert.EqualError(t, err, "field city is ambiguous, use its path: from.city, to.city", "Ambiguous field")
+	loaded, err := New(&tr).Load([]byte(`{"to.city":[{"rule":"required"}]}`))
+	assert.Nil(t, err, "Field path")
+	assert.Equal(t, []string{"to", "city"}, loaded.Validate(trip{From: place{"Paris"}}).(ErrorSlice)[0].Field(), "Field of path")
+	_, err = base.Load([]byte(`{"name":[{"rule":"unknown"}]}`))
+	assert.EqualError(t, err, "name: rule not supported: unknown", "Unknown rule")
+	_, err = base.Load([]byte(`{"name":[{"rule":"phone","regions":["XX"]}]}`))
+	assert.EqualError(t, err, "name: phone: phone region not supported: XX", "Invalid params")
+
+	RegisterRule("lowercase", func(data []byte) (Validator, error) {
+		return Pattern(`^[a-z]*$`), nil
+	})
+	rules, err = base.Load([]byte(`{"name":[{"rule":"lowercase"}]}`))
+	assert.Nil(t, err, "Load registered rule")
+	assert.Len(t, rules.Validate(profile{Name: "ABC", Email: "a@b.com"}), 1, "Registered rule")
+}
+
+func TestWatchRules(t *testing.T) {
+	type upload struct {
+		Name string `json:"name"`
+	}
+	u := upload{}
+	base := New(&u).Field(&u.Name, MaxLength(10))
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("name:\n  - rule: maxLength\n    max: 5\n"), 0o644), "Write file")
+
+	errs := make(chan error, 10)
+	f, err := WatchRules(path, base, 10*time.Millisecond, func(err error) { errs <- err })
+	assert.Nil(t, err, "Watch")
+	defer f.Close()
+	assert.Len(t, f.Validate(upload{Name: "sixchr"}), 1, "Loaded YAML")
+
+	assert.Nil(t, os.WriteFile(path, []byte("name:\n  - rule: maxLength\n    max: 10\n"), 0o644), "Change file")
+	assert.Eventually(t, func() bool { return f.Validate(upload{Name: "sixchr"}) == nil }, time.Second, 5*time.Millisecond, "Reloaded")
+
+	assert.Nil(t, os.WriteFile(path, []byte("name: [{rule: unknown}]"), 0o644), "Break file")
+	select {
+	case err := <-errs:
+		assert.ErrorContains(t, err, "rule not supported", "Reload error")
+	case <-time.After(time.Second):
+		t.Error("No reload error")
+	}
+	assert.Nil(t, f.Validate(upload{Name: "sixchr"}), "Previous rules kept")
+
+	_, err = WatchRules(filepath.Join(t.TempDir(), "missing.json"), base, 0, nil)
+	assert.NotNil(t, err, "Missing file")
+}
+
+func TestParseRules(t *testing.T) {
+	type order struct {
+		Name   string            `json:"name"`
+		Qty    int               `json:"qty"`
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+	}
+	o := order{}
+	exported, _ := New(&o).
+		Field(&o.Name, Required(), MaxLength(20).SetMessage("Too long")).
+		Field(&o.Qty, Min(1), Options(1, 5, 10)).
+		Field(&o.Tags, Each(MinLength(2))).
+		Field(&o.Labels, Keys(Pattern(`^[a-z]+$`)), Values(MaxLength(3))).
+		Export()
+
+	rules, err := ParseRules(&o, exported)
+	assert.Nil(t, err, "Parsed")
+	j, _ := rules.Export()
+	assert.JSONEq(t, string(exported), string(j), "Round trip")
+	assert.Nil(t, rules.Validate(order{Name: "a", Qty: 5, Tags: []string{"go"}}), "Options converted to field type")
+	errs := rules.Validate(order{Qty: 2, Tags: []string{"x"}, Labels: map[string]string{"A": "long"}}).(ErrorSlice)
+	assert.Len(t, errs, 5, "Parsed rules")
+	assert.Equal(t, []string{"tags", "0"}, errs[4].Field(), "Nested rules bound to field")
+
+	decoded := New(&o).Field(&o.Qty, Max(3))
+	assert.Nil(t, json.Unmarshal([]byte(`{"name":[{"rule":"required"}]}`), &decoded), "Unmarshal")
+	j, _ = json.Marshal(decoded)
+	assert.Equal(t, `{"name":[{"rule":"required"}]}`, string(j), "Only rules of the JSON")
+
+	var empty Rules
+	assert.EqualError(t, json.Unmarshal([]byte(`{}`), &empty), "rules need a struct, create them with New before decoding",
+		"Without struct")
+	_, err = ParseRules(&o, []byte(`{"tags":[{"rule":"each","rules":[{"rule":"unknown"}]}]}`))
+	assert.EqualError(t, err, "tags: each: rule not supported: unknown", "Unknown nested rule")
+}
+
+func TestParseRulesRoundTrip(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type config struct {
+		Host string `json:"host"`
+	}
+	type everything struct {
+		Name     string            `json:"name"`
+		Code     string            `json:"code"`
+		Email    string            `json:"email"`
+		Website  string            `json:"website"`
+		Phone    string            `json:"phone"`
+		VAT      string            `json:"vat"`
+		Expiry   string            `json:"expiry"`
+		Level    int               `json:"level"`
+		Score    float64           `json:"score"`
+		Timeout  time.Duration     `json:"timeout"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Start    time.Time         `json:"start"`
+		End      time.Time         `json:"end"`
+		Birthday string            `json:"birthday"`
+		Avatar   []byte            `json:"avatar"`
+		Address  address           `json:"address"`
+		Config   json.RawMessage   `json:"config"`
+	}
+	e, a, c := everything{}, address{}, config{}
+	limit := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	rules := New(&e).
+		Field(&e.Name, Required(), MinLength(2), MaxLength(20).SetMessage("Too long"), MinBytes(2), MaxBytes(80)).
+		Field(&e.Code, Length(4, 4), Pattern(`^[A-Z]+$`), Normalized(norm.NFC), NotOptions("NONE")).
+		Field(&e.Email, Email()).
+		Field(&e.Website, URL().AllowSchemes("https").DenyPrivateHosts(), Remote("https://example.com/check", RemoteOptions{})).
+		Field(&e.Phone, Phone().Region("SG")).
+		Field(&e.VAT, VAT("DE")).
+		Field(&e.Expiry, CardExpiry()).
+		Field(&e.Level, Min(1), Max(10).Exclusive(), Options(1, 5, 9), If(&e.Name, Required()).Then(Min(5)).Else(Max(3))).
+		Field(&e.Score, RangeFloat(0.5, 9.5)).
+		Field(&e.Timeout, MinDuration(time.Second)).
+		Field(&e.Tags, MinItems(1), MaxItems(5), Each(MinLength(2))).
+		Field(&e.Labels, Keys(Pattern(`^[a-z]+$`)), Values(MaxLength(3))).
+		Field(&e.Start, AfterNow()).
+		Field(&e.End, Before(limit)).
+		Field(&e.Birthday, DateFormat("2006-01-02")).
+		Field(&e.Avatar, MaxSize(1<<20), ContentType("image/png"), Image().Formats("png").MaxDims(100, 100)).
+		Nested(&e.Address, New(&a).Field(&a.City, Required())).
+		Field(&e.Config, Decode(New(&c).Field(&c.Host, Required())))
+	exported, err := rules.Export()
+	assert.Nil(t, err, "Export")
+
+	parsed, err := ParseRules(&e, exported)
+	if !assert.Nil(t, err, "Parse") {
+		return
+	}
+	j, _ := parsed.Export()
+	assert.JSONEq(t, string(exported), string(j), "Round trip")
+
+	// every built in rule is covered, other than those registered by other tests
+	var doc map[string][]map[string]any
+	assert.Nil(t, json.Unmarshal(exported, &doc))
+	names := make(map[string]bool)
+	var collect func(rule map[string]any)
+	collect = func(rule map[string]any) {
+		names[rule["rule"].(string)] = true
+		for _, key := range []string{"rules", "then", "else"} {
+			if list, ok := rule[key].([]any); ok {
+				for _, r := range list {
+					collect(r.(map[string]any))
+				}
+			}
+		}
+		if c, ok := rule["condition"].(map[string]any); ok {
+			collect(c)
+		}
+	}
+	for _, list := range doc {
+		for _, rule := range list {
+			collect(rule)
+		}
+	}
+	for _, ext := range Extensions() {
+		if ext.Validator == nil && ext.Name != "lowercase" && ext.Name != "yamlCustom" {
+			assert.True(t, names[ext.Name], ext.Name)
+		}
+	}
+
+	subject := everything{Name: "ab", Level: 3, Config: json.RawMessage(`{}`)}
+	errs := parsed.Validate(subject).(ErrorSlice)
+	fields := make([]string, len(errs))
+	for i, err := range errs {
+		fields[i] = strings.Join(err.Field(), ".")
+	}
+	assert.Contains(t, fields, "address.city", "Nested rules parsed")
+	assert.Contains(t, fields, "config.host", "Decode rules parsed")
+	assert.Contains(t, fields, "level", "If parsed")
+}
@@ -0,0 +1,160 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// MapValidator applies validators to every key or value of a map field. Errors are reported on the field with the
+// key appended, e.g. ["labels", "color"].
+type MapValidator struct {
+	field      []string
+	keys       bool
+	validators []Validator
+}
+
+// Field of the field
+func (c *MapValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *MapValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.validators {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators
+func (c *MapValidator) SetMessage(msg string) Validator {
+	for _, v := range c.validators {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *MapValidator) SetLabel(label string) Validator {
+	for _, v := range c.validators {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Validate the value and return the first error
+func (c *MapValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value with the context and returns the first error
+func (c *MapValidator) ValidateCtx(ctx context.Context, value any) Error {
+	if errs := c.validateMap(ctx, value); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (c *MapValidator) resolveFields(structPtr any) {}
+
+func (c *MapValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *MapValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	value, ok := fieldValue(vmap, c.field)
+	if !ok {
+		return nil
+	}
+	return c.validateMap(ctx, value)
+}
+
+// validateMap validates each entry in order of the keys
+func (c *MapValidator) validateMap(ctx context.Context, value any) ErrorSlice {
+	errs := make(ErrorSlice, 0)
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map {
+		return errs
+	}
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	for _, k := range keys {
+		element := elementValue(v.MapIndex(k))
+		if c.keys {
+			element = elementValue(k)
+		}
+		for _, validator := range c.validators {
+			if err := validateValue(ctx, validator, element); err != nil {
+				err = withField(err, elementField(c.field, fmt.Sprint(k.Interface()), err.Field()))
+				errs = append(errs, setRule(ErrorSlice{err}, ruleName(validator))...)
+			}
+		}
+	}
+	return errs
+}
+
+// CanExport for this validator
+func (c *MapValidator) CanExport() bool {
+	for _, v := range c.validators {
+		if !v.CanExport() {
+			return false
+		}
+	}
+	return true
+}
+
+// Params of this validator
+func (c *MapValidator) Params() map[string]any {
+	return map[string]any{"keys": c.keys, "validators": c.validators}
+}
+
+// MarshalJSON for this validator
+func (c *MapValidator) MarshalJSON() ([]byte, error) {
+	rule := "values"
+	if c.keys {
+		rule = "keys"
+	}
+	return json.Marshal(struct {
+		Rule  string      `json:"rule"`
+		Rules []Validator `json:"rules"`
+	}{rule, c.validators})
+}
+
+func (c *MapValidator) clone() Validator {
+	clone := *c
+	clone.validators = make([]Validator, len(c.validators))
+	for i, v := range c.validators {
+		clone.validators[i] = cloneValidator(v)
+	}
+	return &clone
+}
+
+// Keys applies validators to every key of a map field
+func Keys(validators ...Validator) *MapValidator {
+	return &MapValidator{
+		keys:       true,
+		validators: validators,
+	}
+}
+
+// Values applies validators to every value of a map field
+func Values(validators ...Validator) *MapValidator {
+	return &MapValidator{
+		validators: validators,
+	}
+}
+
+// EachKey applies validators to every key of a map field. Same as Keys, named to match Each.
+func EachKey(validators ...Validator) *MapValidator {
+	return Keys(validators...)
+}
+
+// EachValue applies validators to every value of a map field. Same as Values, named to match Each.
+func EachValue(validators ...Validator) *MapValidator {
+	return Values(validators...)
+}
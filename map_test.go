@@ -0,0 +1,92 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	type mapType struct {
+		Labels map[string]string `json:"labels"`
+		Scores map[string]int    `json:"scores"`
+	}
+	m := mapType{}
+	rules := New(&m).
+		Field(&m.Labels, Keys(Pattern(`^[a-z]+$`)), Values(Required(), MaxLength(5))).
+		Field(&m.Scores, Values(Min(0), Max(100)))
+	assert.Nil(t, rules.Validate(mapType{}), "Empty maps")
+	assert.Nil(t, rules.Validate(mapType{
+		Labels: map[string]string{"color": "red"},
+		Scores: map[string]int{"math": 90},
+	}), "Valid entries")
+
+	errs := rules.Validate(mapType{
+		Labels: map[string]string{"Bad": "ok", "size": "", "name": "too long"},
+		Scores: map[string]int{"math": 101, "art": -1},
+	}).(ErrorSlice)
+	assert.Len(t, errs, 5, "Invalid entries")
+	assert.Equal(t, []string{"labels", "Bad"}, errs[0].Field(), "Key error path")
+	assert.Equal(t, []string{"labels", "name"}, errs[1].Field(), "Value error path")
+	assert.Equal(t, "Please shorten labels to 5 characters or less", errs[1].Error(), "Value message")
+	assert.Equal(t, []string{"labels", "size"}, errs[2].Field(), "Sorted by key")
+	assert.Equal(t, []string{"scores", "art"}, errs[3].Field(), "Number value path")
+
+	j, _ := json.Marshal(New(&m).Field(&m.Scores, Values(Min(0))))
+	assert.Equal(t, `{"scores":[{"rule":"values","rules":[{"rule":"min","min":0}]}]}`, string(j), "Export")
+}
+
+func TestMapInnerErrors(t *testing.T) {
+	type groupsType struct {
+		Groups map[string][]string `json:"groups"`
+		IDs    map[string]string   `json:"ids"`
+	}
+	g := groupsType{}
+	calls := 0
+	rules := New(&g).
+		Field(&g.Groups, Values(Each(Required()))).
+		Field(&g.IDs, Values(Remote("", RemoteOptions{Checker: vatChecker{&calls}})))
+
+	errs := rules.Validate(groupsType{Groups: map[string][]string{"admin": {"ann", ""}}}).(ErrorSlice)
+	assert.Len(t, errs, 1, "Nested element")
+	assert.Equal(t, []string{"groups", "admin", "1"}, errs[0].Field(), "Inner index kept")
+
+	errs = rules.Validate(groupsType{IDs: map[string]string{"eu": "broken"}}).(ErrorSlice)
+	assert.Len(t, errs, 1, "Broken checker")
+	assert.Equal(t, []string{"ids", "eu"}, errs[0].Field(), "Key in field")
+	assert.True(t, errors.Is(errs, ErrInternal), "Internal error kept")
+}
+
+func TestMapPointerValues(t *testing.T) {
+	type mapType struct {
+		Labels map[string]*string `json:"labels"`
+	}
+	m := mapType{}
+	rules := New(&m).Field(&m.Labels, Values(Required(), MaxLength(3)))
+	ok, long := "ok", "long"
+	assert.Nil(t, rules.Validate(mapType{Labels: map[string]*string{"a": &ok}}), "Dereferenced value")
+	errs := rules.Validate(mapType{Labels: map[string]*string{"a": nil, "b": &long}}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Nil and invalid values")
+	assert.Equal(t, "Please enter the labels", errs[0].Error(), "Nil value is zero")
+	assert.Equal(t, []string{"labels", "b"}, errs[1].Field(), "Dereferenced value validated")
+}
+
+func TestEachKeyValue(t *testing.T) {
+	type settingsType struct {
+		Settings map[string]int `json:"settings"`
+	}
+	s := settingsType{}
+	rules := New(&s).Field(&s.Settings, EachKey(Pattern(`^[a-z]+$`)), EachValue(Min(1), Max(60)))
+	assert.Nil(t, rules.Validate(settingsType{Settings: map[string]int{"timeout": 30}}), "Valid entries")
+	errs := rules.Validate(settingsType{Settings: map[string]int{"Retries": 3, "timeout": 0}}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Invalid key and value")
+	assert.Equal(t, []string{"settings", "Retries"}, errs[0].Field(), "Key error path")
+	assert.Equal(t, []string{"settings", "timeout"}, errs[1].Field(), "Value error path")
+	assert.Equal(t, "min", errs[1].(interface{ Rule() string }).Rule(), "Value rule")
+	j, _ := json.Marshal(rules)
+	assert.Equal(t,
+		`{"settings":[{"rule":"keys","rules":[{"rule":"pattern","pattern":"^[a-z]+$"}]},{"rule":"values","rules":[{"rule":"min","min":1},{"rule":"max","max":60}]}]}`,
+		string(j), "Exported like Keys and Values")
+}
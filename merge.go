@@ -0,0 +1,58 @@
+package xvalid
+
+import (
+	"errors"
+	"sort"
+)
+
+// Merge combines the results of several Validate calls, e.g. of the body, query and headers of a request, into one
+// ErrorSlice. Nil results are ignored and errors with the same field and message are only kept once. Nil is returned
+// if there are no errors.
+func Merge(results ...error) error {
+	errs := make(ErrorSlice, 0)
+	for _, result := range results {
+		var slice ErrorSlice
+		var emap ErrorMap
+		var single Error
+		switch {
+		case result == nil:
+		case errors.As(result, &slice):
+			errs = append(errs, slice...)
+		case errors.As(result, &emap):
+			keys := make([]string, 0, len(emap))
+			for k := range emap {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				errs = append(errs, emap[k])
+			}
+		case errors.As(result, &single):
+			errs = append(errs, single)
+		default:
+			errs = append(errs, NewError(result.Error()))
+		}
+	}
+	if errs = dedupe(errs, DedupeMessages); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// MergeMaps combines error maps into one. When a field has errors in more than one map, resolve picks the error to
+// keep. The first error is kept if resolve is nil.
+func MergeMaps(resolve func(field string, a, b Error) Error, maps ...ErrorMap) ErrorMap {
+	merged := make(ErrorMap)
+	for _, m := range maps {
+		for field, err := range m {
+			if existing, ok := merged[field]; ok {
+				if resolve != nil {
+					merged[field] = resolve(field, existing, err)
+				}
+				continue
+			}
+			merged[field] = err
+		}
+	}
+	return merged
+}
@@ -0,0 +1,46 @@
+package xvalid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	type body struct {
+		Name string `json:"name"`
+	}
+	type query struct {
+		Page int `json:"page"`
+	}
+	b := body{}
+	q := query{}
+	bodyErr := New(&b).Field(&b.Name, Required()).Validate(body{})
+	queryErr := New(&q).Field(&q.Page, Min(1)).Validate(query{})
+
+	errs := Merge(bodyErr, nil, queryErr, bodyErr).(ErrorSlice)
+	assert.Len(t, errs, 2, "Duplicates removed")
+	assert.Equal(t, []string{"name"}, errs[0].Field(), "Order kept")
+	assert.Equal(t, []string{"page"}, errs[1].Field(), "Order kept")
+	assert.Nil(t, Merge(nil, nil), "No errors")
+
+	errs = Merge(ErrorMap{"b": NewError("B", "b"), "a": NewError("A", "a")}, errors.New("bad header")).(ErrorSlice)
+	assert.Equal(t, "A", errs[0].Error(), "Map sorted by field")
+	assert.Equal(t, "bad header", errs[2].Error(), "Plain error kept")
+	assert.Empty(t, errs[2].Field(), "Plain error has no field")
+	errs = Merge(NewError("Single", "x")).(ErrorSlice)
+	assert.Equal(t, []string{"x"}, errs[0].Field(), "Single error")
+}
+
+func TestMergeMaps(t *testing.T) {
+	a := ErrorMap{"name": NewError("first", "name"), "age": NewError("age", "age")}
+	b := ErrorMap{"name": NewError("second", "name"), "page": NewError("page", "page")}
+	merged := MergeMaps(nil, a, b)
+	assert.Len(t, merged, 3, "All fields")
+	assert.Equal(t, "first", merged["name"].Error(), "First kept by default")
+	merged = MergeMaps(func(field string, x, y Error) Error {
+		return NewError(x.Error()+" and "+y.Error(), field)
+	}, a, b)
+	assert.Equal(t, "first and second", merged["name"].Error(), "Conflict resolved")
+}
@@ -0,0 +1,107 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// NestedValidator validates a struct field with the rules of its own type. Errors are reported on the field with the
+// field of the inner error appended, e.g. ["address", "city"].
+type NestedValidator struct {
+	field  []string
+	rules  Rules
+	loaded json.RawMessage
+}
+
+// Field of the field
+func (c *NestedValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *NestedValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage does nothing since the errors come from the nested rules
+func (c *NestedValidator) SetMessage(msg string) Validator {
+	return c
+}
+
+// Validate the value and return the first error
+func (c *NestedValidator) Validate(value any) Error {
+	if errs := c.validateValue(context.Background(), value); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (c *NestedValidator) resolveFields(structPtr any) {}
+
+func (c *NestedValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *NestedValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	value, ok := fieldValue(vmap, c.field)
+	if !ok {
+		return nil
+	}
+	return c.validateValue(ctx, value)
+}
+
+// validateValue validates the value with the nested rules. Nil pointers pass.
+func (c *NestedValidator) validateValue(ctx context.Context, value any) ErrorSlice {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil
+	}
+	if c.rules.structPtr == nil {
+		panic("nested rules are loaded with Rules.Load")
+	}
+	inner, _ := c.rules.ValidateCtx(ctx, value).(ErrorSlice)
+	return nestErrors(c.field, inner)
+}
+
+// loadStruct parses the loaded rules for the type of the field
+func (c *NestedValidator) loadStruct(structType reflect.Type, fieldType reflect.Type) error {
+	if c.loaded == nil {
+		return nil
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return fmt.Errorf("nested rules need a struct field, not %s", fieldType)
+	}
+	rules, err := ParseRules(reflect.New(fieldType).Interface(), c.loaded)
+	c.rules = rules
+	return err
+}
+
+// CanExport for this validator
+func (c *NestedValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *NestedValidator) Params() map[string]any {
+	return map[string]any{"rules": c.rules}
+}
+
+// MarshalJSON for this validator
+func (c *NestedValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule  string `json:"rule"`
+		Rules Rules  `json:"rules"`
+	}{"nested", c.rules})
+}
+
+// Nested validates a struct field, or a pointer to one, with rules created for its own type, so the rules of a type
+// can be reused wherever it's used as a field. Errors carry the full field path, e.g. ["address", "city"]. Nil
+// pointers pass, so add Required to the field if it must be set.
+func (r Rules) Nested(fieldPtr any, rules Rules) Rules {
+	return r.Field(fieldPtr, &NestedValidator{rules: rules})
+}
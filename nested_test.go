@@ -0,0 +1,47 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNestedRules(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	type customer struct {
+		Name     string   `json:"name"`
+		Address  address  `json:"address"`
+		Billing  *address `json:"billing"`
+		Shipping *address `json:"shipping"`
+	}
+	a := address{}
+	addressRules := New(&a).Field(&a.City, Required()).Field(&a.Zip, Pattern(`^[0-9]{5}$`))
+	c := customer{}
+	rules := New(&c).
+		Field(&c.Name, Required()).
+		Nested(&c.Address, addressRules).
+		Nested(&c.Billing, addressRules).
+		Field(&c.Shipping, Required()).
+		Nested(&c.Shipping, addressRules)
+
+	valid := address{City: "Paris", Zip: "75001"}
+	assert.Nil(t, rules.Validate(customer{Name: "a", Address: valid, Shipping: &valid}), "Valid")
+
+	errs := rules.Validate(customer{Name: "a", Address: address{Zip: "x"}, Billing: &address{}}).(ErrorSlice)
+	assert.Len(t, errs, 5, "Nested errors")
+	assert.Equal(t, []string{"address", "city"}, errs[0].Field(), "Full field path")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Rule kept")
+	assert.Equal(t, []string{"address", "zip"}, errs[1].Field(), "Full field path")
+	assert.Equal(t, []string{"billing", "city"}, errs[2].Field(), "Pointer field")
+	assert.Equal(t, []string{"shipping"}, errs[4].Field(), "Nil pointer only fails Required")
+	assert.Contains(t, errs.ToNestedMap()["address"], "city", "Nested map")
+
+	j, _ := json.Marshal(New(&c).Nested(&c.Address, addressRules))
+	assert.Equal(t,
+		`{"address":[{"rule":"nested","rules":{"city":[{"rule":"required"}],"zip":[{"rule":"pattern","pattern":"^[0-9]{5}$"}]}}]}`,
+		string(j), "Export nested rules")
+}
@@ -0,0 +1,109 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// OpenAPIParameter is an OpenAPI parameter object
+type OpenAPIParameter struct {
+	Name     string        `json:"name"`
+	In       string        `json:"in"`
+	Required bool          `json:"required,omitempty"`
+	Schema   OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema holds the constraints of a parameter that can be derived from the validators
+type OpenAPISchema struct {
+	Type             string      `json:"type,omitempty"`
+	Format           string      `json:"format,omitempty"`
+	MinLength        *int64      `json:"minLength,omitempty"`
+	MaxLength        *int64      `json:"maxLength,omitempty"`
+	Minimum          json.Number `json:"minimum,omitempty"`
+	Maximum          json.Number `json:"maximum,omitempty"`
+	ExclusiveMinimum bool        `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool        `json:"exclusiveMaximum,omitempty"`
+	MinItems         *int64      `json:"minItems,omitempty"`
+	MaxItems         *int64      `json:"maxItems,omitempty"`
+	Pattern          string      `json:"pattern,omitempty"`
+	Enum             []any       `json:"enum,omitempty"`
+}
+
+// OpenAPIParameters describes each field of the rules as an OpenAPI parameter, e.g. with in set to "query" or
+// "header". Fields are in the order they were added. Struct validators and custom validators are left out.
+func (r Rules) OpenAPIParameters(in string) []OpenAPIParameter {
+	params := make([]OpenAPIParameter, 0)
+	index := make(map[string]int)
+	for _, v := range r.validators {
+		if len(v.Field()) == 0 {
+			continue
+		}
+		name := jsonFieldName(v.Field())
+		i, ok := index[name]
+		if !ok {
+			i = len(params)
+			index[name] = i
+			params = append(params, OpenAPIParameter{
+				Name:   name,
+				In:     in,
+				Schema: OpenAPISchema{Type: r.openAPIType(v.Field())},
+			})
+		}
+		p := &params[i]
+		switch c := v.(type) {
+		case *RequiredValidator:
+			p.Required = true
+		case *MinLengthValidator:
+			p.Schema.MinLength = &c.min
+		case *MaxLengthValidator:
+			p.Schema.MaxLength = &c.max
+		case *MinItemsValidator:
+			p.Schema.MinItems = &c.min
+		case *MaxItemsValidator:
+			p.Schema.MaxItems = &c.max
+		case *MinValidator:
+			p.Schema.Minimum = json.Number(strconv.FormatInt(c.min, 10))
+			p.Schema.ExclusiveMinimum = c.exclusive
+		case *MaxValidator:
+			p.Schema.Maximum = json.Number(strconv.FormatInt(c.max, 10))
+			p.Schema.ExclusiveMaximum = c.exclusive
+		case *LengthValidator:
+			if p.Schema.Type == "array" || p.Schema.Type == "object" {
+				p.Schema.MinItems, p.Schema.MaxItems = &c.min, &c.max
+			} else {
+				p.Schema.MinLength, p.Schema.MaxLength = &c.min, &c.max
+			}
+		case *RangeValidator:
+			p.Schema.Minimum, p.Schema.Maximum = c.bounds()
+			p.Schema.ExclusiveMinimum, p.Schema.ExclusiveMaximum = c.exclusive, c.exclusive
+		case *PatternValidator:
+			p.Schema.Pattern = c.re.String()
+		case *EmailValidator:
+			p.Schema.Format = "email"
+		case *OptionsValidator:
+			p.Schema.Enum = c.getOptions()
+		case interface{ optionValues() []any }:
+			p.Schema.Enum = c.optionValues()
+		}
+	}
+	return params
+}
+
+// openAPIType returns the OpenAPI type of a field, which is the JSON type except for integers
+func (r Rules) openAPIType(field []string) string {
+	f, ok := settableField(reflect.New(reflect.TypeOf(r.structPtr).Elem()).Elem(), field)
+	if !ok {
+		return ""
+	}
+	t := f.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	}
+	return jsonType(t)
+}
@@ -0,0 +1,53 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIParameters(t *testing.T) {
+	type query struct {
+		Search string   `json:"q"`
+		Page   int      `json:"page"`
+		Sort   string   `json:"sort"`
+		Email  string   `json:"email"`
+		Score  float64  `json:"score"`
+		Code   string   `json:"code"`
+		Tags   []string `json:"tags"`
+	}
+	q := query{}
+	rules := New(&q).
+		Field(&q.Search, Required(), MinLength(2), MaxLength(50)).
+		Field(&q.Page, Min(1), Max(100).Exclusive()).
+		Field(&q.Sort, Options("asc", "desc"), Pattern("^[a-z]+$")).
+		Field(&q.Email, Email()).
+		Field(&q.Score, RangeFloat(0, 0.5), FieldFunc(func([]string, any) Error { return nil })).
+		Field(&q.Code, Length(3, 8)).
+		Field(&q.Tags, Length(1, 5)).
+		Struct(StructFunc(func(any) Error { return nil }))
+	j, _ := json.Marshal(rules.OpenAPIParameters("query"))
+	assert.JSONEq(t, `[
+		{"name":"q","in":"query","required":true,"schema":{"type":"string","minLength":2,"maxLength":50}},
+		{"name":"page","in":"query","schema":{"type":"integer","minimum":1,"maximum":100,"exclusiveMaximum":true}},
+		{"name":"sort","in":"query","schema":{"type":"string","pattern":"^[a-z]+$","enum":["asc","desc"]}},
+		{"name":"email","in":"query","schema":{"type":"string","format":"email"}},
+		{"name":"score","in":"query","schema":{"type":"number","minimum":0,"maximum":0.5}},
+		{"name":"code","in":"query","schema":{"type":"string","minLength":3,"maxLength":8}},
+		{"name":"tags","in":"query","schema":{"type":"array","minItems":1,"maxItems":5}}
+	]`, string(j), "Parameters")
+
+	type headers struct {
+		Token string `json:"X-Token"`
+	}
+	h := headers{}
+	params := New(&h).Field(&h.Token, Required()).OpenAPIParameters("header")
+	assert.Equal(t, "header", params[0].In, "Header parameter")
+	assert.True(t, params[0].Required, "Required header")
+
+	params = New(&q).Field(&q.Page, Range(1, math.MaxInt64)).OpenAPIParameters("query")
+	assert.Equal(t, json.Number("1"), params[0].Schema.Minimum, "Range minimum")
+	assert.Equal(t, json.Number("9223372036854775807"), params[0].Schema.Maximum, "Range maximum keeps precision")
+}
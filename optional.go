@@ -0,0 +1,60 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Optional is a field that records whether it was set when decoding JSON. Validators see the value if it was set and
+// nil otherwise, so Required fails for unset fields while explicit zero values are validated like any other value.
+// In Partial mode, validators of unset fields are skipped.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// Some returns an Optional that is set to the value
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{Value: value, Set: true}
+}
+
+// Get returns the value and whether it was set
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set
+}
+
+// UnmarshalJSON marks the field as set. Called only if the key is present.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.Value)
+}
+
+// MarshalJSON encodes the value, or null if it's not set
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// optionalField is implemented by Optional
+type optionalField interface {
+	optionalValue() (any, bool)
+}
+
+var optionalFieldType = reflect.TypeOf((*optionalField)(nil)).Elem()
+
+func (o Optional[T]) optionalValue() (any, bool) {
+	return o.Value, o.Set
+}
+
+// unwrapOptional returns the value of an Optional, or nil if it's not set. Other values are returned as is.
+func unwrapOptional(value any) any {
+	if o, ok := value.(optionalField); ok {
+		if v, set := o.optionalValue(); set {
+			return v
+		}
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,41 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	type profile struct {
+		Nick Optional[string] `json:"nick"`
+		Age  Optional[int]    `json:"age"`
+	}
+	p := profile{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"nick":""}`), &p), "Decode")
+	assert.True(t, p.Nick.Set, "Present key is set")
+	assert.False(t, p.Age.Set, "Missing key is not set")
+
+	rules := New(&p).
+		Field(&p.Nick, Required(), MaxLength(5)).
+		Field(&p.Age, Min(18).SetOptional())
+	errs := rules.Validate(p).(ErrorSlice)
+	assert.Len(t, errs, 1, "Explicit empty value validated")
+	assert.Equal(t, []string{"nick"}, errs[0].Field(), "Required fails on empty")
+	errs = rules.Validate(profile{Nick: Some("toolong"), Age: Some(10)}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Values unwrapped")
+	assert.Nil(t, rules.Validate(profile{Nick: Some("jo")}), "Valid value and unset optional")
+
+	partial := New(&p).Field(&p.Nick, Required()).Field(&p.Age, Min(18)).Partial()
+	assert.Nil(t, partial.Validate(profile{}), "Unset fields skipped in partial mode")
+	assert.Len(t, partial.Validate(profile{Age: Some(0)}), 1, "Set zero validated in partial mode")
+
+	generated, err := rules.Generate(rand.New(rand.NewSource(1)))
+	assert.Nil(t, err, "Generate")
+	assert.True(t, generated.(profile).Nick.Set, "Generated value is set")
+
+	j, _ := json.Marshal(profile{Nick: Some("jo")})
+	assert.Equal(t, `{"nick":"jo","age":null}`, string(j), "Encode")
+}
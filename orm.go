@@ -0,0 +1,205 @@
+package xvalid
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GormTags returns the gorm struct tag of each field, e.g. "size:50;not null", keyed by the Go field name, so the ORM
+// schema follows the rules. Required fields are not null and strings with a MaxLength get its size. Fields without
+// either are left out.
+func (r Rules) GormTags() map[string]string {
+	tags := make(map[string]string)
+	root := reflect.TypeOf(r.structPtr).Elem()
+	for _, field := range r.fields() {
+		sf, ok := structField(root, field)
+		if !ok {
+			continue
+		}
+		parts := make([]string, 0)
+		for _, v := range r.fieldValidators(field) {
+			switch v := v.(type) {
+			case *MaxLengthValidator:
+				if !v.trimmed {
+					parts = append(parts, fmt.Sprintf("size:%d", v.max))
+				}
+			case *RequiredValidator:
+				parts = append(parts, "not null")
+			}
+		}
+		if len(parts) > 0 {
+			tags[sf.Name] = strings.Join(parts, ";")
+		}
+	}
+	return tags
+}
+
+// EntFields returns the fields of an ent schema as Go code, one field per line, e.g.
+// field.String("name").NotEmpty().MaxLen(50), for pasting into the Fields method of the schema. Fields are named by
+// their JSON name and are optional unless they are required. Rules that ent can't express are left out.
+func (r Rules) EntFields() string {
+	var b strings.Builder
+	root := reflect.TypeOf(r.structPtr).Elem()
+	for _, field := range r.fields() {
+		sf, ok := structField(root, field)
+		if !ok {
+			continue
+		}
+		t := sf.Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		name := strconv.Quote(jsonFieldName(field))
+		validators := r.fieldValidators(field)
+		required := false
+		calls := make([]string, 0)
+		kind := entKind(t)
+		for _, v := range validators {
+			switch v := v.(type) {
+			case *RequiredValidator:
+				required = true
+				if kind == "String" {
+					calls = append(calls, "NotEmpty()")
+				}
+			case *MinLengthValidator:
+				calls = append(calls, fmt.Sprintf("MinLen(%d)", v.min))
+			case *MaxLengthValidator:
+				calls = append(calls, fmt.Sprintf("MaxLen(%d)", v.max))
+			case *MinValidator:
+				if min := v.min; kind != "Time" && kind != "JSON" {
+					if v.exclusive {
+						min++
+					}
+					calls = append(calls, fmt.Sprintf("Min(%d)", min))
+				}
+			case *MaxValidator:
+				if max := v.max; kind != "Time" && kind != "JSON" {
+					if v.exclusive {
+						max--
+					}
+					calls = append(calls, fmt.Sprintf("Max(%d)", max))
+				}
+			case *PatternValidator:
+				calls = append(calls, fmt.Sprintf("Match(regexp.MustCompile(%s))", strconv.Quote(v.re.String())))
+			case *OptionsValidator:
+				if kind == "String" {
+					kind = "Enum"
+					calls = append(calls, "Values("+entValues(v.getOptions())+")")
+				}
+			case interface{ optionValues() []any }:
+				if kind == "String" {
+					kind = "Enum"
+					calls = append(calls, "Values("+entValues(v.optionValues())+")")
+				}
+			}
+		}
+		if kind == "Enum" {
+			// enums can't have string validators
+			filtered := make([]string, 0, len(calls))
+			for _, c := range calls {
+				if strings.HasPrefix(c, "Values(") {
+					filtered = append(filtered, c)
+				}
+			}
+			calls = filtered
+		}
+		if !required {
+			calls = append(calls, "Optional()")
+		}
+		if kind == "JSON" {
+			fmt.Fprintf(&b, "field.JSON(%s, %s{})", name, t.String())
+		} else {
+			fmt.Fprintf(&b, "field.%s(%s)", kind, name)
+		}
+		for _, c := range calls {
+			b.WriteString("." + c)
+		}
+		b.WriteString(",\n")
+	}
+	return b.String()
+}
+
+// entKind returns the name of the ent field function for the type
+func entKind(t reflect.Type) string {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return "Time"
+	case reflect.TypeOf(time.Duration(0)):
+		return "Int64"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "String"
+	case reflect.Bool:
+		return "Bool"
+	case reflect.Int:
+		return "Int"
+	case reflect.Int8:
+		return "Int8"
+	case reflect.Int16:
+		return "Int16"
+	case reflect.Int32:
+		return "Int32"
+	case reflect.Int64:
+		return "Int64"
+	case reflect.Uint:
+		return "Uint"
+	case reflect.Uint8:
+		return "Uint8"
+	case reflect.Uint16:
+		return "Uint16"
+	case reflect.Uint32:
+		return "Uint32"
+	case reflect.Uint64:
+		return "Uint64"
+	case reflect.Float32:
+		return "Float32"
+	case reflect.Float64:
+		return "Float"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "Bytes"
+		}
+	}
+	return "JSON"
+}
+
+// entValues quotes the options as enum values
+func entValues(options []any) string {
+	values := make([]string, len(options))
+	for i, o := range options {
+		values[i] = strconv.Quote(fmt.Sprint(o))
+	}
+	return strings.Join(values, ", ")
+}
+
+// structField finds the struct field of a field path by JSON names
+func structField(t reflect.Type, field []string) (reflect.StructField, bool) {
+	var sf reflect.StructField
+	for _, p := range field {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return sf, false
+		}
+		found := false
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = t.Field(i).Name
+			}
+			if name == p {
+				sf, t, found = t.Field(i), t.Field(i).Type, true
+				break
+			}
+		}
+		if !found {
+			return sf, false
+		}
+	}
+	return sf, true
+}
@@ -0,0 +1,45 @@
+package xvalid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGormTags(t *testing.T) {
+	type account struct {
+		Name string `json:"name"`
+		Bio  string `json:"bio"`
+		Age  int    `json:"age"`
+	}
+	a := account{}
+	rules := New(&a).
+		Field(&a.Name, Required(), MaxLength(50)).
+		Field(&a.Bio, MaxLength(200).Trimmed()).
+		Field(&a.Age, Required(), Min(0))
+	assert.Equal(t, map[string]string{"Name": "not null;size:50", "Age": "not null"}, rules.GormTags(), "Tags")
+}
+
+func TestEntFields(t *testing.T) {
+	type account struct {
+		Name    string    `json:"name"`
+		Age     int32     `json:"age"`
+		Plan    string    `json:"plan"`
+		Created time.Time `json:"created"`
+		Tags    []string  `json:"tags"`
+	}
+	a := account{}
+	rules := New(&a).
+		Field(&a.Name, Required(), MaxLength(50), Pattern(`^[a-z]+$`)).
+		Field(&a.Age, Min(0), Max(150).Exclusive()).
+		Field(&a.Plan, Required(), Options("free", "pro")).
+		Field(&a.Created, Required()).
+		Field(&a.Tags, MaxItems(5))
+	assert.Equal(t, `field.String("name").NotEmpty().MaxLen(50).Match(regexp.MustCompile("^[a-z]+$")),
+field.Int32("age").Min(0).Max(149).Optional(),
+field.Enum("plan").Values("free", "pro"),
+field.Time("created"),
+field.JSON("tags", []string{}).Optional(),
+`, rules.EntFields(), "Ent fields")
+}
@@ -0,0 +1,130 @@
+package xvalid
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// OzzoRule is a rule of github.com/go-ozzo/ozzo-validation, e.g. validation.Required or is.Email. It is declared here
+// so the adapter doesn't depend on that package.
+type OzzoRule interface {
+	Validate(value any) error
+}
+
+// OzzoValidator uses a rule of ozzo-validation as a validator, so rules can be moved over one at a time
+type OzzoValidator struct {
+	field   []string
+	message string
+	label   string
+	rule    OzzoRule
+}
+
+// Field of the field
+func (c *OzzoValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *OzzoValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *OzzoValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *OzzoValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value with the rule. The message of the rule, e.g. "cannot be blank", is prefixed with the field name
+// and the error code is used as the rule name of the error. Internal errors of the rule match ErrInternal.
+func (c *OzzoValidator) Validate(value any) Error {
+	err := c.rule.Validate(value)
+	if err == nil {
+		return nil
+	}
+	if ie, ok := err.(interface{ InternalError() error }); ok {
+		return &internalError{validationError{
+			message: fmt.Sprintf("Something went wrong while validating %s", dataName(c.field)),
+			field:   c.field,
+		}, ie.InternalError()}
+	}
+	verr := createError(c.field, c.message, fmt.Sprintf("%s %s", fieldLabel(c.field, c.label), err.Error()))
+	if coded, ok := err.(interface{ Code() string }); ok && coded.Code() != "" {
+		setRule(ErrorSlice{verr}, coded.Code())
+	}
+	return verr
+}
+
+// CanExport for this validator. The rules of ozzo-validation can't be exported.
+func (c *OzzoValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *OzzoValidator) Params() map[string]any {
+	return map[string]any{"rule": c.rule}
+}
+
+// Ozzo uses a rule of ozzo-validation as a validator, e.g. Field(&u.Email, Required(), Ozzo(is.Email))
+func Ozzo(rule OzzoRule) *OzzoValidator {
+	return &OzzoValidator{
+		rule: rule,
+	}
+}
+
+// OzzoErrors converts an error returned by ozzo-validation, e.g. from validation.ValidateStruct, into an ErrorSlice.
+// The keys of nested validation.Errors become the field path, and errors are sorted by field. Other errors are
+// returned as a single error without a field. Nil gives nil.
+func OzzoErrors(err error) ErrorSlice {
+	if err == nil {
+		return nil
+	}
+	errs := make(ErrorSlice, 0)
+	ozzoErrors(reflect.ValueOf(err), nil, &errs)
+	return errs
+}
+
+// ozzoErrors adds the errors of a map of errors, or the error itself, with the field path
+func ozzoErrors(v reflect.Value, field []string, errs *ErrorSlice) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return
+	}
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		if err, ok := v.Interface().(error); ok && err != nil {
+			*errs = append(*errs, ozzoError(err, field))
+		}
+		return
+	}
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		path := append(append(make([]string, 0, len(field)+1), field...), k)
+		ozzoErrors(v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())), path, errs)
+	}
+}
+
+// ozzoError converts a single error, using the error code as the rule name
+func ozzoError(err error, field []string) Error {
+	message := err.Error()
+	if len(field) > 0 {
+		message = fmt.Sprintf("%s %s", jsonFieldName(field), message)
+	}
+	verr := NewError(message, field...)
+	if coded, ok := err.(interface{ Code() string }); ok && coded.Code() != "" {
+		setRule(ErrorSlice{verr}, coded.Code())
+	}
+	return verr
+}
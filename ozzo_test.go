@@ -0,0 +1,97 @@
+package xvalid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ozzoTestError mimics validation.Error of ozzo-validation
+type ozzoTestError struct {
+	code    string
+	message string
+}
+
+func (e ozzoTestError) Error() string {
+	return e.message
+}
+
+func (e ozzoTestError) Code() string {
+	return e.code
+}
+
+// ozzoTestErrors mimics validation.Errors of ozzo-validation
+type ozzoTestErrors map[string]error
+
+func (e ozzoTestErrors) Error() string {
+	return "errors"
+}
+
+// ozzoTestInternalError mimics validation.InternalError of ozzo-validation
+type ozzoTestInternalError struct {
+	error
+}
+
+func (e ozzoTestInternalError) InternalError() error {
+	return e.error
+}
+
+// ozzoTestRule mimics a rule of ozzo-validation
+type ozzoTestRule func(value any) error
+
+func (r ozzoTestRule) Validate(value any) error {
+	return r(value)
+}
+
+func TestOzzo(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	u := user{}
+	notBlank := ozzoTestRule(func(value any) error {
+		if value == "" {
+			return ozzoTestError{"validation_required", "cannot be blank"}
+		}
+		return nil
+	})
+	rules := New(&u).Field(&u.Name, Ozzo(notBlank), MaxLength(3))
+	assert.Nil(t, rules.Validate(user{Name: "bob"}), "Valid")
+	errs := rules.Validate(user{}).(ErrorSlice)
+	assert.Equal(t, "name cannot be blank", errs[0].Error(), "Message with field name")
+	assert.Equal(t, []string{"name"}, errs[0].Field(), "Field")
+	assert.Equal(t, "validation_required", errs[0].(interface{ Rule() string }).Rule(), "Code as rule")
+	errs = New(&u).Field(&u.Name, Ozzo(notBlank)).Label(&u.Name, "Name").Validate(user{}).(ErrorSlice)
+	assert.Equal(t, "Name cannot be blank", errs[0].Error(), "Label")
+	errs = New(&u).Field(&u.Name, Ozzo(notBlank).SetMessage("Please enter a name")).Validate(user{}).(ErrorSlice)
+	assert.Equal(t, "Please enter a name", errs[0].Error(), "Custom message")
+	j, _ := rules.Export()
+	assert.Equal(t, "{\n\t\"name\": [\n\t\t{\n\t\t\t\"rule\": \"maxLength\",\n\t\t\t\"max\": 3\n\t\t}\n\t]\n}", string(j),
+		"Not exported")
+
+	cause := errors.New("connection refused")
+	failing := ozzoTestRule(func(any) error { return ozzoTestInternalError{cause} })
+	err := New(&u).Field(&u.Name, Ozzo(failing)).Validate(user{})
+	assert.ErrorIs(t, err, ErrInternal, "Internal error")
+	assert.ErrorIs(t, err, cause, "Internal error cause")
+}
+
+func TestOzzoErrors(t *testing.T) {
+	assert.Nil(t, OzzoErrors(nil), "No error")
+	errs := OzzoErrors(ozzoTestErrors{
+		"name": ozzoTestError{"validation_required", "cannot be blank"},
+		"address": ozzoTestErrors{
+			"city": errors.New("must be a valid city"),
+			"zip":  nil,
+		},
+	})
+	assert.Len(t, errs, 2, "Nested errors")
+	assert.Equal(t, []string{"address", "city"}, errs[0].Field(), "Nested field")
+	assert.Equal(t, "city must be a valid city", errs[0].Error(), "Nested message")
+	assert.Equal(t, []string{"name"}, errs[1].Field(), "Field")
+	assert.Equal(t, "validation_required", errs[1].(interface{ Rule() string }).Rule(), "Code as rule")
+
+	errs = OzzoErrors(errors.New("must be a struct"))
+	assert.Equal(t, "must be a struct", errs[0].Error(), "Not a map")
+	assert.Nil(t, errs[0].Field(), "No field")
+}
@@ -0,0 +1,18 @@
+package xvalid
+
+// ParamsValidator is implemented by validators that expose their configuration, e.g. for generating docs or linting
+// rules without knowing every validator type. The built in validators use the same keys as their exported JSON, but
+// the values keep their Go types and are set even when they are zero. Messages and labels are not included.
+type ParamsValidator interface {
+	Validator
+	// Params returns the configuration of the validator
+	Params() map[string]any
+}
+
+// Params returns the configuration of a validator, or an empty map if it doesn't implement ParamsValidator
+func Params(validator Validator) map[string]any {
+	if p, ok := validator.(ParamsValidator); ok {
+		return p.Params()
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,33 @@
+package xvalid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParams(t *testing.T) {
+	assert.Equal(t, map[string]any{"min": int64(3), "optional": false, "trimmed": true}, Params(MinLength(3).Trimmed()),
+		"Zero values included")
+	assert.Equal(t, map[string]any{"max": int64(time.Minute), "exclusive": true, "duration": true},
+		Params(MaxDuration(time.Minute).Exclusive()), "Duration")
+	assert.Equal(t, map[string]any{"pattern": "^a+$", "optional": true}, Params(Pattern("^a+$").SetOptional()), "Pattern")
+	assert.Equal(t, map[string]any{"options": []any{"a", "b"}, "caseInsensitive": false}, Params(Options("a", "b")),
+		"Options")
+	assert.Equal(t, map[string]any{"options": []any{1, 2}}, Params(OptionsOf(1, 2)), "Typed options")
+	assert.Equal(t, map[string]any{"max": int64(5), "trimmed": false},
+		Params(ExportFunc(MaxLength(5), func(rule map[string]any) any { return rule })), "Wrapped validator")
+	assert.Equal(t, map[string]any{}, Params(FieldFunc(func([]string, any) Error { return nil })), "No params")
+
+	m := MinLength(3).SetMessage("Too short")
+	assert.NotContains(t, Params(m), "message", "Message not included")
+
+	type custom struct {
+		Validator
+	}
+	assert.Equal(t, map[string]any{}, Params(custom{Required()}), "Validator without params")
+	for _, v := range []Validator{Required(), Email(), URL(), Phone(), Image(), MaxSize(1), CardExpiry(), Bundle()} {
+		assert.Implements(t, (*ParamsValidator)(nil), v, "Built in validator")
+	}
+}
@@ -0,0 +1,51 @@
+package xvalid
+
+import (
+	"reflect"
+)
+
+// Partial treats pointer fields as present only when they are not nil, which is useful for partial updates.
+// Validators of nil pointer fields are skipped, while non-nil pointer fields are dereferenced and validated, even if
+// they point to a zero value.
+func (r Rules) Partial() Rules {
+	r.partial = true
+	return r
+}
+
+// toMap converts the subject into a map of values, dereferencing non-nil pointers in partial mode
+func (r Rules) toMap(subject any) map[string]any {
+	vmap := structToMap(subject)
+	if r.partial {
+		return dereference(vmap)
+	}
+	return vmap
+}
+
+// absent returns true if the field is a nil pointer or an unset Optional in partial mode
+func (r Rules) absent(validator Validator, vmap map[string]any) bool {
+	if !r.partial || len(validator.Field()) == 0 {
+		return false
+	}
+	v, _ := fieldValue(vmap, validator.Field())
+	rv := reflect.ValueOf(v)
+	return v == nil || (rv.Kind() == reflect.Ptr && rv.IsNil())
+}
+
+// dereference returns a copy of the map with non-nil pointers replaced by the values they point to
+func dereference(vmap map[string]any) map[string]any {
+	result := make(map[string]any, len(vmap))
+	for k, v := range vmap {
+		switch v2 := v.(type) {
+		case map[string]any:
+			result[k] = dereference(v2)
+		default:
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+				result[k] = rv.Elem().Interface()
+			} else {
+				result[k] = v
+			}
+		}
+	}
+	return result
+}
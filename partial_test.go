@@ -0,0 +1,33 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartial(t *testing.T) {
+	type patch struct {
+		Name  *string `json:"name"`
+		Age   *int    `json:"age"`
+		Email string  `json:"email"`
+	}
+	p := patch{}
+	rules := New(&p).
+		Field(&p.Name, Required(), MinLength(3)).
+		Field(&p.Age, Min(18)).
+		Field(&p.Email, Email()).
+		Partial()
+	assert.Len(t, rules.Validate(patch{}), 1, "Nil pointers skipped")
+	empty, zero := "", 0
+	errs := rules.Validate(patch{Name: &empty, Age: &zero, Email: "a@b.co"}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Explicit zero values validated")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Required fails on empty")
+	assert.Equal(t, []string{"age"}, errs[2].Field(), "Dereferenced number validated")
+	name, age := "Jane", 20
+	assert.Nil(t, rules.Validate(patch{Name: &name, Age: &age, Email: "a@b.co"}), "Valid values")
+
+	explanations := rules.Explain(patch{Email: "a@b.co"})
+	assert.False(t, explanations[0].Evaluated, "Absent field not evaluated")
+	assert.True(t, explanations[3].Evaluated, "Non pointer field evaluated")
+}
@@ -0,0 +1,187 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// phoneRegion describes the national numbering of a region well enough to tell the length of a number and convert it
+// to E.164. It is not a full numbering plan, so some numbers of the right length that aren't in use will pass.
+type phoneRegion struct {
+	CallingCode string `json:"callingCode"`
+	// Trunk is the prefix dialed before national numbers, e.g. "0" in Malaysia
+	Trunk string `json:"trunk,omitempty"`
+	// TrunkOptional accepts national numbers with or without the trunk prefix
+	TrunkOptional bool `json:"trunkOptional,omitempty"`
+	// Min and Max number of digits after the calling code
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+var phoneRegions = map[string]phoneRegion{
+	"AU": {CallingCode: "61", Trunk: "0", Min: 9, Max: 9},
+	"CA": {CallingCode: "1", Trunk: "1", TrunkOptional: true, Min: 10, Max: 10},
+	"CN": {CallingCode: "86", Trunk: "0", Min: 10, Max: 11},
+	"DE": {CallingCode: "49", Trunk: "0", Min: 6, Max: 13},
+	"FR": {CallingCode: "33", Trunk: "0", Min: 9, Max: 9},
+	"GB": {CallingCode: "44", Trunk: "0", Min: 10, Max: 10},
+	"HK": {CallingCode: "852", Min: 8, Max: 8},
+	"ID": {CallingCode: "62", Trunk: "0", Min: 8, Max: 12},
+	"IN": {CallingCode: "91", Trunk: "0", TrunkOptional: true, Min: 10, Max: 10},
+	"JP": {CallingCode: "81", Trunk: "0", Min: 9, Max: 10},
+	"MY": {CallingCode: "60", Trunk: "0", Min: 8, Max: 10},
+	"PH": {CallingCode: "63", Trunk: "0", Min: 10, Max: 10},
+	"SG": {CallingCode: "65", Min: 8, Max: 8},
+	"TH": {CallingCode: "66", Trunk: "0", Min: 8, Max: 9},
+	"US": {CallingCode: "1", Trunk: "1", TrunkOptional: true, Min: 10, Max: 10},
+}
+
+var (
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+	e164Regex       = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	digitsRegex     = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// NormalizePhone converts a phone number to E.164, e.g. "012-345 6789" to "+60123456789" for the region "MY".
+// Spaces, hyphens, dots and parentheses are ignored. Numbers that start with "+" are accepted for any region when no
+// regions are given. It returns false if the number isn't valid for any of the regions.
+func NormalizePhone(number string, regions ...string) (string, bool) {
+	number = phoneSeparators.Replace(number)
+	if strings.HasPrefix(number, "+") {
+		if !e164Regex.MatchString(number) {
+			return "", false
+		}
+		if len(regions) == 0 {
+			return number, true
+		}
+		for _, code := range regions {
+			r, ok := phoneRegions[strings.ToUpper(code)]
+			if !ok || !strings.HasPrefix(number[1:], r.CallingCode) {
+				continue
+			}
+			if national := number[1+len(r.CallingCode):]; len(national) >= r.Min && len(national) <= r.Max {
+				return number, true
+			}
+		}
+		return "", false
+	}
+	if !digitsRegex.MatchString(number) {
+		return "", false
+	}
+	for _, code := range regions {
+		r, ok := phoneRegions[strings.ToUpper(code)]
+		if !ok {
+			continue
+		}
+		national := number
+		if r.Trunk != "" && strings.HasPrefix(number, r.Trunk) && len(number)-len(r.Trunk) >= r.Min {
+			national = number[len(r.Trunk):]
+		} else if r.Trunk != "" && !r.TrunkOptional {
+			continue
+		}
+		if len(national) >= r.Min && len(national) <= r.Max {
+			return "+" + r.CallingCode + national, true
+		}
+	}
+	return "", false
+}
+
+// PhoneValidator field must be a phone number
+type PhoneValidator struct {
+	field    []string
+	message  string
+	label    string
+	optional bool
+	regions  []string
+}
+
+// Field of the field
+func (c *PhoneValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *PhoneValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *PhoneValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *PhoneValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *PhoneValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Region limits numbers to the regions, given as ISO 3166 country codes such as "MY", and also accepts numbers in
+// their national format. Use NormalizePhone to store the number in E.164. It panics on regions that aren't supported.
+func (c *PhoneValidator) Region(codes ...string) *PhoneValidator {
+	for _, code := range codes {
+		if _, ok := phoneRegions[strings.ToUpper(code)]; !ok {
+			panic(fmt.Errorf("phone region not supported: %s", code))
+		}
+		c.regions = append(c.regions, strings.ToUpper(code))
+	}
+	return c
+}
+
+// Validate the value
+func (c *PhoneValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please enter a valid phone number for %s", fieldLabel(c.field, c.label)))
+	}
+	if c.optional && str == "" {
+		return nil
+	}
+	if _, ok := NormalizePhone(str, c.regions...); ok {
+		return nil
+	}
+	if len(c.regions) == 0 {
+		return createError(c.field, c.message, fmt.Sprintf("Please enter %s with the country code, e.g. +6591234567", fieldLabel(c.field, c.label)))
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please enter a valid phone number for %s", fieldLabel(c.field, c.label)))
+}
+
+// CanExport for this validator
+func (c *PhoneValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *PhoneValidator) Params() map[string]any {
+	return map[string]any{"regions": c.regions, "optional": c.optional}
+}
+
+// MarshalJSON for this validator
+func (c *PhoneValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule     string   `json:"rule"`
+		Regions  []string `json:"regions,omitempty"`
+		Message  string   `json:"message,omitempty"`
+		Label    string   `json:"label,omitempty"`
+		Optional bool     `json:"optional,omitempty"`
+	}{"phone", c.regions, c.message, c.label, c.optional})
+}
+
+// Phone field must be a phone number in E.164 format, e.g. +6591234567. Use Region to accept national formats.
+func Phone() *PhoneValidator {
+	return &PhoneValidator{
+		optional: config.OptionalByDefault,
+	}
+}
@@ -0,0 +1,56 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhone(t *testing.T) {
+	type contact struct {
+		Phone string `json:"phone"`
+	}
+	c := contact{}
+	rules := New(&c).Field(&c.Phone, Phone())
+	assert.Nil(t, rules.Validate(contact{Phone: "+6591234567"}), "E.164")
+	assert.Nil(t, rules.Validate(contact{Phone: "+65 9123-4567"}), "E.164 with separators")
+	errs := rules.Validate(contact{Phone: "91234567"}).(ErrorSlice)
+	assert.Equal(t, "Please enter phone with the country code, e.g. +6591234567", errs[0].Error(), "National without region")
+
+	rules = New(&c).Field(&c.Phone, Phone().Region("MY", "sg"))
+	assert.Nil(t, rules.Validate(contact{Phone: "012-345 6789"}), "Malaysian national format")
+	assert.Nil(t, rules.Validate(contact{Phone: "9123 4567"}), "Singaporean national format")
+	assert.Nil(t, rules.Validate(contact{Phone: "+60123456789"}), "E.164 in region")
+	assert.Len(t, rules.Validate(contact{Phone: "+14155552671"}), 1, "E.164 outside region")
+	assert.Len(t, rules.Validate(contact{Phone: "123"}), 1, "Too short")
+	assert.Len(t, rules.Validate(contact{Phone: "phone"}), 1, "Not a number")
+	assert.Nil(t, New(&c).Field(&c.Phone, Phone().SetOptional()).Validate(contact{}), "Optional")
+
+	assert.Panics(t, func() { Phone().Region("XX") }, "Unsupported region")
+	j, _ := json.Marshal(Phone().Region("MY"))
+	assert.Equal(t, `{"rule":"phone","regions":["MY"]}`, string(j), "Export")
+}
+
+func TestNormalizePhone(t *testing.T) {
+	for _, tc := range []struct {
+		number  string
+		regions []string
+		want    string
+	}{
+		{"012-345 6789", []string{"MY"}, "+60123456789"},
+		{"9123 4567", []string{"MY", "SG"}, "+6591234567"},
+		{"(415) 555-2671", []string{"US"}, "+14155552671"},
+		{"1 415 555 2671", []string{"US"}, "+14155552671"},
+		{"020 7946 0958", []string{"GB"}, "+442079460958"},
+		{"+44 20 7946 0958", nil, "+442079460958"},
+	} {
+		got, ok := NormalizePhone(tc.number, tc.regions...)
+		assert.True(t, ok, tc.number)
+		assert.Equal(t, tc.want, got, tc.number)
+	}
+	_, ok := NormalizePhone("123456789", "MY")
+	assert.False(t, ok, "Trunk prefix required")
+	_, ok = NormalizePhone("0123456789")
+	assert.False(t, ok, "National format needs a region")
+}
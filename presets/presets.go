@@ -0,0 +1,150 @@
+/*
+Package presets provides opinionated bundles of validators for common fields. Every preset is built from the primitive
+validators so it can be exported like any other rule.
+*/
+package presets
+
+import (
+	"fmt"
+
+	"github.com/AgentCosmic/xvalid/v2"
+)
+
+// UsernameOptions configures Username
+type UsernameOptions struct {
+	MinLength int64
+	// MaxLength in characters, unlimited if zero
+	MaxLength int64
+	// Reserved names can't be used, in any case
+	Reserved []string
+}
+
+// ReservedUsernames are names that could be mistaken for the product or its staff, or clash with routes
+var ReservedUsernames = []string{
+	"admin", "administrator", "api", "root", "system", "support", "help", "security", "staff", "moderator", "owner",
+	"www", "mail", "login", "logout", "signup", "register", "settings", "account", "null", "undefined",
+}
+
+// DefaultUsername allows 3 to 30 characters and none of the ReservedUsernames
+var DefaultUsername = UsernameOptions{MinLength: 3, MaxLength: 30, Reserved: ReservedUsernames}
+
+// Username may contain letters, numbers, underscores and dots
+func Username(opts UsernameOptions) *xvalid.BundleValidator {
+	validators := []xvalid.Validator{
+		xvalid.MinLength(opts.MinLength),
+	}
+	if opts.MaxLength > 0 {
+		validators = append(validators, xvalid.MaxLength(opts.MaxLength))
+	}
+	validators = append(validators, xvalid.Pattern(`^[a-zA-Z0-9_.]+$`).SetMessage("Please use only letters, numbers, underscores and dots"))
+	if len(opts.Reserved) > 0 {
+		reserved := make([]any, len(opts.Reserved))
+		for i, name := range opts.Reserved {
+			reserved[i] = name
+		}
+		validators = append(validators, xvalid.NotOptions(reserved...).CaseInsensitive())
+	}
+	return xvalid.Bundle(validators...)
+}
+
+// PasswordOptions configures Password
+type PasswordOptions struct {
+	MinLength int64
+	// MaxLength in characters, unlimited if zero
+	MaxLength int64
+	// MaxBytes of the UTF-8 encoding, unlimited if zero
+	MaxBytes int64
+	// Lower requires a lowercase letter
+	Lower bool
+	// Upper requires an uppercase letter
+	Upper bool
+	// Digit requires a number
+	Digit bool
+	// Symbol requires a character that isn't a letter or number
+	Symbol bool
+}
+
+// DefaultPassword requires at least 8 characters and at most 72 bytes with a lowercase letter, an uppercase letter and
+// a number. The maximum is the most bcrypt can hash, which counts bytes rather than characters.
+var DefaultPassword = PasswordOptions{MinLength: 8, MaxBytes: 72, Lower: true, Upper: true, Digit: true}
+
+// Password with length and character requirements
+func Password(opts PasswordOptions) *xvalid.BundleValidator {
+	validators := []xvalid.Validator{
+		xvalid.MinLength(opts.MinLength),
+	}
+	if opts.MaxLength > 0 {
+		validators = append(validators, xvalid.MaxLength(opts.MaxLength))
+	}
+	if opts.MaxBytes > 0 {
+		validators = append(validators, xvalid.MaxBytes(opts.MaxBytes))
+	}
+	if opts.Lower {
+		validators = append(validators, xvalid.Pattern(`[a-z]`).SetMessage("Please include a lowercase letter"))
+	}
+	if opts.Upper {
+		validators = append(validators, xvalid.Pattern(`[A-Z]`).SetMessage("Please include an uppercase letter"))
+	}
+	if opts.Digit {
+		validators = append(validators, xvalid.Pattern(`[0-9]`).SetMessage("Please include a number"))
+	}
+	if opts.Symbol {
+		validators = append(validators, xvalid.Pattern(`[^a-zA-Z0-9]`).SetMessage("Please include a symbol"))
+	}
+	return xvalid.Bundle(validators...)
+}
+
+// PersonNameOptions configures PersonName
+type PersonNameOptions struct {
+	// MaxLength in characters, unlimited if zero
+	MaxLength int64
+}
+
+// DefaultPersonName allows up to 100 characters
+var DefaultPersonName = PersonNameOptions{MaxLength: 100}
+
+// PersonName may contain letters of any language, spaces, apostrophes, dots and hyphens
+func PersonName(opts PersonNameOptions) *xvalid.BundleValidator {
+	validators := []xvalid.Validator{
+		xvalid.MinLength(1),
+	}
+	if opts.MaxLength > 0 {
+		validators = append(validators, xvalid.MaxLength(opts.MaxLength))
+	}
+	validators = append(validators, xvalid.Pattern(`^[\p{L}\p{M}' .-]+$`).SetMessage("Please use only letters, spaces, apostrophes, dots and hyphens"))
+	return xvalid.Bundle(validators...)
+}
+
+// PhoneE164 must be a phone number in E.164 format, e.g. +6591234567
+func PhoneE164() *xvalid.BundleValidator {
+	return xvalid.Bundle(
+		xvalid.Pattern(`^\+[1-9][0-9]{1,14}$`).SetMessage("Please enter the phone number with the country code, e.g. +6591234567"),
+	)
+}
+
+// MoneyOptions configures Money
+type MoneyOptions struct {
+	// Decimals is the maximum number of decimal places
+	Decimals int
+	// Negative allows amounts below zero
+	Negative bool
+}
+
+// DefaultMoney allows positive amounts with up to 2 decimal places
+var DefaultMoney = MoneyOptions{Decimals: 2}
+
+// Money must be a decimal amount in a string, e.g. "19.99"
+func Money(opts MoneyOptions) *xvalid.BundleValidator {
+	sign := ""
+	if opts.Negative {
+		sign = "-?"
+	}
+	decimals := ""
+	if opts.Decimals > 0 {
+		decimals = fmt.Sprintf(`(\.[0-9]{1,%d})?`, opts.Decimals)
+	}
+	return xvalid.Bundle(
+		xvalid.MaxLength(20),
+		xvalid.Pattern(fmt.Sprintf(`^%s[0-9]+%s$`, sign, decimals)).SetMessage("Please enter a valid amount"),
+	)
+}
@@ -0,0 +1,87 @@
+package presets
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+	Balance  string `json:"balance"`
+}
+
+func rules(a *account) xvalid.Rules {
+	return xvalid.New(a).
+		Field(&a.Username, Username(DefaultUsername)).
+		Field(&a.Password, Password(DefaultPassword)).
+		Field(&a.Name, PersonName(DefaultPersonName)).
+		Field(&a.Phone, PhoneE164()).
+		Field(&a.Balance, Money(DefaultMoney))
+}
+
+func TestPresets(t *testing.T) {
+	a := account{}
+	r := rules(&a)
+	valid := account{
+		Username: "john.doe_1",
+		Password: "Secret123",
+		Name:     "Zoë O'Brien-Smith",
+		Phone:    "+6591234567",
+		Balance:  "19.99",
+	}
+	assert.Nil(t, r.Validate(valid), "Valid")
+
+	invalid := account{
+		Username: "jo hn",
+		Password: "secret",
+		Name:     "R2D2",
+		Phone:    "91234567",
+		Balance:  "19.999",
+	}
+	errs := r.Validate(invalid).(xvalid.ErrorSlice)
+	assert.Len(t, errs, 7, "Invalid")
+	assert.Equal(t, "Please include an uppercase letter", errs[2].Error(), "Password message")
+
+	// reserved names
+	errs = r.Validate(account{Username: "Admin", Password: "Secret123", Name: "Zoë", Phone: "+6591234567", Balance: "1"}).(xvalid.ErrorSlice)
+	assert.Equal(t, "Please choose a different username", errs[0].Error(), "Reserved username")
+	r = xvalid.New(&a).Field(&a.Username, Username(UsernameOptions{MinLength: 3, MaxLength: 30, Reserved: []string{"acme"}}))
+	assert.Nil(t, r.Validate(account{Username: "admin"}), "Custom reserved names")
+	assert.Len(t, r.Validate(account{Username: "ACME"}), 1, "Custom reserved name")
+
+	// bcrypt limit in bytes
+	r = xvalid.New(&a).Field(&a.Password, Password(DefaultPassword))
+	assert.Len(t, r.Validate(account{Password: "Aa1" + strings.Repeat("é", 35)}), 1, "Too many bytes")
+	assert.Nil(t, r.Validate(account{Password: "Aa1" + strings.Repeat("é", 34)}), "Within bytes")
+
+	// configurable
+	a2 := account{}
+	r = xvalid.New(&a2).
+		Field(&a2.Password, Password(PasswordOptions{MinLength: 4, MaxLength: 10, Symbol: true})).
+		Field(&a2.Balance, Money(MoneyOptions{Negative: true}))
+	assert.Nil(t, r.Validate(account{Password: "ab!c", Balance: "-5"}), "Custom options")
+	assert.Len(t, r.Validate(account{Password: "abcd", Balance: "5.5"}), 2, "Custom options fail")
+
+	// zero options have no maximum
+	r = xvalid.New(&a2).
+		Field(&a2.Username, Username(UsernameOptions{})).
+		Field(&a2.Password, Password(PasswordOptions{})).
+		Field(&a2.Name, PersonName(PersonNameOptions{}))
+	long := strings.Repeat("a", 200)
+	assert.Nil(t, r.Validate(account{Username: long, Password: long, Name: long}), "Zero options")
+	assert.Len(t, r.Validate(account{Username: "a b", Password: "", Name: "R2D2"}), 2, "Zero options keep the patterns")
+
+	// exportable
+	j, err := json.Marshal(xvalid.New(&a).Field(&a.Phone, PhoneE164()))
+	assert.Nil(t, err, "Exported")
+	assert.Equal(t,
+		`{"phone":[{"rule":"pattern","pattern":"^\\+[1-9][0-9]{1,14}$","message":"Please enter the phone number with the country code, e.g. +6591234567"}]}`,
+		string(j), "Export preset")
+}
@@ -0,0 +1,178 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// RangeValidator field must be within a range, inclusive unless Exclusive is set
+type RangeValidator struct {
+	field     []string
+	message   string
+	label     string
+	min       float64
+	max       float64
+	intMin    int64
+	intMax    int64
+	isInt     bool
+	exclusive bool
+	optional  bool
+}
+
+// Field of the field
+func (c *RangeValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *RangeValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *RangeValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *RangeValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Exclusive makes the value fail when it equals the minimum or the maximum
+func (c *RangeValidator) Exclusive() *RangeValidator {
+	c.exclusive = true
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *RangeValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Validate the value
+func (c *RangeValidator) Validate(value any) Error {
+	rv := reflect.ValueOf(value)
+	var n float64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// compare integers exactly, float64 can't hold every int64
+		if c.isInt {
+			i := rv.Int()
+			if (c.optional && i == 0) || inRange(i, c.intMin, c.intMax, c.exclusive) {
+				return nil
+			}
+			return c.newError()
+		}
+		n = float64(rv.Int())
+	case reflect.Float32, reflect.Float64:
+		n = rv.Float()
+	case reflect.Invalid:
+		if c.optional {
+			return nil
+		}
+		return c.newError()
+	default:
+		if config.MismatchPolicy == MismatchDefault {
+			panic(fmt.Errorf("type not supported: %v", rv.Type()))
+		}
+		if passMismatch(value, false) {
+			return nil
+		}
+		return c.newError()
+	}
+	if (c.optional && n == 0) || inRange(n, c.min, c.max, c.exclusive) {
+		return nil
+	}
+	return c.newError()
+}
+
+// inRange returns true if the value is between min and max, or strictly between them if exclusive
+func inRange[T number](value T, min T, max T, exclusive bool) bool {
+	if exclusive {
+		return value > min && value < max
+	}
+	return value >= min && value <= max
+}
+
+func (c *RangeValidator) newError() Error {
+	min, max := c.bounds()
+	if c.exclusive {
+		return createError(c.field, c.message, fmt.Sprintf("Please change %s to be more than %s and less than %s",
+			fieldLabel(c.field, c.label), min, max))
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please change %s to be between %s and %s",
+		fieldLabel(c.field, c.label), min, max))
+}
+
+// bounds formats min and max without losing the precision of integer bounds
+func (c *RangeValidator) bounds() (json.Number, json.Number) {
+	if c.isInt {
+		return json.Number(strconv.FormatInt(c.intMin, 10)), json.Number(strconv.FormatInt(c.intMax, 10))
+	}
+	return json.Number(formatNumber(c.min)), json.Number(formatNumber(c.max))
+}
+
+// MarshalJSON for this validator
+func (c *RangeValidator) MarshalJSON() ([]byte, error) {
+	min, max := c.bounds()
+	return json.Marshal(struct {
+		Rule      string      `json:"rule"`
+		Min       json.Number `json:"min"`
+		Max       json.Number `json:"max"`
+		Exclusive bool        `json:"exclusive,omitempty"`
+		Message   string      `json:"message,omitempty"`
+		Label     string      `json:"label,omitempty"`
+		Optional  bool        `json:"optional,omitempty"`
+	}{"range", min, max, c.exclusive, c.message, c.label, c.optional})
+}
+
+// CanExport for this validator
+func (c *RangeValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *RangeValidator) Params() map[string]any {
+	if c.isInt {
+		return map[string]any{"min": c.intMin, "max": c.intMax, "exclusive": c.exclusive, "optional": c.optional}
+	}
+	return map[string]any{"min": c.min, "max": c.max, "exclusive": c.exclusive, "optional": c.optional}
+}
+
+// Range field must be between min and max, both included unless Exclusive is set. It replaces Min and Max with a single rule and message.
+func Range(min int64, max int64) *RangeValidator {
+	if min > max {
+		panic(fmt.Errorf("range minimum %v is more than the maximum %v", min, max))
+	}
+	return &RangeValidator{
+		min:      float64(min),
+		max:      float64(max),
+		intMin:   min,
+		intMax:   max,
+		isInt:    true,
+		optional: config.OptionalByDefault,
+	}
+}
+
+// RangeFloat field must be between min and max, both included unless Exclusive is set
+func RangeFloat(min float64, max float64) *RangeValidator {
+	if min > max {
+		panic(fmt.Errorf("range minimum %v is more than the maximum %v", min, max))
+	}
+	return &RangeValidator{
+		min:      min,
+		max:      max,
+		optional: config.OptionalByDefault,
+	}
+}
+
+// formatNumber formats a number without an exponent, e.g. 1000000 instead of 1e+06
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
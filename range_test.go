@@ -0,0 +1,62 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRange(t *testing.T) {
+	assert.Nil(t, Range(1, 10).Validate(1), "Min included")
+	assert.Nil(t, Range(1, 10).Validate(10), "Max included")
+	assert.Nil(t, Range(1, 10).Validate(5.5), "Float value")
+	assert.NotNil(t, Range(1, 10).Validate(0), "Below")
+	assert.NotNil(t, Range(1, 10).Validate(int8(11)), "Above")
+	assert.NotNil(t, Range(1, 10).Validate(nil), "Nil")
+	assert.Nil(t, Range(1, 10).SetOptional().Validate(0), "Optional")
+	assert.Nil(t, RangeFloat(0.5, 1.5).Validate(float32(0.5)), "Float range")
+	assert.NotNil(t, RangeFloat(0.5, 1.5).Validate(1.6), "Float above")
+	assert.Panics(t, func() { Range(1, 10).Validate("5") }, "Mismatch")
+	assert.Panics(t, func() { Range(10, 1) }, "Min more than max")
+	assert.NotNil(t, Range(1, 10).Exclusive().Validate(1), "Exclusive min")
+	assert.NotNil(t, Range(1, 10).Exclusive().Validate(10), "Exclusive max")
+	assert.Nil(t, Range(1, 10).Exclusive().Validate(5), "Exclusive within")
+	assert.NotNil(t, RangeFloat(0.5, 1.5).Exclusive().Validate(1.5), "Exclusive float max")
+	assert.Equal(t, "Please change quantity to be more than 1 and less than 10", Range(1, 10).Exclusive().SetLabel("quantity").Validate(1).Error(), "Exclusive message")
+	assert.NotNil(t, Range(math.MaxInt64-1, math.MaxInt64).Validate(int64(math.MaxInt64-2)), "Large bounds compared as int64")
+	assert.Nil(t, Range(math.MaxInt64-1, math.MaxInt64).Validate(int64(math.MaxInt64)), "Large max included")
+
+	type product struct {
+		Quantity int     `json:"quantity"`
+		Discount float64 `json:"discount"`
+	}
+	p := product{}
+	rules := New(&p).Field(&p.Quantity, Range(1, 1000000)).Field(&p.Discount, RangeFloat(0, 0.5))
+	errs := rules.Validate(product{Quantity: 0, Discount: 0.75}).(ErrorSlice)
+	assert.Equal(t, "Please change quantity to be between 1 and 1000000", errs[0].Error(), "Message")
+	assert.Equal(t, "Please change discount to be between 0 and 0.5", errs[1].Error(), "Float message")
+	assert.Equal(t, "range", errs[0].(interface{ Rule() string }).Rule(), "Rule name")
+
+	j, _ := json.Marshal(Range(1, 10))
+	assert.Equal(t, `{"rule":"range","min":1,"max":10}`, string(j), "Export")
+	j, _ = json.Marshal(Range(1, 10).Exclusive())
+	assert.Equal(t, `{"rule":"range","min":1,"max":10,"exclusive":true}`, string(j), "Export exclusive")
+
+	exported, _ := rules.Export()
+	loaded, err := New(&p).Load([]byte(exported))
+	assert.Nil(t, err, "Load")
+	assert.Len(t, loaded.Validate(product{Quantity: 0, Discount: 0.75}), 2, "Loaded")
+	assert.Nil(t, loaded.Validate(product{Quantity: 5, Discount: 0.25}), "Loaded passes")
+	_, err = New(&p).Load([]byte(`{"discount":[{"rule":"range","min":1,"max":0}]}`))
+	assert.NotNil(t, err, "Load min more than max")
+	loaded, err = New(&p).Load([]byte(`{"quantity":[{"rule":"range","min":1,"max":10,"exclusive":true}]}`))
+	assert.Nil(t, err, "Load exclusive")
+	assert.Len(t, loaded.Validate(product{Quantity: 10}), 1, "Loaded exclusive")
+	loaded, err = New(&p).Load([]byte(`{"quantity":[{"rule":"range","min":9007199254740993,"max":9007199254740995}]}`))
+	assert.Nil(t, err, "Load large bounds")
+	assert.Len(t, loaded.Validate(product{Quantity: 9007199254740992}), 1, "Loaded large bounds compared as int64")
+	j, _ = json.Marshal(Range(math.MaxInt64-1, math.MaxInt64))
+	assert.Equal(t, `{"rule":"range","min":9223372036854775806,"max":9223372036854775807}`, string(j), "Export large bounds")
+}
@@ -0,0 +1,214 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RemoteChecker verifies a value with an external service. It returns false if the value is rejected, and an error
+// if the service couldn't be reached.
+type RemoteChecker interface {
+	Check(ctx context.Context, value any) (bool, error)
+}
+
+// RemoteBatchChecker is a RemoteChecker that can also check many values with one call, e.g. one request for all the
+// records of an import. ValidateAll checks the values of each remote validator of the rules in one batch with it.
+type RemoteBatchChecker interface {
+	RemoteChecker
+	// CheckBatch returns whether each value is accepted, in the order of the values
+	CheckBatch(ctx context.Context, values []any) ([]bool, error)
+}
+
+// RemoteOptions configures a remote validator
+type RemoteOptions struct {
+	// Timeout of each check. Defaults to 5 seconds.
+	Timeout time.Duration
+	// CacheTTL is how long results are cached for. Zero disables caching.
+	CacheTTL time.Duration
+	// CacheSize is the most results cached. Defaults to 10000.
+	CacheSize int
+	// Checker of the values. Defaults to the checker of the URL given by the function added with RegisterRemote, e.g.
+	// the HTTP checker of xvalidhttp.
+	Checker RemoteChecker
+}
+
+var (
+	newRemoteChecker func(url string) RemoteChecker
+	remoteMutex      sync.RWMutex
+)
+
+// RegisterRemote sets how remote validators without a checker check their URL. Importing xvalidhttp registers a
+// checker that posts the value to the URL. It should be called on startup.
+func RegisterRemote(newChecker func(url string) RemoteChecker) {
+	remoteMutex.Lock()
+	defer remoteMutex.Unlock()
+	newRemoteChecker = newChecker
+}
+
+var errNoRemoteChecker = errors.New("remote validator needs a checker, set RemoteOptions.Checker or import xvalidhttp")
+
+// RemoteValidator verifies the value with an external service
+type RemoteValidator struct {
+	field   []string
+	message string
+	label   string
+	url     string
+	opts    RemoteOptions
+	cache   *valueCache[bool]
+}
+
+// Field of the field
+func (c *RemoteValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *RemoteValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *RemoteValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *RemoteValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value. Zero values aren't checked. Errors from the service are returned as errors that match
+// ErrInternal.
+func (c *RemoteValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value, cancelling the check when the context is done
+func (c *RemoteValidator) ValidateCtx(ctx context.Context, value any) Error {
+	if value == nil || value == "" {
+		return nil
+	}
+	valid, cached := c.cache.get(value)
+	if !cached {
+		valid, cached = batchResult(ctx, c, value)
+	}
+	if !cached {
+		ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+		defer cancel()
+		err := errNoRemoteChecker
+		if checker := c.remoteChecker(); checker != nil {
+			valid, err = checker.Check(ctx, value)
+		}
+		if err != nil {
+			return &internalError{validationError{
+				message: fmt.Sprintf("Something went wrong while validating %s", dataName(c.field)),
+				field:   c.field,
+			}, err}
+		}
+		c.cache.set(value, valid)
+	}
+	if !valid {
+		return createError(c.field, c.message, fmt.Sprintf("Please enter a valid %s", fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+// remoteBatchKey of the context value holding the results of batched checks
+type remoteBatchKey struct{}
+
+// remoteBatch holds the results of batched checks by validator and cache key of the value
+type remoteBatch map[*RemoteValidator]map[string]bool
+
+// batchResult returns the result of the value if it was checked in a batch
+func batchResult(ctx context.Context, c *RemoteValidator, value any) (bool, bool) {
+	batch, ok := ctx.Value(remoteBatchKey{}).(remoteBatch)
+	if !ok {
+		return false, false
+	}
+	valid, ok := batch[c][cacheKey(value)]
+	return valid, ok
+}
+
+// checkBatch checks the values with one call and returns the results by cache key, or nil if the batch failed so each
+// value is checked on its own instead. The results are also cached.
+func (c *RemoteValidator) checkBatch(ctx context.Context, checker RemoteBatchChecker, values []any) map[string]bool {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+	valid, err := checker.CheckBatch(ctx, values)
+	if err != nil || len(valid) != len(values) {
+		return nil
+	}
+	results := make(map[string]bool, len(values))
+	for i, value := range values {
+		results[cacheKey(value)] = valid[i]
+		c.cache.set(value, valid[i])
+	}
+	return results
+}
+
+// remoteChecker returns the checker of the options, or the registered checker of the URL
+func (c *RemoteValidator) remoteChecker() RemoteChecker {
+	if c.opts.Checker != nil {
+		return c.opts.Checker
+	}
+	remoteMutex.RLock()
+	defer remoteMutex.RUnlock()
+	if newRemoteChecker == nil {
+		return nil
+	}
+	return newRemoteChecker(c.url)
+}
+
+// checkSetup reports a missing checker
+func (c *RemoteValidator) checkSetup() error {
+	if c.opts.Checker != nil {
+		return nil
+	}
+	remoteMutex.RLock()
+	defer remoteMutex.RUnlock()
+	if newRemoteChecker == nil {
+		return errNoRemoteChecker
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *RemoteValidator) CanExport() bool {
+	return c.url != ""
+}
+
+// Params of this validator
+func (c *RemoteValidator) Params() map[string]any {
+	return map[string]any{"url": c.url}
+}
+
+// MarshalJSON for this validator
+func (c *RemoteValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule    string `json:"rule"`
+		URL     string `json:"url"`
+		Message string `json:"message,omitempty"`
+		Label   string `json:"label,omitempty"`
+	}{"remote", c.url, c.message, c.label})
+}
+
+// Remote verifies the value with a service at a URL, e.g. an address or VAT number lookup. The value is posted to the
+// URL by the checker of xvalidhttp once it's imported, or checked by the checker of the options. Without either every
+// check fails with an internal error, which Rules.Check reports. The URL is exported so clients can use the same
+// service.
+func Remote(url string, opts RemoteOptions) *RemoteValidator {
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &RemoteValidator{
+		url:   url,
+		opts:  opts,
+		cache: newValueCache[bool](opts.CacheTTL, opts.CacheSize),
+	}
+}
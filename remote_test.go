@@ -0,0 +1,86 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// vatChecker accepts "DE123", fails on "broken" and waits for the context on "slow"
+type vatChecker struct {
+	calls *int
+}
+
+func (v vatChecker) Check(ctx context.Context, value any) (bool, error) {
+	*v.calls++
+	switch value {
+	case "broken":
+		return false, errors.New("service unavailable")
+	case "slow":
+		<-ctx.Done()
+		return false, ctx.Err()
+	}
+	return value == "DE123", nil
+}
+
+func TestRemote(t *testing.T) {
+	calls := 0
+	type company struct {
+		VAT string `json:"vat"`
+	}
+	c := company{}
+	opts := RemoteOptions{Timeout: 20 * time.Millisecond, CacheTTL: time.Minute, Checker: vatChecker{&calls}}
+	rules := New(&c).Field(&c.VAT, Remote("https://example.com/vat", opts))
+	assert.Nil(t, rules.Validate(company{VAT: "DE123"}), "Accepted")
+	assert.Nil(t, rules.Validate(company{VAT: "DE123"}), "Cached")
+	assert.Equal(t, 1, calls, "Result cached")
+	errs := rules.Validate(company{VAT: "XX"}).(ErrorSlice)
+	assert.Equal(t, "Please enter a valid vat", errs[0].Error(), "Rejected")
+	assert.Equal(t, "remote", errs[0].(interface{ Rule() string }).Rule(), "Rule name")
+	assert.Nil(t, rules.Validate(company{}), "Zero value not checked")
+	assert.Equal(t, 2, calls, "Zero value not sent")
+
+	errs = rules.Validate(company{VAT: "broken"}).(ErrorSlice)
+	assert.True(t, errors.Is(errs[0], ErrInternal), "Service error")
+	errs = rules.Validate(company{VAT: "slow"}).(ErrorSlice)
+	assert.True(t, errors.Is(errs[0], ErrInternal), "Timeout")
+	errs = New(&c).Field(&c.VAT, Remote("https://example.com/vat", RemoteOptions{})).Validate(company{VAT: "DE123"}).(ErrorSlice)
+	assert.ErrorIs(t, errs[0], ErrInternal, "No checker")
+
+	j, _ := json.Marshal(Remote("https://example.com/vat", RemoteOptions{}))
+	assert.Equal(t, `{"rule":"remote","url":"https://example.com/vat"}`, string(j), "Export")
+}
+
+type stubChecker map[string]bool
+
+func (s stubChecker) Check(_ context.Context, value any) (bool, error) {
+	return s[value.(string)], nil
+}
+
+func TestRemoteChecker(t *testing.T) {
+	type address struct {
+		Street string `json:"street"`
+	}
+	a := address{}
+	rules := New(&a).Field(&a.Street, Remote("", RemoteOptions{Checker: stubChecker{"Main St": true}}))
+	assert.Nil(t, rules.Validate(address{Street: "Main St"}), "Accepted by checker")
+	assert.Len(t, rules.Validate(address{Street: "Nowhere"}), 1, "Rejected by checker")
+	assert.False(t, Remote("", RemoteOptions{Checker: stubChecker{}}).CanExport(), "Not exportable without URL")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	rules = New(&a).Field(&a.Street, Remote("", RemoteOptions{Checker: ctxChecker{}}))
+	assert.Nil(t, rules.Validate(address{Street: "Main St"}), "Background context")
+	assert.ErrorIs(t, rules.Validators()[0].(ValidatorCtx).ValidateCtx(cancelled, "Main St"), ErrInternal,
+		"Context of ValidateCtx passed to checker")
+}
+
+type ctxChecker struct{}
+
+func (ctxChecker) Check(ctx context.Context, value any) (bool, error) {
+	return true, ctx.Err()
+}
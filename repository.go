@@ -0,0 +1,29 @@
+package xvalid
+
+import "context"
+
+// Validated wraps a function that writes its argument, e.g. an insert or update generated by sqlc, so the argument is
+// validated with the rules before the function is called. The function isn't called if the argument is invalid, so
+// services that don't go through an HTTP handler, such as queue consumers and cron jobs, get the same validation.
+//
+//	createUser := xvalid.Validated(params.Rules(), queries.CreateUser)
+//	user, err := createUser(ctx, params)
+func Validated[T, R any](rules Rules, fn func(ctx context.Context, arg T) (R, error)) func(ctx context.Context, arg T) (R, error) {
+	return func(ctx context.Context, arg T) (R, error) {
+		if err := rules.ValidateCtx(ctx, arg); err != nil {
+			var zero R
+			return zero, err
+		}
+		return fn(ctx, arg)
+	}
+}
+
+// ValidatedExec is like Validated for functions that only return an error
+func ValidatedExec[T any](rules Rules, fn func(ctx context.Context, arg T) error) func(ctx context.Context, arg T) error {
+	return func(ctx context.Context, arg T) error {
+		if err := rules.ValidateCtx(ctx, arg); err != nil {
+			return err
+		}
+		return fn(ctx, arg)
+	}
+}
@@ -0,0 +1,37 @@
+package xvalid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidated(t *testing.T) {
+	type createParams struct {
+		Name string `json:"name"`
+	}
+	p := createParams{}
+	rules := New(&p).Field(&p.Name, Required())
+	calls := 0
+	create := Validated(rules, func(ctx context.Context, arg createParams) (int64, error) {
+		calls++
+		return 1, nil
+	})
+	id, err := create(context.Background(), createParams{})
+	assert.NotNil(t, err, "Invalid argument")
+	assert.Equal(t, int64(0), id, "Zero result")
+	assert.Equal(t, 0, calls, "Not called")
+	id, err = create(context.Background(), createParams{Name: "a"})
+	assert.Nil(t, err, "Valid argument")
+	assert.Equal(t, int64(1), id, "Result")
+	assert.Equal(t, 1, calls, "Called")
+
+	update := ValidatedExec(rules, func(ctx context.Context, arg createParams) error {
+		calls++
+		return nil
+	})
+	assert.NotNil(t, update(context.Background(), createParams{}), "Invalid exec")
+	assert.Nil(t, update(context.Background(), createParams{Name: "a"}), "Valid exec")
+	assert.Equal(t, 2, calls, "Exec called once")
+}
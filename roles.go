@@ -0,0 +1,150 @@
+package xvalid
+
+import (
+	"context"
+
+	"golang.org/x/exp/slices"
+)
+
+// RolesValidator limits validators to the roles of a view, e.g. so rules only admins can see aren't exported to public
+// clients. Validating the full rules still runs them.
+type RolesValidator struct {
+	field      []string
+	roles      []string
+	validators []Validator
+}
+
+// Field of the field
+func (c *RolesValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *RolesValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.validators {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators
+func (c *RolesValidator) SetMessage(msg string) Validator {
+	for _, v := range c.validators {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *RolesValidator) SetLabel(label string) Validator {
+	for _, v := range c.validators {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Validate the value and return the first error
+func (c *RolesValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value with the context and returns the first error
+func (c *RolesValidator) ValidateCtx(ctx context.Context, value any) Error {
+	for _, v := range c.validators {
+		if err := validateValue(ctx, v, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *RolesValidator) CanExport() bool {
+	for _, v := range c.validators {
+		if !v.CanExport() {
+			return false
+		}
+	}
+	return true
+}
+
+// Params of this validator
+func (c *RolesValidator) Params() map[string]any {
+	return map[string]any{"roles": c.roles, "validators": c.validators}
+}
+
+// Roles the validators are limited to
+func (c *RolesValidator) Roles() []string {
+	return c.roles
+}
+
+func (c *RolesValidator) clone() Validator {
+	validators := make([]Validator, len(c.validators))
+	for i, v := range c.validators {
+		validators[i] = cloneValidator(v)
+	}
+	return &RolesValidator{
+		field:      c.field,
+		roles:      c.roles,
+		validators: validators,
+	}
+}
+
+// ForRoles limits the validators to views of the roles, see Rules.View. Nesting ForRoles limits the validators to the
+// roles in both.
+func ForRoles(roles []string, validators ...Validator) *RolesValidator {
+	return &RolesValidator{
+		roles:      roles,
+		validators: validators,
+	}
+}
+
+// expand replaces bundles and role groups with their validators and remembers the roles of the validators in groups
+func (r *Rules) expand(validators []Validator, roles []string) []Validator {
+	list := make([]Validator, 0, len(validators))
+	for _, v := range expandBundles(validators) {
+		rv, ok := v.(*RolesValidator)
+		if !ok {
+			if roles != nil {
+				r.setRoles(v, roles)
+			}
+			list = append(list, v)
+			continue
+		}
+		limited := rv.roles
+		if roles != nil {
+			limited = make([]string, 0, len(roles))
+			for _, role := range roles {
+				if slices.Contains(rv.roles, role) {
+					limited = append(limited, role)
+				}
+			}
+		}
+		list = append(list, r.expand(rv.validators, limited)...)
+	}
+	return list
+}
+
+// setRoles copies the roles map so other chains sharing it aren't changed
+func (r *Rules) setRoles(validator Validator, roles []string) {
+	m := make(map[Validator][]string, len(r.roles)+1)
+	for k, v := range r.roles {
+		m[k] = v
+	}
+	m[validator] = roles
+	r.roles = m
+}
+
+// View returns the rules seen by the role, which are the validators not limited with ForRoles and the ones limited to
+// the role. The view can be validated and exported like any other rules, so public and admin clients can get
+// different contracts from one definition.
+func (r Rules) View(role string) Rules {
+	validators := make([]Validator, 0, len(r.validators))
+	for _, v := range r.validators {
+		if roles, ok := r.roles[v]; !ok || slices.Contains(roles, role) {
+			validators = append(validators, v)
+		}
+	}
+	r.validators = validators
+	return r
+}
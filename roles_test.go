@@ -0,0 +1,47 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolesView(t *testing.T) {
+	type account struct {
+		Name  string `json:"name"`
+		Notes string `json:"notes"`
+		Quota int    `json:"quota"`
+	}
+	a := account{}
+	rules := New(&a).
+		Field(&a.Name, Required(), ForRoles([]string{"admin"}, MaxLength(50))).
+		Field(&a.Notes, ForRoles([]string{"admin", "support"}, MaxLength(10), ForRoles([]string{"admin"}, Pattern(`^\w*$`)))).
+		Field(&a.Quota, ForRoles([]string{"admin"}, Min(1)))
+
+	j, _ := json.Marshal(rules.View("public"))
+	assert.Equal(t, `{"name":[{"rule":"required"}]}`, string(j), "Public export")
+	j, _ = json.Marshal(rules.View("support"))
+	assert.Equal(t, `{"name":[{"rule":"required"}],"notes":[{"rule":"maxLength","max":10}]}`, string(j), "Support export")
+	j, _ = json.Marshal(rules.View("admin"))
+	assert.Equal(t,
+		`{"name":[{"rule":"required"},{"rule":"maxLength","max":50}],"notes":[{"rule":"maxLength","max":10},{"rule":"pattern","pattern":"^\\w*$"}],"quota":[{"rule":"min","min":1}]}`,
+		string(j), "Admin export")
+
+	subject := account{Name: "a", Notes: "not a word"}
+	assert.Nil(t, rules.View("public").Validate(subject), "Public skips admin rules")
+	assert.Nil(t, rules.View("support").Validate(subject), "Support skips nested admin rule")
+	assert.Len(t, rules.View("admin").Validate(subject), 2, "Admin runs all rules")
+	assert.Len(t, rules.Validate(subject), 2, "Full rules run all rules")
+
+	password := Bundle(ForRoles([]string{"admin"}, MinLength(8)))
+	type reset struct {
+		Password string `json:"password"`
+		Confirm  string `json:"confirm"`
+	}
+	r := reset{}
+	resetRules := New(&r).Field(&r.Password, password).Field(&r.Confirm, password)
+	errs := resetRules.View("admin").Validate(reset{Password: "longenough"}).(ErrorSlice)
+	assert.Equal(t, []string{"confirm"}, errs[0].Field(), "Roles in bundle are copied per field")
+	assert.Nil(t, resetRules.View("public").Validate(reset{}), "Roles in bundle")
+}
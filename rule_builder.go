@@ -1,10 +1,15 @@
 package xvalid
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Error when a rule is broken
@@ -17,6 +22,7 @@ type Error interface {
 type validationError struct {
 	message string
 	field   []string
+	rule    string
 }
 
 // Error message
@@ -29,12 +35,51 @@ func (v validationError) Field() []string {
 	return v.field
 }
 
+// Rule name of the validator that returned this error
+func (v validationError) Rule() string {
+	return v.rule
+}
+
+func (v *validationError) setRule(rule string) {
+	if v.rule == "" {
+		v.rule = rule
+	}
+}
+
 func (e validationError) MarshalJSON() ([]byte, error) {
+	return marshalError(e, "")
+}
+
+// marshalError encodes the error with the code if there is one, e.g. "timeout"
+func marshalError(e validationError, code string) ([]byte, error) {
+	// the rule is only added if it's configured
+	rule := ""
+	if config.ErrorRules {
+		rule = e.rule
+	}
 	// only use the last field name for embeded structs
-	return json.MarshalIndent(struct {
+	return json.Marshal(struct {
 		Message   string `json:"message"`
 		FieldName string `json:"field"`
-	}{e.message, jsonFieldName(e.field)}, "", "	")
+		Rule      string `json:"rule,omitempty"`
+		Code      string `json:"code,omitempty"`
+	}{e.message, errorPath(e.field), rule, code})
+}
+
+// UnmarshalJSON parses an error encoded with MarshalJSON. The field is split back into its path, e.g. "items[3].name"
+// becomes ["items", "3", "name"], but the names of parent structs that weren't encoded can't be recovered. The rule is
+// only encoded if Config.ErrorRules is set.
+func (e *validationError) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Message   string `json:"message"`
+		FieldName string `json:"field"`
+		Rule      string `json:"rule"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	e.message, e.field, e.rule = v.Message, parseErrorPath(v.FieldName), v.Rule
+	return nil
 }
 
 // NewError creates new validation error
@@ -45,6 +90,54 @@ func NewError(message string, field... string) Error {
 	}
 }
 
+// ErrTooComplex is matched by the error returned when a subject exceeds the limits set with Rules.MaxDepth or
+// Rules.MaxElements. Use errors.Is to check for it.
+var ErrTooComplex = errors.New("too complex")
+
+// complexityError is returned when a subject exceeds the limits of the rules
+type complexityError struct {
+	validationError
+}
+
+// Unwrap to ErrTooComplex
+func (e complexityError) Unwrap() error {
+	return ErrTooComplex
+}
+
+// ErrTimeout is matched by the error returned when a validator takes longer than allowed. Use errors.Is to check for
+// it.
+var ErrTimeout = errors.New("timeout")
+
+// timeoutError is returned when a validator doesn't finish in time
+type timeoutError struct {
+	validationError
+}
+
+// Unwrap to ErrTimeout
+func (e timeoutError) Unwrap() error {
+	return ErrTimeout
+}
+
+// MarshalJSON adds the "timeout" code so clients can tell the error apart from a failed rule, e.g. to retry
+func (e timeoutError) MarshalJSON() ([]byte, error) {
+	return marshalError(e.validationError, "timeout")
+}
+
+// ErrInternal is matched by the error returned when a validator panics. Use errors.Is to check for it. The panic value
+// can also be matched if it is an error.
+var ErrInternal = errors.New("internal error")
+
+// internalError is returned when a validator panics
+type internalError struct {
+	validationError
+	cause error
+}
+
+// Unwrap to ErrInternal and the panic value
+func (e internalError) Unwrap() []error {
+	return []error{ErrInternal, e.cause}
+}
+
 // ErrorSlice is a list of Error
 type ErrorSlice []Error
 
@@ -66,15 +159,77 @@ func (e ErrorSlice) Unwrap() []error {
 	return errs
 }
 
+// UnmarshalJSON parses a list of errors encoded with MarshalJSON, e.g. the response of another service. Errors with the
+// "timeout" code match ErrTimeout.
+func (e *ErrorSlice) UnmarshalJSON(data []byte) error {
+	var list []*validationError
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	var codes []struct {
+		Code string `json:"code"`
+	}
+	json.Unmarshal(data, &codes)
+	errs := make(ErrorSlice, 0, len(list))
+	for i, err := range list {
+		if err == nil {
+			continue
+		}
+		if i < len(codes) && codes[i].Code == "timeout" {
+			errs = append(errs, &timeoutError{*err})
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	*e = errs
+	return nil
+}
+
 // ToMap converts to map
 func (e ErrorSlice) ToMap() ErrorMap {
 	errs := make(ErrorMap)
 	for i, err := range e {
-		errs[jsonFieldName(err.Field())] = e[i]
+		errs[errorPath(err.Field())] = e[i]
 	}
 	return errs
 }
 
+// ToNestedMap converts to a map that is nested by the field path, e.g. {"deep": {"deepInt": ...}}, so fields of nested
+// structs with the same name don't collide. The error of a field that also has errors of nested fields is kept under
+// the "_error" key, e.g. {"address": {"_error": ..., "city": ...}}.
+func (e ErrorSlice) ToNestedMap() NestedErrorMap {
+	errs := make(NestedErrorMap)
+	for _, err := range e {
+		field := err.Field()
+		if len(field) == 0 {
+			errs[""] = err
+			continue
+		}
+		m := errs
+		for _, p := range field[:len(field)-1] {
+			child, ok := m[p].(NestedErrorMap)
+			if !ok {
+				child = make(NestedErrorMap)
+				if parent, ok := m[p].(Error); ok {
+					child[nestedErrorKey] = parent
+				}
+				m[p] = child
+			}
+			m = child
+		}
+		last := field[len(field)-1]
+		if child, ok := m[last].(NestedErrorMap); ok {
+			child[nestedErrorKey] = err
+		} else {
+			m[last] = err
+		}
+	}
+	return errs
+}
+
+// nestedErrorKey holds the error of a field in the NestedErrorMap of its nested fields
+const nestedErrorKey = "_error"
+
 // ErrorMap is a map of Error
 type ErrorMap map[string]Error
 
@@ -113,6 +268,36 @@ func (e ErrorMap) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// UnmarshalJSON parses a map of errors encoded with MarshalJSON. Rule names aren't part of the map so they are empty.
+func (e *ErrorMap) UnmarshalJSON(data []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	errs := make(ErrorMap, len(m))
+	for k, v := range m {
+		errs[k] = &validationError{message: v, field: parseErrorPath(k)}
+	}
+	*e = errs
+	return nil
+}
+
+// NestedErrorMap is a map of Error by field name, with a NestedErrorMap for each nested struct
+type NestedErrorMap map[string]any
+
+// MarshalJSON encodes each Error as its message and each NestedErrorMap as an object
+func (e NestedErrorMap) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(e))
+	for k, v := range e {
+		if err, ok := v.(Error); ok {
+			m[k] = err.Error()
+		} else {
+			m[k] = v
+		}
+	}
+	return json.Marshal(m)
+}
+
 // -----
 
 // Validator to implement a rule
@@ -124,10 +309,66 @@ type Validator interface {
 	Validate(any) Error
 }
 
+// ValidatorCtx is implemented by validators that use the context given to ValidateCtx, e.g. to stop a database lookup
+// once the deadline passes or to read request scoped values such as the tenant. Validate is used by Validate and by
+// rules that don't pass a context.
+type ValidatorCtx interface {
+	Validator
+	ValidateCtx(ctx context.Context, value any) Error
+}
+
+// validateValue validates the value with the context if the validator supports it, or as its type if the validator is
+// typed
+func validateValue(ctx context.Context, validator Validator, value any) Error {
+	if vc, ok := validator.(ValidatorCtx); ok {
+		return vc.ValidateCtx(ctx, value)
+	}
+	if tv, ok := validator.(typedValidator); ok {
+		if err, ok := tv.validateTyped(value); ok {
+			return err
+		}
+	}
+	return validator.Validate(value)
+}
+
+// Metrics for instrumenting validation
+type Metrics interface {
+	// OnRuleEvaluated is called after each validator with the rule name, the field and how long it took
+	OnRuleEvaluated(rule string, field []string, failed bool, duration time.Duration)
+}
+
+// MetricsFunc is a function that implements Metrics
+type MetricsFunc func(rule string, field []string, failed bool, duration time.Duration)
+
+// OnRuleEvaluated calls the function
+func (f MetricsFunc) OnRuleEvaluated(rule string, field []string, failed bool, duration time.Duration) {
+	f(rule, field, failed, duration)
+}
+
+// Tracer for tracing validation, e.g. by starting an OpenTelemetry span
+type Tracer interface {
+	// Start is called before validating with the type of the subject and the number of rules. The returned context is
+	// used for validation and the returned function is called with the number of errors when validation ends.
+	Start(ctx context.Context, structType string, rules int) (context.Context, func(errors int))
+}
+
 // Rules for creating a chain of rules for validating a struct
 type Rules struct {
-	validators []Validator
-	structPtr  any
+	validators   []Validator
+	structPtr    any
+	labels       map[string]string
+	maxDepth     int
+	maxElements  int
+	timeout      time.Duration
+	metrics      Metrics
+	tracer       Tracer
+	partial      bool
+	dedupe       DedupeMode
+	shortCircuit bool
+	roles        map[Validator][]string
+	audit        AuditSink
+	sensitive    map[string]bool
+	showValues   bool
 }
 
 // New rule chain
@@ -135,76 +376,501 @@ func New(structPtr any) Rules {
 	return Rules{
 		structPtr:  structPtr,
 		validators: make([]Validator, 0),
+		labels:     make(map[string]string),
 	}
 }
 
+// contextValidator is implemented by validators that depend on the context given to ValidateCtx
+type contextValidator interface {
+	fieldsValidator
+	// validateContext using the context and the values of all fields
+	validateContext(ctx context.Context, vmap map[string]any) ErrorSlice
+}
+
+// fieldsValidator is implemented by validators that need the values of other fields
+type fieldsValidator interface {
+	// resolveFields converts field pointers into field names
+	resolveFields(structPtr any)
+	// validateFields using the values of all fields
+	validateFields(vmap map[string]any) ErrorSlice
+}
+
 // Field adds validators for a field
 func (r Rules) Field(fieldPtr any, validators ...Validator) Rules {
-	for _, validator := range validators {
-		validator.SetField(getField(r.structPtr, fieldPtr)...)
+	for _, validator := range r.expand(validators, nil) {
+		field := getField(r.structPtr, fieldPtr)
+		validator.SetField(field...)
+		if fv, ok := validator.(fieldsValidator); ok {
+			fv.resolveFields(r.structPtr)
+		}
+		if label, ok := r.labels[strings.Join(field, ".")]; ok {
+			setLabel(validator, label)
+		}
 		r.validators = append(r.validators, validator)
 	}
 	return r
 }
 
+// Label sets a human readable name for a field. The label is used in error messages and exported rules, while the
+// field name is still used for JSON keys. Labels are kept by the path of the field, e.g. "Address.city", so fields of
+// nested structs with the same name can have different labels.
+func (r Rules) Label(fieldPtr any, label string) Rules {
+	name := strings.Join(getField(r.structPtr, fieldPtr), ".")
+	labels := make(map[string]string, len(r.labels)+1)
+	for k, v := range r.labels {
+		labels[k] = v
+	}
+	labels[name] = label
+	r.labels = labels
+	for _, validator := range r.validators {
+		if strings.Join(validator.Field(), ".") == name {
+			setLabel(validator, label)
+		}
+	}
+	return r
+}
+
+// Labels returns the field labels of this chain by the path of the field
+func (r Rules) Labels() map[string]string {
+	return r.labels
+}
+
+// Timeout limits how long each validator can run for when validating with ValidateCtx. Zero means no limit.
+func (r Rules) Timeout(timeout time.Duration) Rules {
+	r.timeout = timeout
+	return r
+}
+
+// Metrics receives a measurement for every validator that is evaluated
+func (r Rules) Metrics(metrics Metrics) Rules {
+	r.metrics = metrics
+	return r
+}
+
+// Tracer traces every call to Validate and ValidateCtx
+func (r Rules) Tracer(tracer Tracer) Rules {
+	r.tracer = tracer
+	return r
+}
+
+// MaxDepth limits how deeply nested the subject can be. Subjects that are nested deeper fail with ErrTooComplex
+// without running any validators. Zero means no limit.
+func (r Rules) MaxDepth(depth int) Rules {
+	r.maxDepth = depth
+	return r
+}
+
+// MaxElements limits the number of elements in any slice, array or map of the subject. Subjects with more elements
+// fail with ErrTooComplex without running any validators. Zero means no limit.
+func (r Rules) MaxElements(count int) Rules {
+	r.maxElements = count
+	return r
+}
+
 // Struct adds validators for the struct
 func (r Rules) Struct(validators ...Validator) Rules {
-	r.validators = append(r.validators, validators...)
+	r.validators = append(r.validators, r.expand(validators, nil)...)
 	return r
 }
 
 // Validate a struct and return Errors
 func (r Rules) Validate(subject any) error {
+	return r.ValidateCtx(context.Background(), subject)
+}
+
+// ValidateJSON decodes the JSON into a new instance of the struct the rules were created with and validates it.
+// Errors from decoding are returned as is.
+func (r Rules) ValidateJSON(data []byte) error {
+	subject := reflect.New(reflect.TypeOf(r.structPtr).Elem())
+	if err := json.Unmarshal(data, subject.Interface()); err != nil {
+		return err
+	}
+	return r.Validate(subject.Elem().Interface())
+}
+
+// ValidateMap validates a decoded map, e.g. from CBOR or MessagePack, the same way as ValidateJSON. Keys of
+// map[any]any are converted to strings. Errors from converting the map into the struct are returned as is.
+func (r Rules) ValidateMap(m any) error {
+	data, err := json.Marshal(stringKeys(m))
+	if err != nil {
+		return err
+	}
+	return r.ValidateJSON(data)
+}
+
+// stringKeys converts map[any]any to map[string]any, including nested maps and slices, so it can be encoded as JSON
+func stringKeys(v any) any {
+	switch v2 := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v2))
+		for k, e := range v2 {
+			m[fmt.Sprint(k)] = stringKeys(e)
+		}
+		return m
+	case map[string]any:
+		m := make(map[string]any, len(v2))
+		for k, e := range v2 {
+			m[k] = stringKeys(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v2))
+		for i, e := range v2 {
+			s[i] = stringKeys(e)
+		}
+		return s
+	}
+	return v
+}
+
+// ValidateCtx validates a struct and stops waiting for validators once the deadline of the context passes or the
+// validator runs longer than the timeout set with Rules.Timeout. Validators that didn't finish in time return an error
+// matching ErrTimeout, encoded with the "timeout" code in JSON. The validator itself keeps running in the background
+// until it returns. Validators that implement ValidatorCtx get the context, including the deadline of the timeout, and
+// are the only ones stopped by a context without a deadline.
+func (r Rules) ValidateCtx(ctx context.Context, subject any) error {
+	var errs ErrorSlice
+	if r.tracer != nil {
+		var end func(errors int)
+		ctx, end = r.tracer.Start(ctx, fmt.Sprintf("%T", subject), len(r.validators))
+		errs = r.validate(ctx, subject)
+		end(len(errs))
+	} else {
+		errs = r.validate(ctx, subject)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate the subject with all validators
+func (r Rules) validate(ctx context.Context, subject any) ErrorSlice {
+	if r.maxDepth > 0 || r.maxElements > 0 {
+		c := complexity{r.maxDepth, r.maxElements, make(map[uintptr]bool)}
+		if err := c.check(reflect.ValueOf(subject), nil, 0); err != nil {
+			return ErrorSlice{err}
+		}
+	}
 	errs := make(ErrorSlice, 0)
-	vmap := structToMap(subject)
+	vmap := r.toMap(subject)
+	missing := make(map[string]bool)
 	for _, validator := range r.validators {
-		var err Error
-		if validator.Field() == nil || len(validator.Field()) == 0 {
-			// struct validation
-			err = validator.Validate(subject)
+		if r.absent(validator, vmap) || missing[strings.Join(validator.Field(), ".")] {
+			continue
+		}
+		start := time.Now()
+		verrs := r.run(ctx, validator, func(ctx context.Context) ErrorSlice {
+			return validate(ctx, validator, subject, vmap)
+		})
+		if r.metrics != nil {
+			r.metrics.OnRuleEvaluated(ruleName(validator), validator.Field(), len(verrs) > 0, time.Since(start))
+		}
+		verrs = r.translateErrors(ctx, validator, verrs)
+		verrs = r.redactErrors(validator, vmap, verrs)
+		if r.showValues {
+			verrs = r.addValues(validator, vmap, verrs)
+		}
+		if r.audit != nil {
+			r.record(ctx, subject, vmap, verrs)
+		}
+		r.stop(validator, verrs, missing)
+		errs = append(errs, verrs...)
+	}
+	return dedupe(errs, r.dedupe)
+}
+
+// Explanation of how a validator was evaluated
+type Explanation struct {
+	// Rule name of the validator
+	Rule string `json:"rule"`
+	// Field being validated, empty for struct validators
+	Field []string `json:"field"`
+	// Value seen by the validator
+	Value any `json:"value"`
+	// Found is false if the field doesn't exist in the subject
+	Found bool `json:"found"`
+	// Evaluated is false if the validator wasn't run
+	Evaluated bool `json:"evaluated"`
+	// Errors returned by the validator
+	Errors ErrorSlice `json:"errors,omitempty"`
+}
+
+// Explain validates the subject and reports every validator that was evaluated, the value it saw and the errors it
+// returned. Useful for finding out why a field isn't validated as expected.
+func (r Rules) Explain(subject any) []Explanation {
+	vmap := r.toMap(subject)
+	missing := make(map[string]bool)
+	explanations := make([]Explanation, len(r.validators))
+	for i, validator := range r.validators {
+		e := Explanation{
+			Rule:  ruleName(validator),
+			Field: validator.Field(),
+		}
+		if len(validator.Field()) == 0 {
+			e.Value, e.Found, e.Evaluated = subject, true, true
+			if len(r.sensitive) > 0 {
+				e.Value = redactValue(subject)
+			}
 		} else {
-			// field validation
-			v := vmap
-			for _, p := range validator.Field() {
-				switch v2 := v[p].(type) {
-				default:
-					err = validator.Validate(v2)
-				case map[string]any:
-					v = v2
-				}
+			e.Value, e.Evaluated = fieldValue(vmap, validator.Field())
+			e.Found = hasField(vmap, validator.Field())
+			if _, ok := validator.(fieldsValidator); ok {
+				e.Evaluated = true
 			}
 		}
-		if err != nil {
-			errs = append(errs, err)
+		if r.absent(validator, vmap) || missing[strings.Join(validator.Field(), ".")] {
+			e.Evaluated = false
+		}
+		if e.Evaluated {
+			e.Errors = validate(context.Background(), validator, subject, vmap)
+			e.Errors = r.redactErrors(validator, vmap, e.Errors)
+			r.stop(validator, e.Errors, missing)
 		}
+		if r.isSensitive(validator.Field()) {
+			e.Value = redactValue(e.Value)
+		}
+		explanations[i] = e
 	}
-	if len(errs) > 0 {
-		return errs
+	return explanations
+}
+
+// run the validation function within the time limits
+func (r Rules) run(ctx context.Context, validator Validator, f func(ctx context.Context) ErrorSlice) ErrorSlice {
+	// a context without a deadline is left to the validator, so requests without a timeout don't start a goroutine
+	if _, ok := ctx.Deadline(); r.timeout == 0 && !ok {
+		return f(ctx)
+	}
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	if ctx.Err() == nil {
+		done := make(chan ErrorSlice, 1)
+		go func() {
+			done <- f(ctx)
+		}()
+		select {
+		case errs := <-done:
+			return errs
+		case <-ctx.Done():
+		}
+	}
+	return ErrorSlice{&timeoutError{validationError{
+		message: fmt.Sprintf("Please try again, %s took too long to validate", dataName(validator.Field())),
+		field:   validator.Field(),
+		rule:    ruleName(validator),
+	}}}
+}
+
+// subjectKey of the context value holding the subject given to Validate
+type subjectKey struct{}
+
+// validate the subject with a single validator. Panics are recovered and returned as an error matching ErrInternal.
+func validate(ctx context.Context, validator Validator, subject any, vmap map[string]any) (errs ErrorSlice) {
+	defer func() {
+		if p := recover(); p != nil {
+			cause, ok := p.(error)
+			if !ok {
+				cause = fmt.Errorf("%v", p)
+			}
+			errs = ErrorSlice{&internalError{validationError{
+				message: fmt.Sprintf("Something went wrong while validating %s", dataName(validator.Field())),
+				field:   validator.Field(),
+				rule:    ruleName(validator),
+			}, cause}}
+		}
+	}()
+	// validators such as When decide on the whole subject
+	ctx = context.WithValue(ctx, subjectKey{}, subject)
+	var err Error
+	if validator.Field() == nil || len(validator.Field()) == 0 {
+		// struct validation
+		err = validateValue(ctx, validator, subject)
+	} else if cv, ok := validator.(contextValidator); ok {
+		// validation that depends on the context
+		return setRule(cv.validateContext(ctx, vmap), ruleName(validator))
+	} else if fv, ok := validator.(fieldsValidator); ok {
+		// validation that depends on other fields
+		return setRule(fv.validateFields(vmap), ruleName(validator))
+	} else if v, ok := fieldValue(vmap, validator.Field()); ok {
+		// field validation
+		err = validateValue(ctx, validator, v)
+	}
+	if err != nil {
+		return setRule(ErrorSlice{err}, ruleName(validator))
 	}
 	return nil
 }
 
+// validateAll validates the field with each validator the way validate does, for validators that hold others
+func validateAll(ctx context.Context, validators []Validator, field []string, vmap map[string]any) ErrorSlice {
+	errs := make(ErrorSlice, 0)
+	value, ok := fieldValue(vmap, field)
+	for _, v := range validators {
+		if cv, isContext := v.(contextValidator); isContext {
+			errs = append(errs, setRule(cv.validateContext(ctx, vmap), ruleName(v))...)
+		} else if fv, isFields := v.(fieldsValidator); isFields {
+			errs = append(errs, setRule(fv.validateFields(vmap), ruleName(v))...)
+		} else if ok {
+			if err := validateValue(ctx, v, value); err != nil {
+				errs = append(errs, setRule(ErrorSlice{err}, ruleName(v))...)
+			}
+		}
+	}
+	return errs
+}
+
 // Validators for this chain
 func (r Rules) Validators() []Validator {
 	return r.validators
 }
 
 func (r Rules) MarshalJSON() ([]byte, error) {
-	rmap := make(map[string][]any)
+	return r.Export()
+}
+
+// Compact exports the rules without indentation
+func Compact() ExportOption {
+	return func(c *exportConfig) {
+		c.compact = true
+	}
+}
+
+// Export the rules as JSON. Fields are in the order they are declared in the struct, with fields of embedded and nested
+// structs in place of the struct field. Same as MarshalJSON without options.
+func (r Rules) Export(options ...ExportOption) ([]byte, error) {
+	rmap, config, err := r.exportMap(options)
+	if err != nil {
+		return nil, err
+	}
+	if config.compact {
+		return json.Marshal(rmap)
+	}
+	return json.MarshalIndent(rmap, "", "	")
+}
+
+// exportMap returns the rules to export by field name
+func (r Rules) exportMap(options []ExportOption) (*orderedMap, exportConfig, error) {
+	config := exportConfig{}
+	for _, o := range options {
+		o(&config)
+	}
+	rmap := newOrderedMap()
+	fields := make(map[string][]string)
 	validators := r.Validators()
 	for _, v := range validators {
 		if !v.CanExport() {
 			continue
 		}
 		name := jsonFieldName(v.Field())
-		rules, ok := rmap[name]
+		if (config.only != nil && !config.only[name]) || config.exclude[name] {
+			continue
+		}
+		rules, ok := rmap.values[name].([]any)
 		if !ok {
 			rules = make([]any, 0)
+			fields[name] = v.Field()
 		}
-		rules = append(rules, v)
-		rmap[name] = rules
+		if export := extensionExport(v); export != nil {
+			rule, err := export(v)
+			if err != nil {
+				return nil, config, err
+			}
+			rules = append(rules, rule)
+		} else if e, ok := v.(RuleExporter); ok {
+			rule, err := e.ExportRule()
+			if err != nil {
+				return nil, config, err
+			}
+			rules = append(rules, rule)
+		} else {
+			rules = append(rules, v)
+		}
+		rmap.set(name, rules)
 	}
-	return json.MarshalIndent(rmap, "", "	")
+	// fields that aren't found, e.g. map keys, keep the order they were added in after the struct fields
+	t := reflect.TypeOf(r.structPtr).Elem()
+	indexes := make(map[string][]int, len(rmap.keys))
+	for _, name := range rmap.keys {
+		indexes[name] = fieldIndex(t, fields[name])
+	}
+	sort.SliceStable(rmap.keys, func(i, j int) bool {
+		a, b := indexes[rmap.keys[i]], indexes[rmap.keys[j]]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	if config.types {
+		for _, name := range rmap.keys {
+			rmap.values[name] = struct {
+				Type  string `json:"type,omitempty"`
+				Rules []any  `json:"rules"`
+			}{r.fieldType(fields[name]), rmap.values[name].([]any)}
+		}
+	}
+	return rmap, config, nil
+}
+
+// fieldIndex returns the index of the field in each struct along its path, or nil if the field isn't found
+func fieldIndex(t reflect.Type, field []string) []int {
+	index := make([]int, 0, len(field))
+	for i := range field {
+		sf, ok := structField(t, field[:i+1])
+		if !ok {
+			return nil
+		}
+		index = append(index, sf.Index[0])
+	}
+	return index
+}
+
+// orderedMap is encoded as a JSON object with the keys in the order they were added
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]any)}
+}
+
+// set the value of a key, adding the key to the end if it's new
+func (m *orderedMap) set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
 }
 
 // -------------------
@@ -223,7 +889,7 @@ func getField(structPtr any, fieldPtr any) []string {
 	if fv.Kind() != reflect.Ptr {
 		panic(errors.New("field is not pointer"))
 	}
-	fields := findStructField(value, fv, make([]*reflect.StructField, 0))
+	fields := findStructField(value, fv, make([]*reflect.StructField, 0), make(map[uintptr]bool))
 	if len(fields) == 0 {
 		panic(errors.New("can't find field"))
 	}
@@ -239,21 +905,25 @@ func getField(structPtr any, fieldPtr any) []string {
 	return parts
 }
 
-// findStructField looks for a field in the given struct.
+// findStructField looks for a field in the given struct, including fields of nested structs.
 // The field being looked for should be a pointer to the actual struct field.
 // If found, the fields will be returned. Otherwise, an empty list will be returned.
-func findStructField(structValue reflect.Value, fieldValue reflect.Value, results []*reflect.StructField) []*reflect.StructField {
+// Pointers that have already been visited are skipped so self-referencing types terminate.
+func findStructField(structValue reflect.Value, fieldValue reflect.Value, results []*reflect.StructField, visited map[uintptr]bool) []*reflect.StructField {
 	ptr := fieldValue.Pointer()
 	depth := len(results)
 	for i := structValue.NumField() - 1; i >= 0; i-- {
 		sf := structValue.Type().Field(i)
-		if ptr == structValue.Field(i).UnsafeAddr() {
-			if sf.Anonymous {
-				return findStructField(structValue.Field(i), fieldValue, append(results, &sf))
+		f := structValue.Field(i)
+		inner, isStruct := nestedStruct(f, visited)
+		if ptr == f.UnsafeAddr() {
+			// the first field of a struct has the same address as the struct so compare the type too
+			if isStruct && fieldValue.Type().Elem() != sf.Type {
+				return findStructField(inner, fieldValue, append(results, &sf), visited)
 			}
 			return append(results, &sf)
-		} else if sf.Anonymous {
-			tmp := findStructField(structValue.Field(i), fieldValue, append(results, &sf))
+		} else if isStruct {
+			tmp := findStructField(inner, fieldValue, append(results, &sf), visited)
 			if len(tmp) > depth+1 {
 				return tmp
 			}
@@ -262,8 +932,176 @@ func findStructField(structValue reflect.Value, fieldValue reflect.Value, result
 	return results
 }
 
+// embeddedStruct returns the struct of an embedded field, following pointers that haven't been visited
+func embeddedStruct(sf reflect.StructField, v reflect.Value, visited map[uintptr]bool) (reflect.Value, bool) {
+	if !sf.Anonymous {
+		return v, false
+	}
+	return nestedStruct(v, visited)
+}
+
+// nestedStruct returns the struct of a field, following pointers that haven't been visited
+func nestedStruct(v reflect.Value, visited map[uintptr]bool) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct || visited[v.Pointer()] {
+			return v, false
+		}
+		visited[v.Pointer()] = true
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+// complexity limits of a subject
+type complexity struct {
+	maxDepth    int
+	maxElements int
+	visited     map[uintptr]bool
+}
+
+// check walks the value and returns an error as soon as a limit is exceeded
+func (c complexity) check(v reflect.Value, field []string, depth int) Error {
+	newError := func() Error {
+		return &complexityError{validationError{
+			message: "The data is too complex to validate",
+			field:   field,
+		}}
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || c.visited[v.Pointer()] {
+			return nil
+		}
+		c.visited[v.Pointer()] = true
+		return c.check(v.Elem(), field, depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return c.check(v.Elem(), field, depth)
+	case reflect.Struct:
+		if c.maxDepth > 0 && depth > c.maxDepth {
+			return newError()
+		}
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			name := strings.Split(sf.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = sf.Name
+			}
+			path := append(append(make([]string, 0, len(field)+1), field...), name)
+			if err := c.check(v.Field(i), path, depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// bytes are data rather than structure
+			return nil
+		}
+		if c.maxDepth > 0 && depth > c.maxDepth {
+			return newError()
+		}
+		if c.maxElements > 0 && v.Len() > c.maxElements {
+			return newError()
+		}
+		if v.Kind() == reflect.Map {
+			iter := v.MapRange()
+			for iter.Next() {
+				if err := c.check(iter.Value(), field, depth+1); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := c.check(v.Index(i), field, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldValue finds the value of a field. Returns false if the field doesn't point to a value.
+func fieldValue(vmap map[string]any, field []string) (any, bool) {
+	v := vmap
+	for i, p := range field {
+		switch v2 := v[p].(type) {
+		default:
+			if i < len(field)-1 {
+				// field of a nested struct
+				return nestedValue(reflect.ValueOf(v2), field[i+1:])
+			}
+			return trimString(unwrapOptional(v2)), true
+		case map[string]any:
+			v = v2
+		}
+	}
+	return nil, false
+}
+
+// nestedValue finds the value of a field in a nested struct. Nil pointers along the way give a nil value.
+func nestedValue(v reflect.Value, field []string) (any, bool) {
+	for _, p := range field {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, true
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, false
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			name := strings.Split(sf.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = sf.Name
+			}
+			if name == p {
+				v, found = v.Field(i), true
+				break
+			}
+		}
+		if !found || !v.CanInterface() {
+			return nil, false
+		}
+	}
+	return trimString(unwrapOptional(v.Interface())), true
+}
+
+// trimString removes white space from strings if configured to
+func trimString(value any) any {
+	if s, ok := value.(string); ok && config.TrimStrings {
+		return strings.TrimSpace(s)
+	}
+	return value
+}
+
+// hasField returns true if the field exists in the map
+func hasField(vmap map[string]any, field []string) bool {
+	v := vmap
+	for _, p := range field {
+		value, ok := v[p]
+		if !ok {
+			return false
+		}
+		m, ok := value.(map[string]any)
+		if !ok {
+			return true
+		}
+		v = m
+	}
+	return true
+}
+
 // joinSentences converts a list of strings to a paragraph
 func joinSentences(list []string) string {
+	if config.JoinMessages != nil {
+		return config.JoinMessages(list)
+	}
 	l := len(list)
 	if l == 0 {
 		return ""
@@ -273,8 +1111,22 @@ func joinSentences(list []string) string {
 
 // structToMap converts struct to map and uses the json name if available
 func structToMap(structPtr any) map[string]any {
-	vmap := make(map[string]any)
 	structValue := reflect.ValueOf(structPtr)
+	visited := make(map[uintptr]bool)
+	if structValue.Kind() == reflect.Ptr {
+		if structValue.IsNil() {
+			return make(map[string]any)
+		}
+		visited[structValue.Pointer()] = true
+		structValue = structValue.Elem()
+	}
+	return structValueToMap(structValue, visited)
+}
+
+// structValueToMap converts struct to map. Embedded pointers that are already being converted are left empty so
+// self-referencing types terminate.
+func structValueToMap(structValue reflect.Value, visited map[uintptr]bool) map[string]any {
+	vmap := make(map[string]any)
 	for i := structValue.NumField() - 1; i >= 0; i-- {
 		sf := structValue.Type().Field(i)
 		name := strings.Split(sf.Tag.Get("json"), ",")[0]
@@ -282,17 +1134,129 @@ func structToMap(structPtr any) map[string]any {
 			name = sf.Name
 		}
 		f := structValue.Field(i)
-		if f.CanInterface() {
-			if sf.Anonymous {
-				vmap[name] = structToMap(f.Interface())
-			} else {
-				vmap[name] = f.Interface()
+		if !f.CanInterface() {
+			continue
+		}
+		if es, ok := embeddedStruct(sf, f, visited); ok {
+			vmap[name] = structValueToMap(es, visited)
+			if f.Kind() == reflect.Ptr {
+				delete(visited, f.Pointer())
 			}
+		} else if sf.Anonymous && f.Kind() == reflect.Ptr && f.Type().Elem().Kind() == reflect.Struct {
+			// nil or already visited
+			vmap[name] = make(map[string]any)
+		} else {
+			vmap[name] = f.Interface()
 		}
 	}
 	return vmap
 }
 
+// setLabel sets the label if the validator supports it
+func setLabel(validator Validator, label string) {
+	if l, ok := validator.(interface{ SetLabel(string) Validator }); ok {
+		l.SetLabel(label)
+	}
+}
+
+// fieldLabel returns the label if set, otherwise the last field name
+func fieldLabel(field []string, label string) string {
+	if label != "" {
+		return label
+	}
+	return jsonFieldName(field)
+}
+
+// setRule sets the rule name of errors that don't have one
+func setRule(errs ErrorSlice, rule string) ErrorSlice {
+	for _, err := range errs {
+		if r, ok := err.(interface{ setRule(string) }); ok {
+			r.setRule(rule)
+		}
+	}
+	return errs
+}
+
+// withMessage returns a copy of the error with another message. Errors can be shared, e.g. by Cached, so they are
+// never changed in place. False is returned for errors that can't be copied.
+func withMessage(err Error, message string) (Error, bool) {
+	e, ok := err.(*validationError)
+	if !ok {
+		return err, false
+	}
+	clone := *e
+	clone.message = message
+	return &clone, true
+}
+
+// withField returns a copy of the error with another field. The type of the error is kept so that errors.Is still
+// matches, e.g. ErrInternal. Errors of other types are recreated with the same message and rule.
+func withField(err Error, field []string) Error {
+	switch e := err.(type) {
+	case *validationError:
+		clone := *e
+		clone.field = field
+		return &clone
+	case *internalError:
+		clone := *e
+		clone.field = field
+		return &clone
+	case *timeoutError:
+		clone := *e
+		clone.field = field
+		return &clone
+	case *complexityError:
+		clone := *e
+		clone.field = field
+		return &clone
+	}
+	nested := NewError(err.Error(), field...)
+	if r, ok := err.(interface{ Rule() string }); ok {
+		setRule(ErrorSlice{nested}, r.Rule())
+	}
+	return nested
+}
+
+// nestErrors prefixes the fields of errors from nested rules with the parent field, keeping the rule names
+func nestErrors(parent []string, errs ErrorSlice) ErrorSlice {
+	nested := make(ErrorSlice, len(errs))
+	for i, err := range errs {
+		field := append(append(make([]string, 0, len(parent)+len(err.Field())), parent...), err.Field()...)
+		nested[i] = withField(err, field)
+	}
+	return nested
+}
+
+// namedRule is implemented by validators whose type name doesn't give the rule name, e.g. acronyms
+type namedRule interface {
+	ruleName() string
+}
+
+// ruleName is the name of the validator type without the Validator suffix, e.g. "minLength" for MinLengthValidator,
+// unless the validator names its rule
+func ruleName(validator Validator) string {
+	if n, ok := validator.(namedRule); ok {
+		return n.ruleName()
+	}
+	t := reflect.TypeOf(validator)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := strings.TrimSuffix(strings.SplitN(t.Name(), "[", 2)[0], "Validator")
+	if name == "" {
+		return ""
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// dataName returns the last field name or a generic name for struct validators
+func dataName(field []string) string {
+	if name := jsonFieldName(field); name != "" {
+		return name
+	}
+	return "the data"
+}
+
 // jsonFieldName returns the last field name
 func jsonFieldName(field []string) string {
 	if field == nil {
@@ -300,3 +1264,57 @@ func jsonFieldName(field []string) string {
 	}
 	return field[len(field)-1]
 }
+
+// errorPath returns the last field name, or the path from the first collection if the field contains indexes,
+// e.g. "items[3].name" for ["items", "3", "name"]
+func errorPath(field []string) string {
+	first := -1
+	for i, p := range field {
+		if isIndex(p) {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return jsonFieldName(field)
+	}
+	var b strings.Builder
+	if first > 0 {
+		b.WriteString(field[first-1])
+	}
+	for _, p := range field[first:] {
+		if isIndex(p) {
+			b.WriteString("[" + p + "]")
+		} else {
+			b.WriteString("." + p)
+		}
+	}
+	return b.String()
+}
+
+// parseErrorPath splits a path returned by errorPath into its parts, e.g. ["items", "3", "name"] for "items[3].name"
+func parseErrorPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	field := make([]string, 0)
+	for _, p := range strings.Split(strings.ReplaceAll(path, "[", ".["), ".") {
+		if p != "" {
+			field = append(field, strings.TrimSuffix(strings.TrimPrefix(p, "["), "]"))
+		}
+	}
+	return field
+}
+
+// isIndex returns true if the field segment is a collection index
+func isIndex(p string) bool {
+	if p == "" {
+		return false
+	}
+	for _, c := range p {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package xvalid
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/net/html"
+)
+
+// HTMLPolicy decides which elements and attributes are allowed in HTML. Event handler attributes and URLs with
+// schemes other than http, https and mailto are always rejected.
+type HTMLPolicy interface {
+	AllowElement(tag string) bool
+	AllowAttribute(tag string, attr string) bool
+}
+
+// HTMLAllowList is an HTMLPolicy that allows the elements in the map with the attributes listed for each
+type HTMLAllowList map[string][]string
+
+// AllowElement returns true if the element is in the list
+func (l HTMLAllowList) AllowElement(tag string) bool {
+	_, ok := l[tag]
+	return ok
+}
+
+// AllowAttribute returns true if the attribute is listed for the element
+func (l HTMLAllowList) AllowAttribute(tag string, attr string) bool {
+	return slices.Contains(l[tag], attr)
+}
+
+// RichTextPolicy allows the formatting most rich text editors produce
+var RichTextPolicy = HTMLAllowList{
+	"p": nil, "br": nil, "b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil, "sub": nil, "sup": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil, "blockquote": nil, "code": nil, "pre": nil,
+	"ul": nil, "ol": nil, "li": nil, "a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+}
+
+// urlAttributes hold URLs that are checked for unsafe schemes
+var urlAttributes = []string{"href", "src", "action", "formaction", "cite", "poster", "background"}
+
+// SafeHTMLValidator field must only contain HTML allowed by the policy
+type SafeHTMLValidator struct {
+	field   []string
+	message string
+	label   string
+	policy  HTMLPolicy
+}
+
+// Field of the field
+func (c *SafeHTMLValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *SafeHTMLValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *SafeHTMLValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *SafeHTMLValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value
+func (c *SafeHTMLValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, true) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please remove unsupported formatting from %s", fieldLabel(c.field, c.label)))
+	}
+	if str == "" {
+		return nil
+	}
+	if reason := c.check(str); reason != "" {
+		return createError(c.field, c.message, fmt.Sprintf("Please remove %s from %s", reason, fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+// check returns what isn't allowed in the HTML, or an empty string if it is safe
+func (c *SafeHTMLValidator) check(str string) string {
+	z := html.NewTokenizer(strings.NewReader(str))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			if !c.policy.AllowElement(t.Data) {
+				return fmt.Sprintf("the <%s> tag", t.Data)
+			}
+			for _, a := range t.Attr {
+				if strings.HasPrefix(a.Key, "on") || !c.policy.AllowAttribute(t.Data, a.Key) {
+					return fmt.Sprintf("the %s attribute", a.Key)
+				}
+				if slices.Contains(urlAttributes, a.Key) && !isSafeURL(a.Val) {
+					return fmt.Sprintf("the %s link", a.Key)
+				}
+			}
+		case html.EndTagToken:
+			if t := z.Token(); !c.policy.AllowElement(t.Data) {
+				return fmt.Sprintf("the <%s> tag", t.Data)
+			}
+		}
+	}
+}
+
+// isSafeURL returns true for relative URLs and http, https and mailto URLs
+func isSafeURL(raw string) bool {
+	// browsers ignore control characters and white space in schemes, e.g. "java\tscript:"
+	raw = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || slices.Contains([]string{"http", "https", "mailto"}, strings.ToLower(u.Scheme))
+}
+
+// CanExport for this validator
+func (c *SafeHTMLValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *SafeHTMLValidator) Params() map[string]any {
+	return map[string]any{"policy": c.policy}
+}
+
+// SafeHTML field must only contain the elements and attributes allowed by the policy, e.g. RichTextPolicy. It rejects
+// rather than cleans, so use a sanitizer such as bluemonday if the HTML should be cleaned instead.
+func SafeHTML(policy HTMLPolicy) *SafeHTMLValidator {
+	return &SafeHTMLValidator{
+		policy: policy,
+	}
+}
@@ -0,0 +1,37 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeHTML(t *testing.T) {
+	type article struct {
+		Body string `json:"body"`
+	}
+	a := article{}
+	rules := New(&a).Field(&a.Body, SafeHTML(RichTextPolicy))
+	assert.Nil(t, rules.Validate(article{Body: `<p>Hello <b>world</b> <a href="https://example.com" title="x">link</a></p>`}), "Allowed")
+	assert.Nil(t, rules.Validate(article{Body: `plain text & <br/>`}), "Plain text")
+	assert.Nil(t, rules.Validate(article{Body: `<a href="/about">relative</a>`}), "Relative link")
+
+	for html, msg := range map[string]string{
+		`<script>alert(1)</script>`:                "Please remove the <script> tag from body",
+		`<p onclick="alert(1)">x</p>`:              "Please remove the onclick attribute from body",
+		`<p style="color:red">x</p>`:               "Please remove the style attribute from body",
+		`<a href="javascript:alert(1)">x</a>`:      "Please remove the href link from body",
+		`<a href="java&#09;script:alert(1)">x</a>`: "Please remove the href link from body",
+		`<img src="data:image/png;base64,AA">`:     "Please remove the src link from body",
+		`</iframe>`:                                "Please remove the <iframe> tag from body",
+	} {
+		errs := rules.Validate(article{Body: html}).(ErrorSlice)
+		assert.Equal(t, msg, errs[0].Error(), html)
+	}
+
+	custom := HTMLAllowList{"span": {"class"}}
+	rules = New(&a).Field(&a.Body, SafeHTML(custom))
+	assert.Nil(t, rules.Validate(article{Body: `<span class="x">x</span>`}), "Custom policy")
+	assert.Len(t, rules.Validate(article{Body: `<p>x</p>`}), 1, "Custom policy rejects")
+	assert.False(t, SafeHTML(custom).CanExport(), "Not exportable")
+}
@@ -0,0 +1,123 @@
+package xvalid
+
+import (
+	"context"
+
+	"golang.org/x/exp/slices"
+)
+
+// scenarioKey of the context value set by WithScenario
+type scenarioKey struct{}
+
+// WithScenario returns a context that makes ValidateCtx run the validators of the scenario, e.g. "create" or "update"
+func WithScenario(ctx context.Context, scenario string) context.Context {
+	return context.WithValue(ctx, scenarioKey{}, scenario)
+}
+
+// ValidateScenario validates the subject with the validators that aren't limited to scenarios and the ones limited
+// to the scenario, so one set of rules can serve both creating and updating a record
+func (r Rules) ValidateScenario(scenario string, subject any) error {
+	return r.ValidateCtx(WithScenario(context.Background(), scenario), subject)
+}
+
+// ScenarioValidator limits validators to scenarios given to ValidateScenario
+type ScenarioValidator struct {
+	field      []string
+	scenarios  []string
+	validators []Validator
+}
+
+// Field of the field
+func (c *ScenarioValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ScenarioValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.validators {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators
+func (c *ScenarioValidator) SetMessage(msg string) Validator {
+	for _, v := range c.validators {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ScenarioValidator) SetLabel(label string) Validator {
+	for _, v := range c.validators {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Scenario adds another scenario the validators run in
+func (c *ScenarioValidator) Scenario(scenario string) *ScenarioValidator {
+	c.scenarios = append(c.scenarios, scenario)
+	return c
+}
+
+// Scenarios the validators are limited to
+func (c *ScenarioValidator) Scenarios() []string {
+	return c.scenarios
+}
+
+// Validate does nothing since there is no scenario without ValidateScenario
+func (c *ScenarioValidator) Validate(value any) Error {
+	return nil
+}
+
+func (c *ScenarioValidator) resolveFields(structPtr any) {
+	for _, v := range c.validators {
+		if fv, ok := v.(fieldsValidator); ok {
+			fv.resolveFields(structPtr)
+		}
+	}
+}
+
+func (c *ScenarioValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *ScenarioValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	scenario, ok := ctx.Value(scenarioKey{}).(string)
+	if !ok || !slices.Contains(c.scenarios, scenario) {
+		return make(ErrorSlice, 0)
+	}
+	return validateAll(ctx, c.validators, c.field, vmap)
+}
+
+func (c *ScenarioValidator) clone() Validator {
+	clone := *c
+	clone.scenarios = append([]string{}, c.scenarios...)
+	clone.validators = make([]Validator, len(c.validators))
+	for i, v := range c.validators {
+		clone.validators[i] = cloneValidator(v)
+	}
+	return &clone
+}
+
+// CanExport for this validator. The scenario is only known on the server.
+func (c *ScenarioValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *ScenarioValidator) Params() map[string]any {
+	return map[string]any{"scenarios": c.scenarios, "validators": c.validators}
+}
+
+// Scenario limits the validators to a scenario, e.g. requiring a password only when creating a user. They only run
+// with ValidateScenario, or ValidateCtx given a context from WithScenario, so Validate skips them. Add more scenarios
+// with the Scenario method.
+func Scenario(scenario string, validators ...Validator) *ScenarioValidator {
+	return &ScenarioValidator{
+		scenarios:  []string{scenario},
+		validators: validators,
+	}
+}
@@ -0,0 +1,36 @@
+package xvalid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScenario(t *testing.T) {
+	type user struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+	u := user{}
+	rules := New(&u).
+		Field(&u.ID, Scenario("create", Max(0).SetMessage("Please leave out the id")), Scenario("update", Required())).
+		Field(&u.Name, Required()).
+		Field(&u.Password, Scenario("create", Required()).Scenario("reset"), MaxLength(64))
+
+	errs := rules.ValidateScenario("create", user{ID: 5}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Create")
+	assert.Equal(t, "Please leave out the id", errs[0].Error(), "ID forbidden")
+	assert.Equal(t, []string{"password"}, errs[2].Field(), "Password required")
+	assert.Equal(t, "required", errs[2].(interface{ Rule() string }).Rule(), "Rule of the validator")
+
+	errs = rules.ValidateScenario("update", user{Name: "a"}).(ErrorSlice)
+	assert.Len(t, errs, 1, "Update")
+	assert.Equal(t, []string{"id"}, errs[0].Field(), "ID required")
+	assert.Nil(t, rules.ValidateScenario("update", user{ID: 5, Name: "a"}), "Password optional")
+	assert.Len(t, rules.ValidateScenario("reset", user{Name: "a"}), 1, "Added scenario")
+	assert.Nil(t, rules.Validate(user{Name: "a"}), "No scenario")
+	assert.Len(t, rules.ValidateCtx(WithScenario(context.Background(), "update"), user{}), 2, "Context")
+	assert.False(t, Scenario("create", Required()).CanExport(), "Not exportable")
+}
@@ -0,0 +1,52 @@
+package xvalid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sensitive marks fields such as passwords, tokens and personal data, so their values are never shown. Error messages
+// of the fields that contain the value are replaced with a generic message, and Explain and Audit only describe the
+// value by its type and size. Explain also redacts the subject of struct validators once any field is sensitive.
+func (r Rules) Sensitive(fieldPtrs ...any) Rules {
+	sensitive := make(map[string]bool, len(r.sensitive)+len(fieldPtrs))
+	for k, v := range r.sensitive {
+		sensitive[k] = v
+	}
+	for _, fieldPtr := range fieldPtrs {
+		sensitive[strings.Join(getField(r.structPtr, fieldPtr), ".")] = true
+	}
+	r.sensitive = sensitive
+	return r
+}
+
+// isSensitive returns true if the field or the field it is nested in is sensitive
+func (r Rules) isSensitive(field []string) bool {
+	for i := len(field); i > 0; i-- {
+		if r.sensitive[strings.Join(field[:i], ".")] {
+			return true
+		}
+	}
+	return false
+}
+
+// redactErrors replaces the messages of a sensitive field that contain its value
+func (r Rules) redactErrors(validator Validator, vmap map[string]any, errs ErrorSlice) ErrorSlice {
+	field := validator.Field()
+	if len(errs) == 0 || !r.isSensitive(field) {
+		return errs
+	}
+	v, _ := fieldValue(vmap, field)
+	value := fmt.Sprint(v)
+	if v == nil || value == "" {
+		return errs
+	}
+	redacted := make(ErrorSlice, len(errs))
+	for i, err := range errs {
+		redacted[i] = err
+		if strings.Contains(err.Error(), value) {
+			redacted[i], _ = withMessage(err, fmt.Sprintf("Please correct %s", fieldLabel(field, r.labels[strings.Join(field, ".")])))
+		}
+	}
+	return redacted
+}
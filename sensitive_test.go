@@ -0,0 +1,49 @@
+package xvalid
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensitive(t *testing.T) {
+	type signup struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	s := signup{}
+	leak := FieldFunc(func(field []string, value any) Error {
+		return NewError(fmt.Sprintf("%v is too common", value), field...)
+	})
+	rules := New(&s).
+		Field(&s.Email, FieldFunc(func(field []string, value any) Error {
+			return NewError(fmt.Sprintf("%v is taken", value), field...)
+		})).
+		Field(&s.Password, MinLength(20), leak).
+		Label(&s.Password, "Password").
+		Sensitive(&s.Password)
+
+	subject := signup{Email: "a@b.com", Password: "hunter2"}
+	errs := rules.Validate(subject).(ErrorSlice)
+	assert.Equal(t, "a@b.com is taken", errs[0].Error(), "Field that isn't sensitive")
+	assert.Equal(t, "Please lengthen Password to 20 characters or more", errs[1].Error(), "Message without value kept")
+	assert.Equal(t, "Please correct Password", errs[2].Error(), "Message with value replaced")
+
+	cached := Cached(leak, time.Minute)
+	rules = New(&s).Field(&s.Password, cached).Sensitive(&s.Password)
+	rules.Validate(subject)
+	assert.Equal(t, "hunter2 is too common", cached.Validate("hunter2").Error(), "Cached error not changed")
+
+	for _, e := range rules.Explain(subject) {
+		assert.NotContains(t, fmt.Sprint(e.Value), "hunter2", "Explain value redacted")
+		for _, err := range e.Errors {
+			assert.NotContains(t, err.Error(), "hunter2", "Explain error redacted")
+		}
+	}
+	explanations := New(&s).Field(&s.Password, MinLength(1)).Sensitive(&s.Password).
+		Struct(StructFunc(func(any) Error { return nil })).Explain(subject)
+	assert.Equal(t, "string(7)", explanations[0].Value, "Field value described")
+	assert.Equal(t, "xvalid.signup", explanations[1].Value, "Struct value described")
+}
@@ -0,0 +1,19 @@
+package xvalid
+
+import (
+	"strings"
+)
+
+// ShortCircuit skips the remaining validators of a field once its Required validator fails, so an empty field only
+// reports that it's required
+func (r Rules) ShortCircuit() Rules {
+	r.shortCircuit = true
+	return r
+}
+
+// stop marks the field as missing if a Required validator failed in short circuit mode
+func (r Rules) stop(validator Validator, errs ErrorSlice, missing map[string]bool) {
+	if _, ok := validator.(*RequiredValidator); ok && r.shortCircuit && len(errs) > 0 {
+		missing[strings.Join(validator.Field(), ".")] = true
+	}
+}
@@ -0,0 +1,30 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortCircuit(t *testing.T) {
+	type user struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	u := user{}
+	rules := New(&u).
+		Field(&u.Name, MinLength(3), Required(), Pattern("^[a-z]+$")).
+		Field(&u.Email, Required(), Email())
+	assert.Len(t, rules.Validate(user{}), 5, "All errors by default")
+
+	errs := rules.ShortCircuit().Validate(user{}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Rules after Required skipped")
+	assert.Equal(t, "minLength", errs[0].(interface{ Rule() string }).Rule(), "Rules before Required run")
+	assert.Equal(t, "required", errs[1].(interface{ Rule() string }).Rule(), "Required reported")
+	assert.Equal(t, "required", errs[2].(interface{ Rule() string }).Rule(), "Only Required for other field")
+	errs = rules.ShortCircuit().Validate(user{Name: "AB", Email: "x"}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Rules run when Required passes")
+
+	explanations := rules.ShortCircuit().Explain(user{})
+	assert.False(t, explanations[2].Evaluated, "Skipped rule not evaluated")
+}
@@ -0,0 +1,179 @@
+package xvalid
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SQLColumn is a column definition with the constraints that can be derived from the validators of a field
+type SQLColumn struct {
+	Name    string
+	Type    string
+	NotNull bool
+	Checks  []string
+}
+
+// String returns the column definition as used in CREATE TABLE
+func (c SQLColumn) String() string {
+	var b strings.Builder
+	b.WriteString(quoteSQLName(c.Name))
+	if c.Type != "" {
+		b.WriteString(" " + c.Type)
+	}
+	if c.NotNull {
+		b.WriteString(" NOT NULL")
+	}
+	for _, check := range c.Checks {
+		b.WriteString(" CHECK (" + check + ")")
+	}
+	return b.String()
+}
+
+// SQLColumns describes each field of the rules as a PostgreSQL column, so the database enforces the same limits as
+// the application. Required fields are NOT NULL and strings with a MaxLength are VARCHAR. Lengths, values, options
+// and patterns become CHECK constraints. Fields are in the order they were added, and struct validators and custom
+// validators are left out.
+func (r Rules) SQLColumns() []SQLColumn {
+	columns := make([]SQLColumn, 0)
+	index := make(map[string]int)
+	for _, v := range r.validators {
+		if len(v.Field()) == 0 {
+			continue
+		}
+		name := jsonFieldName(v.Field())
+		i, ok := index[name]
+		if !ok {
+			i = len(columns)
+			index[name] = i
+			columns = append(columns, SQLColumn{Name: name, Type: r.sqlType(v.Field())})
+		}
+		c := &columns[i]
+		col := quoteSQLName(name)
+		switch v := v.(type) {
+		case *RequiredValidator:
+			c.NotNull = true
+			if c.Type == "TEXT" || strings.HasPrefix(c.Type, "VARCHAR") {
+				c.Checks = append(c.Checks, col+" <> ''")
+			}
+		case *MinLengthValidator:
+			c.Checks = append(c.Checks, sqlOptional(fmt.Sprintf("CHAR_LENGTH(%s) >= %d", sqlLength(col, v.trimmed), v.min), col, "''", v.optional))
+		case *MaxLengthValidator:
+			if c.Type == "TEXT" && !v.trimmed {
+				c.Type = fmt.Sprintf("VARCHAR(%d)", v.max)
+			} else {
+				c.Checks = append(c.Checks, fmt.Sprintf("CHAR_LENGTH(%s) <= %d", sqlLength(col, v.trimmed), v.max))
+			}
+		case *MinBytesValidator:
+			c.Checks = append(c.Checks, fmt.Sprintf("OCTET_LENGTH(%s) >= %d", col, v.min))
+		case *MaxBytesValidator:
+			c.Checks = append(c.Checks, fmt.Sprintf("OCTET_LENGTH(%s) <= %d", col, v.max))
+		case *MinValidator:
+			op := ">="
+			if v.exclusive {
+				op = ">"
+			}
+			c.Checks = append(c.Checks, sqlOptional(fmt.Sprintf("%s %s %d", col, op, v.min), col, "0", v.optional))
+		case *MaxValidator:
+			op := "<="
+			if v.exclusive {
+				op = "<"
+			}
+			c.Checks = append(c.Checks, fmt.Sprintf("%s %s %d", col, op, v.max))
+		case *PatternValidator:
+			c.Checks = append(c.Checks, sqlOptional(fmt.Sprintf("%s ~ %s", col, quoteSQLValue(v.re.String())), col, "''", v.optional))
+		case *OptionsValidator:
+			c.Checks = append(c.Checks, sqlIn(col, v.getOptions()))
+		case interface{ optionValues() []any }:
+			c.Checks = append(c.Checks, sqlIn(col, v.optionValues()))
+		}
+	}
+	return columns
+}
+
+// SQLTable returns a CREATE TABLE statement with the columns from SQLColumns, meant as a starting point for a migration
+func (r Rules) SQLTable(table string) string {
+	columns := r.SQLColumns()
+	lines := make([]string, len(columns))
+	for i, c := range columns {
+		lines[i] = "\t" + c.String()
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);\n", quoteSQLName(table), strings.Join(lines, ",\n"))
+}
+
+// sqlType returns the PostgreSQL type of a field
+func (r Rules) sqlType(field []string) string {
+	f, ok := settableField(reflect.New(reflect.TypeOf(r.structPtr).Elem()).Elem(), field)
+	if !ok {
+		return ""
+	}
+	t := f.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return "TIMESTAMPTZ"
+	case reflect.TypeOf(time.Duration(0)):
+		return "BIGINT"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "TEXT"
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "SMALLINT"
+	case reflect.Int32, reflect.Uint16:
+		return "INTEGER"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "BIGINT"
+	case reflect.Float32:
+		return "REAL"
+	case reflect.Float64:
+		return "DOUBLE PRECISION"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BYTEA"
+		}
+	}
+	return "JSONB"
+}
+
+// sqlLength returns the column to count the length of, trimmed if the validator counts without surrounding space
+func sqlLength(col string, trimmed bool) string {
+	if trimmed {
+		return "TRIM(" + col + ")"
+	}
+	return col
+}
+
+// sqlOptional allows the zero value in the check if the validator is optional
+func sqlOptional(check string, col string, zero string, optional bool) string {
+	if optional {
+		return fmt.Sprintf("%s = %s OR %s", col, zero, check)
+	}
+	return check
+}
+
+// sqlIn returns a check that the column is one of the values
+func sqlIn(col string, values []any) string {
+	list := make([]string, len(values))
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			list[i] = quoteSQLValue(s)
+		} else {
+			list[i] = fmt.Sprint(v)
+		}
+	}
+	return fmt.Sprintf("%s IN (%s)", col, strings.Join(list, ", "))
+}
+
+func quoteSQLName(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteSQLValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
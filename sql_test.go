@@ -0,0 +1,43 @@
+package xvalid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLTable(t *testing.T) {
+	type account struct {
+		Name    string    `json:"name"`
+		Bio     string    `json:"bio"`
+		Age     int32     `json:"age"`
+		Plan    string    `json:"plan"`
+		Score   float64   `json:"score"`
+		Created time.Time `json:"created"`
+		Tags    []string  `json:"tags"`
+	}
+	a := account{}
+	rules := New(&a).
+		Field(&a.Name, Required(), MinLength(2), MaxLength(50), Pattern(`^[a-z']+$`)).
+		Field(&a.Bio, MaxLength(200).Trimmed()).
+		Field(&a.Age, Min(0), Max(150).Exclusive()).
+		Field(&a.Plan, Options("free", "pro"), MinLength(3).SetOptional()).
+		Field(&a.Score, FieldFunc(func([]string, any) Error { return nil })).
+		Field(&a.Created, Required()).
+		Field(&a.Tags, MaxItems(5)).
+		Struct(StructFunc(func(any) Error { return nil }))
+	assert.Equal(t, `CREATE TABLE "accounts" (
+	"name" VARCHAR(50) NOT NULL CHECK ("name" <> '') CHECK (CHAR_LENGTH("name") >= 2) CHECK ("name" ~ '^[a-z'']+$'),
+	"bio" TEXT CHECK (CHAR_LENGTH(TRIM("bio")) <= 200),
+	"age" INTEGER CHECK ("age" >= 0) CHECK ("age" < 150),
+	"plan" TEXT CHECK ("plan" IN ('free', 'pro')) CHECK ("plan" = '' OR CHAR_LENGTH("plan") >= 3),
+	"score" DOUBLE PRECISION,
+	"created" TIMESTAMPTZ NOT NULL,
+	"tags" JSONB
+);
+`, rules.SQLTable("accounts"), "Create table")
+
+	columns := rules.SQLColumns()
+	assert.Equal(t, SQLColumn{Name: "created", Type: "TIMESTAMPTZ", NotNull: true}, columns[5], "Column")
+}
@@ -0,0 +1,182 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// tagPatterns are the tags of go-playground/validator that are checked with a pattern
+var tagPatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+	"numeric":  "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"e164":     "^\\+[1-9]?[0-9]{7,14}$",
+	"uuid":     "^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$",
+}
+
+// LoadTags adds rules from the validate tags of go-playground/validator, e.g. `validate:"required,min=3,max=32"`, so
+// structs that already use those tags can move over without writing the rules again. It works like Load, so fields
+// with tags replace the rules they have. Fields of nested structs are included. The supported tags are required,
+// omitempty, min, max, len, gt, gte, lt, lte, oneof, email, url, http_url, alpha, alphanum, numeric, e164 and uuid.
+// Like go-playground/validator, min, max and len check the length of strings, the number of items of slices and maps,
+// and the value of numbers. Other tags, including dive and "|", return an error.
+func (r Rules) LoadTags() (Rules, error) {
+	fields := make(map[string][]map[string]any)
+	if err := tagRules(reflect.TypeOf(r.structPtr), nil, fields, make(map[reflect.Type]bool)); err != nil {
+		return r, err
+	}
+	if len(fields) == 0 {
+		return r, nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return r, err
+	}
+	return r.Load(data)
+}
+
+// tagRules converts the validate tags of a struct and its nested structs to exported rules by the dotted path of JSON
+// names, so fields of different nested structs with the same name keep their own tags
+func tagRules(t reflect.Type, path []string, fields map[string][]map[string]any, visited map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return nil
+	}
+	visited[t] = true
+	defer delete(visited, t)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := strings.Split(sf.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = sf.Name
+		}
+		p := append(append([]string{}, path...), name)
+		if tag := sf.Tag.Get("validate"); tag != "" && tag != "-" {
+			rules, err := tagRule(tag, sf.Type)
+			if err != nil {
+				return fmt.Errorf("%s: %w", strings.Join(p, "."), err)
+			}
+			fields[strings.Join(p, ".")] = rules
+		}
+		if err := tagRules(sf.Type, p, fields, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tagRule converts a validate tag to exported rules for a field of the type
+func tagRule(tag string, t reflect.Type) ([]map[string]any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	kind := "number"
+	switch t.Kind() {
+	case reflect.String:
+		kind = "length"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		kind = "items"
+	}
+	bounds := map[string][2]string{"length": {"minLength", "maxLength"}, "items": {"minItems", "maxItems"},
+		"number": {"min", "max"}}[kind]
+	rules := make([]map[string]any, 0)
+	optional := false
+	for _, part := range strings.Split(tag, ",") {
+		if strings.Contains(part, "|") {
+			return nil, fmt.Errorf("tag not supported: %s", part)
+		}
+		name, param, _ := strings.Cut(part, "=")
+		var n int64
+		if name == "min" || name == "max" || name == "len" || name == "gt" || name == "gte" || name == "lt" || name == "lte" {
+			var err error
+			if n, err = strconv.ParseInt(param, 10, 64); err != nil {
+				return nil, fmt.Errorf("%s needs a whole number: %s", name, param)
+			}
+		}
+		var added []map[string]any
+		switch name {
+		case "required":
+			added = append(added, map[string]any{"rule": "required"})
+		case "omitempty":
+			optional = true
+		case "min", "gte":
+			added = append(added, map[string]any{"rule": bounds[0], "min": n})
+		case "max", "lte":
+			added = append(added, map[string]any{"rule": bounds[1], "max": n})
+		case "len":
+			added = append(added, map[string]any{"rule": bounds[0], "min": n}, map[string]any{"rule": bounds[1], "max": n})
+		case "gt":
+			if kind == "number" {
+				added = append(added, map[string]any{"rule": "min", "min": n, "exclusive": true})
+			} else {
+				added = append(added, map[string]any{"rule": bounds[0], "min": n + 1})
+			}
+		case "lt":
+			if kind == "number" {
+				added = append(added, map[string]any{"rule": "max", "max": n, "exclusive": true})
+			} else {
+				added = append(added, map[string]any{"rule": bounds[1], "max": n - 1})
+			}
+		case "oneof":
+			options, err := tagOptions(param, kind == "number")
+			if err != nil {
+				return nil, err
+			}
+			added = append(added, map[string]any{"rule": "options", "options": options})
+		case "email":
+			added = append(added, map[string]any{"rule": "type", "type": "email"})
+		case "url":
+			added = append(added, map[string]any{"rule": "url"})
+		case "http_url":
+			added = append(added, map[string]any{"rule": "url", "schemes": []string{"http", "https"}})
+		default:
+			pattern, ok := tagPatterns[name]
+			if !ok {
+				return nil, fmt.Errorf("tag not supported: %s", name)
+			}
+			added = append(added, map[string]any{"rule": "pattern", "pattern": pattern})
+		}
+		rules = append(rules, added...)
+	}
+	if optional {
+		for _, rule := range rules {
+			rule["optional"] = true
+		}
+	}
+	return rules, nil
+}
+
+// tagOptions splits the options of oneof on spaces. Options with spaces are wrapped in single quotes.
+func tagOptions(param string, numbers bool) ([]any, error) {
+	options := make([]any, 0)
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		var option string
+		if strings.HasPrefix(param, "'") {
+			end := strings.Index(param[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed quote: %s", param)
+			}
+			option, param = param[1:end+1], param[end+2:]
+		} else {
+			option, param, _ = strings.Cut(param, " ")
+		}
+		if !numbers {
+			options = append(options, option)
+			continue
+		}
+		f, err := strconv.ParseFloat(option, 64)
+		if err != nil {
+			return nil, fmt.Errorf("oneof needs numbers: %s", option)
+		}
+		options = append(options, f)
+	}
+	return options, nil
+}
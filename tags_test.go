@@ -0,0 +1,71 @@
+package xvalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTags(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validate:"required,alpha"`
+	}
+	type signup struct {
+		Name    string   `json:"name" validate:"required,min=3,max=32"`
+		Email   string   `json:"email" validate:"omitempty,email"`
+		Plan    string   `json:"plan" validate:"oneof=free pro 'pro plus'"`
+		Age     int      `json:"age" validate:"gte=18,lt=130"`
+		Level   int      `json:"level" validate:"oneof=1 2 3"`
+		Tags    []string `json:"tags" validate:"len=2"`
+		Skipped string   `json:"skipped" validate:"-"`
+		Address Address  `json:"address"`
+	}
+	s := signup{}
+	rules, err := New(&s).LoadTags()
+	assert.Nil(t, err, "Load tags")
+	j, _ := rules.Export(ExcludeFields("email"))
+	assert.JSONEq(t, `{"age":[{"rule":"min","min":18},{"rule":"max","max":130,"exclusive":true}],`+
+		`"city":[{"rule":"required"},{"rule":"pattern","pattern":"^[a-zA-Z]+$"}],`+
+		`"level":[{"rule":"options","options":[1,2,3]}],`+
+		`"name":[{"rule":"required"},{"rule":"minLength","min":3},{"rule":"maxLength","max":32}],`+
+		`"plan":[{"rule":"options","options":["free","pro","pro plus"]}],`+
+		`"tags":[{"rule":"minItems","min":2},{"rule":"maxItems","max":2}]}`,
+		string(j), "Equivalent rules")
+	email := rules.Validators()[4].(*EmailValidator)
+	assert.Equal(t, []string{"email"}, email.Field(), "Email field")
+	assert.True(t, email.optional, "Omit empty")
+
+	valid := signup{Name: "bob", Plan: "pro plus", Age: 18, Level: 2, Tags: []string{"a", "b"}, Address: Address{"Paris"}}
+	assert.Nil(t, rules.Validate(valid), "Valid")
+	invalid := signup{Name: "bo", Email: "x", Plan: "team", Age: 130, Level: 4, Tags: []string{"a"}, Address: Address{"P4ris"}}
+	errs := rules.Validate(invalid).(ErrorSlice)
+	assert.Len(t, errs, 7, "Invalid")
+	assert.Equal(t, []string{"address", "city"}, errs[0].Field(), "Nested field")
+
+	type order struct {
+		Billing  Address `json:"billing"`
+		Shipping struct {
+			City string `json:"city" validate:"max=3"`
+		} `json:"shipping"`
+	}
+	o := order{}
+	rules, err = New(&o).LoadTags()
+	assert.Nil(t, err, "Same name in nested structs")
+	o.Billing.City, o.Shipping.City = "Paris", "Rome"
+	errs = rules.Validate(o).(ErrorSlice)
+	assert.Len(t, errs, 1, "Each field keeps its tags")
+	assert.Equal(t, []string{"shipping", "city"}, errs[0].Field(), "Tags of the second field")
+
+	_, err = New(&struct {
+		Items []string `json:"items" validate:"dive,required"`
+	}{}).LoadTags()
+	assert.EqualError(t, err, "items: tag not supported: dive", "Unsupported tag")
+	_, err = New(&struct {
+		Color string `json:"color" validate:"hexcolor|rgb"`
+	}{}).LoadTags()
+	assert.EqualError(t, err, "color: tag not supported: hexcolor|rgb", "Or operator")
+	_, err = New(&struct {
+		Name string `json:"name" validate:"min=x"`
+	}{}).LoadTags()
+	assert.EqualError(t, err, "name: min needs a whole number: x", "Invalid number")
+}
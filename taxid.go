@@ -0,0 +1,434 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+var (
+	taxIDs      = make(map[string]func(id string) bool)
+	taxIDsMutex sync.RWMutex
+	taxIDClean  = strings.NewReplacer(" ", "", "-", "", ".", "", "/", "")
+)
+
+// RegisterTaxID adds or replaces the check of a kind of tax ID, so TaxID(kind) can validate it. The check gets the ID
+// in upper case without spaces, hyphens, dots and slashes. VAT numbers are registered as "VAT:" followed by the
+// country code, e.g. "VAT:DE". It should be called on startup.
+func RegisterTaxID(kind string, check func(id string) bool) {
+	taxIDsMutex.Lock()
+	defer taxIDsMutex.Unlock()
+	taxIDs[kind] = check
+}
+
+func taxIDCheck(kind string) (func(id string) bool, bool) {
+	taxIDsMutex.RLock()
+	defer taxIDsMutex.RUnlock()
+	check, ok := taxIDs[kind]
+	return check, ok
+}
+
+// TaxIDValidator field must be a valid tax ID
+type TaxIDValidator struct {
+	field    []string
+	message  string
+	label    string
+	optional bool
+	kind     string
+	check    func(id string) bool
+}
+
+// Field of the field
+func (c *TaxIDValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *TaxIDValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *TaxIDValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *TaxIDValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *TaxIDValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Validate the value
+func (c *TaxIDValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please enter a valid tax number for %s", fieldLabel(c.field, c.label)))
+	}
+	if c.optional && str == "" {
+		return nil
+	}
+	check := c.check
+	if check == nil {
+		// the kind may be registered after the validator was created
+		if check, ok = taxIDCheck(c.kind); !ok {
+			return &internalError{validationError{
+				message: fmt.Sprintf("Something went wrong while validating %s", dataName(c.field)),
+				field:   c.field,
+				rule:    "taxId",
+			}, fmt.Errorf("tax ID not supported: %s", c.kind)}
+		}
+	}
+	if check(strings.ToUpper(taxIDClean.Replace(str))) {
+		return nil
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please enter a valid tax number for %s", fieldLabel(c.field, c.label)))
+}
+
+// CanExport for this validator
+func (c *TaxIDValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *TaxIDValidator) Params() map[string]any {
+	return map[string]any{"kind": c.kind, "optional": c.optional}
+}
+
+func (c *TaxIDValidator) ruleName() string {
+	return "taxId"
+}
+
+// MarshalJSON for this validator
+func (c *TaxIDValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule     string `json:"rule"`
+		Kind     string `json:"kind"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+	}{"taxId", c.kind, c.message, c.label, c.optional})
+}
+
+// TaxID field must be a valid tax ID of the kind, e.g. "EIN" for US employer identification numbers. Kinds are added
+// with RegisterTaxID. Validating a kind that isn't registered returns an error matching ErrInternal.
+func TaxID(kind string) *TaxIDValidator {
+	check, _ := taxIDCheck(kind)
+	return &TaxIDValidator{
+		optional: config.OptionalByDefault,
+		kind:     kind,
+		check:    check,
+	}
+}
+
+// VAT field must be a valid VAT number of the EU country, with or without the country prefix. The format and, where
+// the country has one, the check digits are validated. Greece uses the prefix "EL" but is given as "GR".
+func VAT(country string) *TaxIDValidator {
+	return TaxID("VAT:" + strings.ToUpper(country))
+}
+
+func init() {
+	RegisterTaxID("EIN", isEIN)
+	for country, check := range vatChecks {
+		RegisterTaxID("VAT:"+country, vatCheck(country, check.pattern, check.digits))
+	}
+}
+
+var (
+	einRegex = regexp.MustCompile(`^[0-9]{9}$`)
+	// einInvalidPrefixes aren't assigned by the IRS
+	einInvalidPrefixes = []string{"00", "07", "08", "09", "17", "18", "19", "28", "29", "49", "69", "70", "78", "79", "89", "96", "97"}
+)
+
+func isEIN(id string) bool {
+	return einRegex.MatchString(id) && !slices.Contains(einInvalidPrefixes, id[:2])
+}
+
+// vatCheck validates the number after the optional country prefix against the pattern and the check digits
+func vatCheck(country string, pattern string, digits func(n string) bool) func(string) bool {
+	re := regexp.MustCompile("^" + pattern + "$")
+	prefix := country
+	if country == "GR" {
+		prefix = "EL"
+	}
+	return func(id string) bool {
+		n := strings.TrimPrefix(id, prefix)
+		return re.MatchString(n) && (digits == nil || digits(n))
+	}
+}
+
+var vatChecks = map[string]struct {
+	pattern string
+	digits  func(n string) bool
+}{
+	"AT": {`U[0-9]{8}`, vatAT},
+	"BE": {`[01][0-9]{9}`, func(n string) bool { return 97-atoi(n[:8])%97 == atoi(n[8:]) }},
+	"BG": {`[0-9]{9,10}`, vatBG},
+	"CY": {`[0-59][0-9]{7}[A-Z]`, vatCY},
+	"CZ": {`[0-9]{8,10}`, vatCZ},
+	"DE": {`[0-9]{9}`, iso7064},
+	"DK": {`[0-9]{8}`, func(n string) bool { return weightedSum(n, 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0 }},
+	"EE": {`10[0-9]{7}`, func(n string) bool { return (10-weightedSum(n, 3, 7, 1, 3, 7, 1, 3, 7)%10)%10 == atoi(n[8:]) }},
+	"ES": {`[0-9A-Z][0-9]{7}[0-9A-Z]`, vatES},
+	"FI": {`[0-9]{8}`, func(n string) bool { return weightedSum(n, 7, 9, 10, 5, 8, 4, 2, 1)%11 == 0 }},
+	"FR": {`[0-9A-Z]{2}[0-9]{9}`, vatFR},
+	"GR": {`[0-9]{9}`, func(n string) bool { return weightedSum(n, 256, 128, 64, 32, 16, 8, 4, 2)%11%10 == atoi(n[8:]) }},
+	"HR": {`[0-9]{11}`, iso7064},
+	"HU": {`[0-9]{8}`, func(n string) bool { return (10-weightedSum(n, 9, 7, 3, 1, 9, 7, 3)%10)%10 == atoi(n[7:]) }},
+	"IE": {`[0-9][0-9A-Z+*][0-9]{5}[A-W][A-IW]?`, vatIE},
+	"IT": {`[0-9]{11}`, luhn},
+	"LT": {`[0-9]{9}|[0-9]{12}`, vatLT},
+	"LU": {`[0-9]{8}`, func(n string) bool { return atoi(n[:6])%89 == atoi(n[6:]) }},
+	"LV": {`[0-9]{11}`, vatLV},
+	"MT": {`[1-9][0-9]{7}`, func(n string) bool { return 37-weightedSum(n, 3, 4, 6, 7, 8, 9)%37 == atoi(n[6:]) }},
+	"NL": {`[0-9]{9}B[0-9]{2}`, vatNL},
+	"PL": {`[0-9]{10}`, func(n string) bool { return weightedSum(n, 6, 5, 7, 2, 3, 4, 5, 6, 7)%11 == atoi(n[9:]) }},
+	"PT": {`[0-9]{9}`, vatPT},
+	"RO": {`[1-9][0-9]{1,9}`, vatRO},
+	"SE": {`[0-9]{10}01`, func(n string) bool { return luhn(n[:10]) }},
+	"SI": {`[1-9][0-9]{7}`, vatSI},
+	"SK": {`[1-9][0-9]{9}`, func(n string) bool { return atoi(n)%11 == 0 }},
+}
+
+func vatAT(n string) bool {
+	sum := 0
+	for i, w := range []int{1, 2, 1, 2, 1, 2, 1} {
+		d := int(n[i+1]-'0') * w
+		sum += d/10 + d%10
+	}
+	return (96-sum)%10 == int(n[8]-'0')
+}
+
+// iso7064 checks the last digit with ISO 7064 MOD 11,10
+func iso7064(n string) bool {
+	product := 10
+	for i := 0; i < len(n)-1; i++ {
+		sum := (int(n[i]-'0') + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == int(n[len(n)-1]-'0')
+}
+
+// vatBG accepts the checks of companies and of the three kinds of personal numbers
+func vatBG(n string) bool {
+	if len(n) == 9 {
+		check := weightedSum(n, 1, 2, 3, 4, 5, 6, 7, 8) % 11
+		if check == 10 {
+			check = weightedSum(n, 3, 4, 5, 6, 7, 8, 9, 10) % 11 % 10
+		}
+		return check == atoi(n[8:])
+	}
+	last := atoi(n[9:])
+	if weightedSum(n, 2, 4, 8, 5, 10, 9, 7, 3, 6)%11%10 == last {
+		return true
+	}
+	if weightedSum(n, 21, 19, 17, 13, 11, 9, 7, 3, 1)%10 == last {
+		return true
+	}
+	check := 11 - weightedSum(n, 4, 3, 2, 7, 6, 5, 4, 3, 2)%11
+	return check != 10 && check%11 == last
+}
+
+func vatCY(n string) bool {
+	if n[:2] == "12" {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		d := int(n[i] - '0')
+		if i%2 == 0 {
+			d = []int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21}[d]
+		}
+		sum += d
+	}
+	return 'A'+byte(sum%26) == n[8]
+}
+
+// vatCZ checks the numbers of companies, and of individuals with a 10 digit birth number. Other birth numbers only
+// have a format.
+func vatCZ(n string) bool {
+	switch len(n) {
+	case 8:
+		check := (11 - weightedSum(n, 8, 7, 6, 5, 4, 3, 2)%11) % 10
+		return n[0] != '9' && check == atoi(n[7:])
+	case 10:
+		return atoi(n)%11 == 0
+	}
+	return true
+}
+
+// vatES checks the letter of personal numbers and the check digit or letter of company numbers
+func vatES(n string) bool {
+	const letters = "TRWAGMYFPDXBNJZSQVHLCKE"
+	switch {
+	case n[0] >= '0' && n[0] <= '9':
+		return isDigits(n[:8]) && letters[atoi(n[:8])%23] == n[8]
+	case n[0] >= 'X' && n[0] <= 'Z':
+		return letters[atoi(string('0'+n[0]-'X')+n[1:8])%23] == n[8]
+	case strings.IndexByte("KLM", n[0]) >= 0:
+		return letters[atoi(n[1:8])%23] == n[8]
+	}
+	sum := 0
+	for i := 1; i < 8; i++ {
+		d := int(n[i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	check := (10 - sum%10) % 10
+	return n[8] == byte('0'+check) || n[8] == "JABCDEFGHI"[check]
+}
+
+// vatIE checks the letter of the current format, and of the old format after moving its digits into place
+func vatIE(n string) bool {
+	if n[1] < '0' || n[1] > '9' {
+		if len(n) != 8 {
+			return false
+		}
+		n = "0" + n[2:7] + n[:1] + n[7:]
+	}
+	if !isDigits(n[:7]) {
+		return false
+	}
+	sum := weightedSum(n, 8, 7, 6, 5, 4, 3, 2)
+	if len(n) == 9 && n[8] != 'W' {
+		sum += int(n[8]-'A'+1) * 9
+	}
+	return "WABCDEFGHIJKLMNOPQRSTUV"[sum%23] == n[7]
+}
+
+func vatLT(n string) bool {
+	last := atoi(n[len(n)-1:])
+	weights := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 1, 2}[:len(n)-1]
+	check := weightedSum(n, weights...) % 11
+	if check == 10 {
+		weights = []int{3, 4, 5, 6, 7, 8, 9, 1, 2, 3, 4}[:len(n)-1]
+		check = weightedSum(n, weights...) % 11 % 10
+	}
+	return check == last
+}
+
+// vatLV checks the numbers of companies. Numbers of individuals start with a birth date and only have a format.
+func vatLV(n string) bool {
+	if n[0] <= '3' {
+		return true
+	}
+	check := 3 - weightedSum(n, 9, 1, 4, 8, 3, 10, 2, 5, 7, 6)%11
+	if check == -1 {
+		return false
+	}
+	if check < -1 {
+		check += 11
+	}
+	return check == atoi(n[10:])
+}
+
+func vatRO(n string) bool {
+	padded := strings.Repeat("0", 10-len(n)) + n
+	check := weightedSum(padded, 7, 5, 3, 2, 1, 7, 5, 3, 2) * 10 % 11 % 10
+	return check == atoi(padded[9:])
+}
+
+func vatSI(n string) bool {
+	check := 11 - weightedSum(n, 8, 7, 6, 5, 4, 3, 2)%11
+	return check != 11 && check%10 == atoi(n[7:])
+}
+
+func vatFR(n string) bool {
+	key := n[:2]
+	if key[0] > '9' || key[1] > '9' {
+		// keys with letters have no published check
+		return true
+	}
+	return (12+3*(atoi(n[2:])%97))%97 == atoi(key)
+}
+
+// vatNL accepts the mod 11 check of the older numbers and the mod 97 check of the sole proprietor numbers
+func vatNL(n string) bool {
+	if (weightedSum(n, 9, 8, 7, 6, 5, 4, 3, 2)-int(n[8]-'0'))%11 == 0 {
+		return true
+	}
+	// mod 97 over "NL" followed by the number, with letters as two digit numbers
+	rem := 0
+	for _, r := range "NL" + n {
+		v := int(r - '0')
+		if r >= 'A' {
+			v = int(r-'A') + 10
+			rem = (rem*100 + v) % 97
+			continue
+		}
+		rem = (rem*10 + v) % 97
+	}
+	return rem == 1
+}
+
+func vatPT(n string) bool {
+	check := 11 - weightedSum(n, 9, 8, 7, 6, 5, 4, 3, 2)%11
+	if check > 9 {
+		check = 0
+	}
+	return check == int(n[8]-'0')
+}
+
+func weightedSum(n string, weights ...int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(n[i]-'0') * w
+	}
+	return sum
+}
+
+func luhn(n string) bool {
+	sum := 0
+	for i := range n {
+		d := int(n[len(n)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func isDigits(n string) bool {
+	for _, r := range n {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func atoi(n string) int {
+	v := 0
+	for _, r := range n {
+		v = v*10 + int(r-'0')
+	}
+	return v
+}
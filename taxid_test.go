@@ -0,0 +1,81 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVAT(t *testing.T) {
+	valid := map[string]string{
+		"AT": "ATU13585627",
+		"BE": "BE 0428.759.497",
+		"BG": "BG175074752",
+		"CZ": "CZ25123891",
+		"DE": "DE136695976",
+		"DK": "DK13585628",
+		"EE": "EE100931558",
+		"ES": "ESB58378431",
+		"FI": "FI20774740",
+		"FR": "FR40303265045",
+		"GR": "EL094259216",
+		"HR": "HR33392005961",
+		"HU": "HU12892312",
+		"IT": "IT00743110157",
+		"LT": "LT100001919017",
+		"LU": "LU15027442",
+		"LV": "LV40003521600",
+		"MT": "MT11679112",
+		"PL": "PL5260001246",
+		"PT": "PT501964843",
+		"RO": "RO18547290",
+		"SE": "SE556703748501",
+		"SI": "SI50223054",
+		"SK": "SK2022749619",
+	}
+	for country, id := range valid {
+		assert.Nil(t, VAT(country).Validate(id), country)
+		assert.Nil(t, VAT(strings.ToLower(country)).Validate(id[2:]), country+" without prefix")
+		// change the last digit
+		last := id[len(id)-1]
+		wrong := id[:len(id)-1] + string('0'+(last-'0'+1)%10)
+		assert.NotNil(t, VAT(country).Validate(wrong), country+" check digits")
+	}
+	assert.Nil(t, VAT("NL").Validate("NL004495445B01"), "NL mod 11")
+	assert.Nil(t, VAT("NL").Validate("NL000099998B57"), "NL mod 97")
+	assert.NotNil(t, VAT("NL").Validate("NL004495446B01"), "NL check digits")
+	assert.Nil(t, VAT("LT").Validate("LT119511515"), "LT 9 digits")
+	assert.Nil(t, VAT("CY").Validate("CY10259033P"), "CY")
+	assert.NotNil(t, VAT("CY").Validate("CY10259033Q"), "CY check letter")
+	assert.Nil(t, VAT("ES").Validate("ESX5253868R"), "ES NIE")
+	assert.NotNil(t, VAT("ES").Validate("ESX5253868S"), "ES NIE check letter")
+	assert.Nil(t, VAT("IE").Validate("IE6433435F"), "IE")
+	assert.Nil(t, VAT("IE").Validate("IE8D79739I"), "IE old format")
+	assert.NotNil(t, VAT("IE").Validate("IE6433435G"), "IE check letter")
+	assert.NotNil(t, VAT("DE").Validate("DE12345"), "Wrong format")
+	err := VAT("XX").Validate("XX123")
+	assert.ErrorIs(t, err, ErrInternal, "Unsupported country")
+
+	type invoice struct {
+		VAT string `json:"vat"`
+	}
+	i := invoice{}
+	errs := New(&i).Field(&i.VAT, VAT("DE")).Validate(invoice{VAT: "DE136695977"}).(ErrorSlice)
+	assert.Equal(t, "Please enter a valid tax number for vat", errs[0].Error(), "Message")
+	assert.Equal(t, "taxId", errs[0].(interface{ Rule() string }).Rule(), "Rule name")
+	assert.Nil(t, New(&i).Field(&i.VAT, VAT("DE").SetOptional()).Validate(invoice{}), "Optional")
+	j, _ := json.Marshal(VAT("de"))
+	assert.Equal(t, `{"rule":"taxId","kind":"VAT:DE"}`, string(j), "Export")
+}
+
+func TestTaxID(t *testing.T) {
+	assert.Nil(t, TaxID("EIN").Validate("12-3456789"), "EIN")
+	assert.NotNil(t, TaxID("EIN").Validate("07-3456789"), "Unassigned EIN prefix")
+	assert.NotNil(t, TaxID("EIN").Validate("12-345678"), "EIN length")
+
+	RegisterTaxID("TEST", func(id string) bool { return id == "AB12" })
+	assert.Nil(t, TaxID("TEST").Validate("ab-12"), "Registered kind gets clean ID")
+	assert.NotNil(t, TaxID("TEST").Validate("AB13"), "Registered kind")
+}
@@ -0,0 +1,78 @@
+package xvalid
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// Remove drops the validators of a field with the rule names, e.g. "maxLength", or all validators of the field if no
+// names are given. It is mostly used with Tenants to replace a rule of the base chain.
+func (r Rules) Remove(fieldPtr any, rules ...string) Rules {
+	name := jsonFieldName(getField(r.structPtr, fieldPtr))
+	validators := make([]Validator, 0, len(r.validators))
+	for _, v := range r.validators {
+		if jsonFieldName(v.Field()) == name && (len(rules) == 0 || slices.Contains(rules, ruleName(v))) {
+			continue
+		}
+		validators = append(validators, v)
+	}
+	r.validators = validators
+	return r
+}
+
+// Tenants layers the overrides of each tenant on top of a base chain, e.g. for workspaces that configure their own
+// limits. Tenants without overrides use the base chain. It is safe for concurrent use.
+type Tenants struct {
+	base      Rules
+	mutex     sync.RWMutex
+	overrides map[string]Rules
+}
+
+// NewTenants with the rules every tenant starts with
+func NewTenants(base Rules) *Tenants {
+	// limit the capacity so tenants appending to the base chain don't share the same array
+	base.validators = base.validators[:len(base.validators):len(base.validators)]
+	return &Tenants{
+		base:      base,
+		overrides: make(map[string]Rules),
+	}
+}
+
+// Override sets the rules of the tenant to the result of calling override with the base chain. The override adds
+// validators with Field and replaces them with Remove, using field pointers of the struct the base chain was created
+// with. Calling it again for the same tenant replaces the previous override.
+func (t *Tenants) Override(tenant string, override func(base Rules) Rules) {
+	rules := override(t.base)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.overrides[tenant] = rules
+}
+
+// Reset removes the overrides of the tenant
+func (t *Tenants) Reset(tenant string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.overrides, tenant)
+}
+
+// Rules of the tenant, e.g. for exporting them to the tenant's clients
+func (t *Tenants) Rules(tenant string) Rules {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	if rules, ok := t.overrides[tenant]; ok {
+		return rules
+	}
+	return t.base
+}
+
+// Validate the subject with the rules of the tenant
+func (t *Tenants) Validate(tenant string, subject any) error {
+	return t.Rules(tenant).Validate(subject)
+}
+
+// ValidateCtx validates the subject with the rules of the tenant, see Rules.ValidateCtx
+func (t *Tenants) ValidateCtx(ctx context.Context, tenant string, subject any) error {
+	return t.Rules(tenant).ValidateCtx(ctx, subject)
+}
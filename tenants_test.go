@@ -0,0 +1,51 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenants(t *testing.T) {
+	type upload struct {
+		Name  string `json:"name"`
+		Notes string `json:"notes"`
+	}
+	u := upload{}
+	tenants := NewTenants(New(&u).Field(&u.Name, Required(), MaxLength(10)))
+	tenants.Override("strict", func(base Rules) Rules {
+		return base.Remove(&u.Name, "maxLength").Field(&u.Name, MaxLength(5)).Field(&u.Notes, Required())
+	})
+	tenants.Override("loose", func(base Rules) Rules {
+		return base.Remove(&u.Name, "maxLength")
+	})
+
+	subject := upload{Name: "eightchr"}
+	assert.Nil(t, tenants.Validate("default", subject), "Base rules")
+	assert.Len(t, tenants.Validate("strict", subject), 2, "Override replaces and adds rules")
+	assert.Nil(t, tenants.Validate("loose", upload{Name: "longer than ten"}), "Override removes rule")
+	assert.Len(t, tenants.Validate("default", upload{Name: "longer than ten"}), 1, "Base rules unchanged")
+
+	j, _ := json.Marshal(tenants.Rules("strict"))
+	assert.Equal(t,
+		`{"name":[{"rule":"required"},{"rule":"maxLength","max":5}],"notes":[{"rule":"required"}]}`,
+		string(j), "Export tenant rules")
+	j, _ = json.Marshal(tenants.Rules("loose"))
+	assert.Equal(t, `{"name":[{"rule":"required"}]}`, string(j), "Tenants don't share validators")
+
+	tenants.Reset("strict")
+	assert.Nil(t, tenants.Validate("strict", subject), "Reset to base rules")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tenants.Override("concurrent", func(base Rules) Rules { return base })
+			_ = tenants.Validate("concurrent", subject)
+		}()
+	}
+	wg.Wait()
+}
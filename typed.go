@@ -0,0 +1,238 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// TypedValidator validates values of a known type. Add it to a field with FieldOf so a validator for the wrong type is
+// caught when compiling instead of when validating.
+type TypedValidator[T any] interface {
+	Validator
+	// ValidateTyped validates the value without converting it to any
+	ValidateTyped(value T) Error
+}
+
+// typedValidator is implemented by the typed validators of this package, so values of the type are validated with
+// ValidateTyped
+type typedValidator interface {
+	// validateTyped returns false if the value isn't of the type of the validator
+	validateTyped(value any) (Error, bool)
+}
+
+// FieldOf adds typed validators for a field. It's the same as Rules.Field, but the validators must be for the type of
+// the field, and values of the field are validated with ValidateTyped.
+func FieldOf[T any](r Rules, fieldPtr *T, validators ...TypedValidator[T]) Rules {
+	list := make([]Validator, len(validators))
+	for i, v := range validators {
+		if _, ok := v.(typedValidator); ok {
+			list[i] = v
+		} else {
+			list[i] = &AdapterValidator[T]{validator: v, typed: v}
+		}
+	}
+	return r.Field(fieldPtr, list...)
+}
+
+//
+// ==================== Typed ====================
+//
+
+// AdapterValidator uses a validator of any value as a TypedValidator
+type AdapterValidator[T any] struct {
+	validator Validator
+	// typed is the validator if it's a TypedValidator of another package
+	typed TypedValidator[T]
+}
+
+// Field of the field
+func (c *AdapterValidator[T]) Field() []string {
+	return c.validator.Field()
+}
+
+// SetField of the field
+func (c *AdapterValidator[T]) SetField(name ...string) {
+	c.validator.SetField(name...)
+}
+
+// SetMessage set error message
+func (c *AdapterValidator[T]) SetMessage(msg string) Validator {
+	c.validator.SetMessage(msg)
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *AdapterValidator[T]) SetLabel(label string) Validator {
+	setLabel(c.validator, label)
+	return c
+}
+
+// Validate the value with the wrapped validator
+func (c *AdapterValidator[T]) Validate(value any) Error {
+	err := c.validator.Validate(value)
+	if err != nil {
+		setRule(ErrorSlice{err}, ruleName(c.validator))
+	}
+	return err
+}
+
+// ValidateTyped validates the value with the wrapped validator
+func (c *AdapterValidator[T]) ValidateTyped(value T) Error {
+	if c.typed == nil {
+		return c.Validate(value)
+	}
+	err := c.typed.ValidateTyped(value)
+	if err != nil {
+		setRule(ErrorSlice{err}, ruleName(c.validator))
+	}
+	return err
+}
+
+func (c *AdapterValidator[T]) validateTyped(value any) (Error, bool) {
+	v, ok := value.(T)
+	if !ok {
+		return nil, false
+	}
+	return c.ValidateTyped(v), true
+}
+
+func (c *AdapterValidator[T]) ruleName() string {
+	return ruleName(c.validator)
+}
+
+// CanExport for this validator
+func (c *AdapterValidator[T]) CanExport() bool {
+	return c.validator.CanExport()
+}
+
+// Params of the wrapped validator
+func (c *AdapterValidator[T]) Params() map[string]any {
+	return Params(c.validator)
+}
+
+// MarshalJSON exports the wrapped validator
+func (c *AdapterValidator[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.validator)
+}
+
+func (c *AdapterValidator[T]) clone() Validator {
+	clone := &AdapterValidator[T]{validator: cloneValidator(c.validator)}
+	if c.typed != nil {
+		clone.typed, _ = clone.validator.(TypedValidator[T])
+	}
+	return clone
+}
+
+// Typed uses a validator of any value for fields of type T, e.g. FieldOf(rules, &u.Name, Typed[string](MaxLength(50))).
+// It panics if the validator is a built in validator that can't validate values of type T, e.g. Typed[int](MaxLength(5)).
+func Typed[T any](validator Validator) *AdapterValidator[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if !acceptsType(validator, t) {
+		panic(fmt.Errorf("%s can't validate %s", ruleName(validator), t))
+	}
+	return &AdapterValidator[T]{validator: validator}
+}
+
+// acceptsType returns false if the validator is known not to validate values of the type
+func acceptsType(validator Validator, t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+	isList := t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map
+	switch validator.(type) {
+	case *MinLengthValidator, *MaxLengthValidator, *MinBytesValidator, *MaxBytesValidator, *PatternValidator,
+		*EmailValidator, *NormalizedValidator, *URLValidator, *PhoneValidator, *TaxIDValidator, *DateFormatValidator:
+		return t.Kind() == reflect.String
+	case *LengthValidator:
+		return t.Kind() == reflect.String || isList
+	case *MinItemsValidator, *MaxItemsValidator:
+		return isList
+	case *MinValidator, *MaxValidator, *RangeValidator:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+//
+// ==================== TypedFunc ====================
+//
+
+// TypedFuncValidator for validating with a custom function that receives the value as its own type
+type TypedFuncValidator[T any] struct {
+	field   []string
+	message string
+	label   string
+	checker func([]string, T) Error
+}
+
+// Field of the field
+func (c *TypedFuncValidator[T]) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *TypedFuncValidator[T]) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set the error message used when the value is not of type T
+func (c *TypedFuncValidator[T]) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *TypedFuncValidator[T]) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value. Nil is validated as the zero value of T.
+func (c *TypedFuncValidator[T]) Validate(value any) Error {
+	v, ok := value.(T)
+	if !ok && value != nil {
+		if passMismatch(value, false) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please correct %s", fieldLabel(c.field, c.label)))
+	}
+	return c.checker(c.field, v)
+}
+
+// ValidateTyped validates the value with the function
+func (c *TypedFuncValidator[T]) ValidateTyped(value T) Error {
+	return c.checker(c.field, value)
+}
+
+func (c *TypedFuncValidator[T]) validateTyped(value any) (Error, bool) {
+	v, ok := value.(T)
+	if !ok {
+		return nil, false
+	}
+	return c.ValidateTyped(v), true
+}
+
+// CanExport for this validator
+func (c *TypedFuncValidator[T]) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *TypedFuncValidator[T]) Params() map[string]any {
+	return map[string]any{}
+}
+
+// TypedFunc for validating with a custom function that receives the value as its own type
+func TypedFunc[T any](f func([]string, T) Error) *TypedFuncValidator[T] {
+	return &TypedFuncValidator[T]{
+		checker: f,
+	}
+}
@@ -0,0 +1,84 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// evenValidator stands in for a typed validator of another package
+type evenValidator struct {
+	field []string
+}
+
+func (c *evenValidator) Field() []string                 { return c.field }
+func (c *evenValidator) SetField(name ...string)         { c.field = name }
+func (c *evenValidator) SetMessage(msg string) Validator { return c }
+func (c *evenValidator) CanExport() bool                 { return false }
+func (c *evenValidator) Validate(value any) Error {
+	return NewError("Validate called", c.field...)
+}
+func (c *evenValidator) ValidateTyped(value int) Error {
+	if value%2 != 0 {
+		return NewError("Please use an even number", c.field...)
+	}
+	return nil
+}
+
+func TestTyped(t *testing.T) {
+	type account struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	a := account{}
+	rules := New(&a)
+	rules = FieldOf(rules, &a.Name, Typed[string](Required()), TypedFunc(func(field []string, v string) Error {
+		if strings.Contains(v, " ") {
+			return NewError("Please remove the spaces", field...)
+		}
+		return nil
+	}))
+	rules = FieldOf(rules, &a.Age, Typed[int](Min(18)))
+	rules = rules.Label(&a.Name, "Name")
+
+	assert.Nil(t, rules.Validate(account{Name: "bob", Age: 20}), "Valid")
+	errs := rules.Validate(account{Name: "", Age: 1}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Typed errors")
+	assert.Equal(t, "Please enter the Name", errs[0].Error(), "Label of wrapped validator")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Rule of wrapped validator")
+	assert.Equal(t, []string{"age"}, errs[1].Field(), "Field")
+	errs = rules.Validate(account{Name: "b b", Age: 20}).(ErrorSlice)
+	assert.Equal(t, "Please remove the spaces", errs[0].Error(), "Typed function")
+	assert.Equal(t, "typedFunc", errs[0].(interface{ Rule() string }).Rule(), "Typed function rule")
+
+	j, _ := json.Marshal(rules)
+	assert.Equal(t, `{"name":[{"rule":"required","label":"Name"}],"age":[{"rule":"min","min":18}]}`, string(j),
+		"Export wrapped validators")
+	assert.Equal(t, map[string]any{"max": int64(3), "trimmed": false}, Params(Typed[string](MaxLength(3))), "Params")
+
+	check := TypedFunc(func(field []string, v int) Error {
+		if v != 0 {
+			return NewError("Not zero", field...)
+		}
+		return nil
+	})
+	assert.Nil(t, check.ValidateTyped(0), "Validate typed")
+	assert.Nil(t, check.Validate(nil), "Nil as zero value")
+	check.SetField("count")
+	assert.Equal(t, "Please correct count", check.Validate("x").Error(), "Wrong type")
+	assert.Equal(t, "Too long", Typed[string](MaxLength(1).SetMessage("Too long")).ValidateTyped("ab").Error(),
+		"Adapter validate typed")
+
+	rules = FieldOf(New(&a), &a.Age, &evenValidator{})
+	assert.Nil(t, rules.Validate(account{Age: 2}), "ValidateTyped used")
+	errs = rules.Validate(account{Age: 3}).(ErrorSlice)
+	assert.Equal(t, "Please use an even number", errs[0].Error(), "ValidateTyped error")
+	assert.Equal(t, []string{"age"}, errs[0].Field(), "Field of typed validator")
+
+	assert.Panics(t, func() { Typed[int](MaxLength(5)) }, "Validator of another type")
+	assert.Panics(t, func() { Typed[string](Min(1)) }, "Number validator")
+	assert.NotPanics(t, func() { Typed[*string](MaxLength(5)) }, "Pointer")
+	assert.NotPanics(t, func() { Typed[[]string](Length(1, 2)) }, "Length of list")
+}
@@ -0,0 +1,204 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// URLValidator field must be an absolute URL
+type URLValidator struct {
+	field       []string
+	message     string
+	label       string
+	optional    bool
+	schemes     []string
+	denyPrivate bool
+	lookupIP    func(ctx context.Context, host string) ([]net.IP, error)
+}
+
+// Field of the field
+func (c *URLValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *URLValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *URLValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *URLValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *URLValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// AllowSchemes only accepts URLs with one of the schemes, e.g. "https"
+func (c *URLValidator) AllowSchemes(schemes ...string) *URLValidator {
+	c.schemes = make([]string, len(schemes))
+	for i, s := range schemes {
+		c.schemes[i] = strings.ToLower(s)
+	}
+	return c
+}
+
+// DenyPrivateHosts rejects URLs that point to loopback, private, shared, link local, multicast or unspecified
+// addresses, to protect against server side request forgery with user supplied URLs such as webhooks. Host names are
+// resolved, and any private address rejects the URL. A host can resolve to another address when it's dialed later, so
+// dial with a check like xvalidhttp.Reachable does.
+func (c *URLValidator) DenyPrivateHosts() *URLValidator {
+	c.denyPrivate = true
+	return c
+}
+
+// urlTimeout limits host lookups when the context has no earlier deadline
+const urlTimeout = 10 * time.Second
+
+// Validate the value with a background context
+func (c *URLValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value, using the context for host lookups
+func (c *URLValidator) ValidateCtx(ctx context.Context, value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please use a valid URL for %s", fieldLabel(c.field, c.label)))
+	}
+	if c.optional && str == "" {
+		return nil
+	}
+	u, err := url.Parse(str)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return createError(c.field, c.message, fmt.Sprintf("Please use a valid URL for %s", fieldLabel(c.field, c.label)))
+	}
+	if len(c.schemes) > 0 && !slices.Contains(c.schemes, strings.ToLower(u.Scheme)) {
+		return createError(c.field, c.message, fmt.Sprintf("Please use a %s URL for %s", strings.Join(c.schemes, " or "), fieldLabel(c.field, c.label)))
+	}
+	if !c.denyPrivate {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, urlTimeout)
+	defer cancel()
+	if isPrivateHost(ctx, u.Hostname(), c.lookupIP) {
+		return createError(c.field, c.message, fmt.Sprintf("Please use a public URL for %s", fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+// deniedNetworks that aren't covered by the methods of net.IP
+var deniedNetworks = func() []*net.IPNet {
+	cidrs := []string{
+		"100.64.0.0/10",  // shared address space of carrier grade NAT
+		"192.0.0.0/24",   // IETF protocol assignments
+		"198.18.0.0/15",  // benchmarking
+		"240.0.0.0/4",    // reserved
+		"64:ff9b::/96",   // NAT64, which embeds any IPv4 address
+		"64:ff9b:1::/48", // local NAT64
+		"2002::/16",      // 6to4, which embeds any IPv4 address
+	}
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, networks[i], _ = net.ParseCIDR(cidr)
+	}
+	return networks
+}()
+
+// IsPrivateIP returns true if the address isn't a public unicast address, which DenyPrivateHosts rejects
+func IsPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, n := range deniedNetworks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPrivateHost returns true if the host is or resolves to an address that isn't public. Hosts that can't be resolved
+// are treated as private.
+func IsPrivateHost(ctx context.Context, host string) bool {
+	return isPrivateHost(ctx, host, lookupIP)
+}
+
+func isPrivateHost(ctx context.Context, host string, lookup func(ctx context.Context, host string) ([]net.IP, error)) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+		ips, err = lookup(ctx, host)
+		if err != nil || len(ips) == 0 {
+			return true
+		}
+	}
+	for _, ip := range ips {
+		if IsPrivateIP(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	return net.DefaultResolver.LookupIP(ctx, "ip", host)
+}
+
+// CanExport for this validator
+func (c *URLValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *URLValidator) Params() map[string]any {
+	return map[string]any{"schemes": c.schemes, "denyPrivateHosts": c.denyPrivate, "optional": c.optional}
+}
+
+func (c *URLValidator) ruleName() string {
+	return "url"
+}
+
+// MarshalJSON for this validator
+func (c *URLValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule             string   `json:"rule"`
+		Schemes          []string `json:"schemes,omitempty"`
+		DenyPrivateHosts bool     `json:"denyPrivateHosts,omitempty"`
+		Message          string   `json:"message,omitempty"`
+		Label            string   `json:"label,omitempty"`
+		Optional         bool     `json:"optional,omitempty"`
+	}{"url", c.schemes, c.denyPrivate, c.message, c.label, c.optional})
+}
+
+// URL field must be an absolute URL with a scheme and host
+func URL() *URLValidator {
+	return &URLValidator{
+		optional: config.OptionalByDefault,
+		lookupIP: lookupIP,
+	}
+}
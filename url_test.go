@@ -0,0 +1,58 @@
+package xvalid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURL(t *testing.T) {
+	type hookType struct {
+		Webhook string `json:"webhook"`
+	}
+	h := hookType{}
+	rules := New(&h).Field(&h.Webhook, URL())
+	assert.Nil(t, rules.Validate(hookType{Webhook: "ftp://example.com/file"}), "Valid URL")
+	assert.Len(t, rules.Validate(hookType{Webhook: "example.com"}), 1, "Missing scheme")
+	assert.Len(t, rules.Validate(hookType{Webhook: "http://"}), 1, "Missing host")
+	assert.Nil(t, New(&h).Field(&h.Webhook, URL().SetOptional()).Validate(hookType{}), "Optional")
+
+	rules = New(&h).Field(&h.Webhook, URL().AllowSchemes("https"))
+	assert.Nil(t, rules.Validate(hookType{Webhook: "HTTPS://example.com"}), "Allowed scheme")
+	errs := rules.Validate(hookType{Webhook: "http://example.com"}).(ErrorSlice)
+	assert.Equal(t, "Please use a https URL for webhook", errs[0].Error(), "Scheme message")
+
+	lookup := func(ctx context.Context, host string) ([]net.IP, error) {
+		switch host {
+		case "internal.example.com":
+			return []net.IP{net.ParseIP("93.184.216.34"), net.ParseIP("10.0.0.1")}, nil
+		case "example.com":
+			return []net.IP{net.ParseIP("93.184.216.34")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	v := URL().DenyPrivateHosts()
+	v.lookupIP = lookup
+	rules = New(&h).Field(&h.Webhook, v)
+	assert.Nil(t, rules.Validate(hookType{Webhook: "https://example.com/hook"}), "Public host")
+	assert.Nil(t, rules.Validate(hookType{Webhook: "https://93.184.216.34/hook"}), "Public address")
+	for _, u := range []string{
+		"http://localhost:8080", "http://127.0.0.1", "http://[::1]/", "http://192.168.1.1", "http://169.254.169.254/latest",
+		"http://0.0.0.0", "https://internal.example.com", "https://unknown.example.com", "http://api.localhost.",
+	} {
+		errs := rules.Validate(hookType{Webhook: u}).(ErrorSlice)
+		assert.Equal(t, "Please use a public URL for webhook", errs[0].Error(), u)
+	}
+	for _, ip := range []string{"100.64.0.1", "64:ff9b::a00:1", "224.0.0.1", "::ffff:10.0.0.1"} {
+		assert.True(t, IsPrivateIP(net.ParseIP(ip)), ip)
+	}
+	errs = rules.Validate(hookType{Webhook: "http://127.0.0.1"}).(ErrorSlice)
+	assert.Equal(t, "url", errs[0].(interface{ Rule() string }).Rule(), "Rule name")
+
+	j, _ := json.Marshal(URL().AllowSchemes("https").DenyPrivateHosts())
+	assert.Equal(t, `{"rule":"url","schemes":["https"],"denyPrivateHosts":true}`, string(j), "Export")
+}
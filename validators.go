@@ -1,12 +1,21 @@
 package xvalid
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/mail"
 	"reflect"
 	"regexp"
+	"strings"
+	"time"
 
 	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
 )
 
 //
@@ -15,8 +24,10 @@ import (
 
 // RequiredValidator field must not be zero
 type RequiredValidator struct {
-	field   []string
-	message string
+	field    []string
+	message  string
+	label    string
+	zeroTime ZeroTimePolicy
 }
 
 // Field of the field
@@ -35,12 +46,20 @@ func (c *RequiredValidator) SetMessage(msg string) Validator {
 	return c
 }
 
+// SetLabel set the label used in error messages
+func (c *RequiredValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
 // Validate the value
 func (c *RequiredValidator) Validate(value any) Error {
 	v := reflect.ValueOf(value)
 	zero := false
 	kind := v.Kind()
-	if !v.IsValid() {
+	if t, ok := timeValue(v); ok {
+		zero = c.zeroTime == ZeroTimeExact && t == time.Time{} || c.zeroTime == ZeroTimeInstant && t.IsZero()
+	} else if !v.IsValid() {
 		zero = true
 	} else if v.IsZero() {
 		zero = true
@@ -48,9 +67,11 @@ func (c *RequiredValidator) Validate(value any) Error {
 		zero = true
 	} else if (kind == reflect.Array || kind == reflect.Slice || kind == reflect.Map) && v.Len() == 0 {
 		zero = true
+	} else if (kind == reflect.Array || kind == reflect.Slice) && hasNilElement(v) {
+		zero = true
 	}
 	if zero {
-		return createError(c.field, c.message, fmt.Sprintf("Please enter the %v", jsonFieldName(c.field)))
+		return createError(c.field, c.message, fmt.Sprintf("Please enter the %v", fieldLabel(c.field, c.label)))
 	}
 	return nil
 }
@@ -60,7 +81,8 @@ func (c *RequiredValidator) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Rule    string `json:"rule"`
 		Message string `json:"message,omitempty"`
-	}{"required", c.message})
+		Label   string `json:"label,omitempty"`
+	}{"required", c.message, c.label})
 }
 
 // CanExport for this validator
@@ -68,9 +90,20 @@ func (c *RequiredValidator) CanExport() bool {
 	return true
 }
 
+// Params of this validator
+func (c *RequiredValidator) Params() map[string]any {
+	return map[string]any{"zeroTime": c.zeroTime}
+}
+
+// ZeroTime sets whether a zero time.Time counts as unset
+func (c *RequiredValidator) ZeroTime(policy ZeroTimePolicy) *RequiredValidator {
+	c.zeroTime = policy
+	return c
+}
+
 // Required fields must not be zero
 func Required() *RequiredValidator {
-	return &RequiredValidator{}
+	return &RequiredValidator{zeroTime: config.ZeroTimePolicy}
 }
 
 //
@@ -81,8 +114,10 @@ func Required() *RequiredValidator {
 type MinLengthValidator struct {
 	field    []string
 	message  string
+	label    string
 	min      int64
 	optional bool
+	trimmed  bool
 }
 
 // Field of the field
@@ -101,27 +136,42 @@ func (c *MinLengthValidator) SetMessage(msg string) Validator {
 	return c
 }
 
+// SetLabel set the label used in error messages
+func (c *MinLengthValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
 // SetOptional don't validate if the value is zero
 func (c *MinLengthValidator) SetOptional() Validator {
 	c.optional = true
 	return c
 }
 
+// Trimmed doesn't count leading and trailing white space toward the length
+func (c *MinLengthValidator) Trimmed() *MinLengthValidator {
+	c.trimmed = true
+	return c
+}
+
 // Validate the value
 func (c *MinLengthValidator) Validate(value any) Error {
 	str, ok := value.(string)
 	if !ok {
-		if c.optional {
+		if passMismatch(value, c.optional) {
 			return nil
 		} else {
-			return createError(c.field, c.message, fmt.Sprintf("Please lengthen %s to %d characters or more", jsonFieldName(c.field), c.min))
+			return createError(c.field, c.message, fmt.Sprintf("Please lengthen %s to %d characters or more", fieldLabel(c.field, c.label), c.min))
 		}
 	}
+	if c.trimmed {
+		str = strings.TrimSpace(str)
+	}
 	if c.optional && str == "" {
 		return nil
 	}
 	if len([]rune(str)) < int(c.min) {
-		return createError(c.field, c.message, fmt.Sprintf("Please lengthen %s to %d characters or more", jsonFieldName(c.field), c.min))
+		return createError(c.field, c.message, fmt.Sprintf("Please lengthen %s to %d characters or more", fieldLabel(c.field, c.label), c.min))
 	}
 	return nil
 }
@@ -129,10 +179,13 @@ func (c *MinLengthValidator) Validate(value any) Error {
 // MarshalJSON for this validator
 func (c *MinLengthValidator) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		Rule    string `json:"rule"`
-		Min     int64  `json:"min"`
-		Message string `json:"message,omitempty"`
-	}{"minLength", c.min, c.message})
+		Rule     string `json:"rule"`
+		Min      int64  `json:"min"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+		Trimmed  bool   `json:"trimmed,omitempty"`
+	}{"minLength", c.min, c.message, c.label, c.optional, c.trimmed})
 }
 
 // CanExport for this validator
@@ -140,10 +193,16 @@ func (c *MinLengthValidator) CanExport() bool {
 	return true
 }
 
+// Params of this validator
+func (c *MinLengthValidator) Params() map[string]any {
+	return map[string]any{"min": c.min, "optional": c.optional, "trimmed": c.trimmed}
+}
+
 // MinLength field must have minimum length
 func MinLength(min int64) *MinLengthValidator {
 	return &MinLengthValidator{
-		min: min,
+		min:      min,
+		optional: config.OptionalByDefault,
 	}
 }
 
@@ -155,7 +214,9 @@ func MinLength(min int64) *MinLengthValidator {
 type MaxLengthValidator struct {
 	ifeld   []string
 	message string
+	label   string
 	max     int64
+	trimmed bool
 }
 
 // Field of the field
@@ -174,14 +235,32 @@ func (c *MaxLengthValidator) SetMessage(msg string) Validator {
 	return c
 }
 
+// SetLabel set the label used in error messages
+func (c *MaxLengthValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Trimmed doesn't count leading and trailing white space toward the length
+func (c *MaxLengthValidator) Trimmed() *MaxLengthValidator {
+	c.trimmed = true
+	return c
+}
+
 // Validate the value
 func (c *MaxLengthValidator) Validate(value any) Error {
 	v, ok := value.(string)
 	if !ok {
-		return nil
+		if passMismatch(value, true) {
+			return nil
+		}
+		return createError(c.ifeld, c.message, fmt.Sprintf("Please shorten %s to %d characters or less", fieldLabel(c.ifeld, c.label), c.max))
+	}
+	if c.trimmed {
+		v = strings.TrimSpace(v)
 	}
 	if len([]rune(v)) > int(c.max) {
-		return createError(c.ifeld, c.message, fmt.Sprintf("Please shorten %s to %d characters or less", jsonFieldName(c.ifeld), c.max))
+		return createError(c.ifeld, c.message, fmt.Sprintf("Please shorten %s to %d characters or less", fieldLabel(c.ifeld, c.label), c.max))
 	}
 	return nil
 }
@@ -192,7 +271,9 @@ func (c *MaxLengthValidator) MarshalJSON() ([]byte, error) {
 		Rule    string `json:"rule"`
 		Max     int64  `json:"max"`
 		Message string `json:"message,omitempty"`
-	}{"maxLength", c.max, c.message})
+		Label   string `json:"label,omitempty"`
+		Trimmed bool   `json:"trimmed,omitempty"`
+	}{"maxLength", c.max, c.message, c.label, c.trimmed})
 }
 
 // CanExport for this validator
@@ -200,6 +281,11 @@ func (c *MaxLengthValidator) CanExport() bool {
 	return true
 }
 
+// Params of this validator
+func (c *MaxLengthValidator) Params() map[string]any {
+	return map[string]any{"max": c.max, "trimmed": c.trimmed}
+}
+
 // MaxLength field have maximum length
 func MaxLength(max int64) *MaxLengthValidator {
 	return &MaxLengthValidator{
@@ -208,373 +294,1623 @@ func MaxLength(max int64) *MaxLengthValidator {
 }
 
 //
-// ==================== Min ====================
+// ==================== MinBytes ====================
 //
 
-// MinValidator field have minimum value
-type MinValidator struct {
+// MinBytesValidator field must have minimum length in bytes
+type MinBytesValidator struct {
 	field    []string
 	message  string
+	label    string
 	min      int64
 	optional bool
 }
 
 // Field of the field
-func (c *MinValidator) Field() []string {
+func (c *MinBytesValidator) Field() []string {
 	return c.field
 }
 
 // SetField of the field
-func (c *MinValidator) SetField(name ...string) {
+func (c *MinBytesValidator) SetField(name ...string) {
 	c.field = name
 }
 
 // SetMessage set error message
-func (c *MinValidator) SetMessage(msg string) Validator {
+func (c *MinBytesValidator) SetMessage(msg string) Validator {
 	c.message = msg
 	return c
 }
 
+// SetLabel set the label used in error messages
+func (c *MinBytesValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
 // SetOptional don't validate if the value is zero
-func (c *MinValidator) SetOptional() Validator {
+func (c *MinBytesValidator) SetOptional() Validator {
 	c.optional = true
 	return c
 }
 
 // Validate the value
-func (c *MinValidator) Validate(value any) Error {
-	rv := reflect.ValueOf(value)
-	newError := func() Error {
-		return createError(c.field, c.message, fmt.Sprintf("Please increase %s to be %v or more", jsonFieldName(c.field), c.min))
-	}
-	switch rv.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if isLess(toInt64(value), c.min, c.optional) {
-			return newError()
-		}
-	case reflect.Float32, reflect.Float64:
-		if isLess(toFloat64(value), float64(c.min), c.optional) {
-			return newError()
-		}
-	case reflect.Invalid:
-		if !c.optional {
-			return newError()
+func (c *MinBytesValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		} else {
+			return createError(c.field, c.message, fmt.Sprintf("Please lengthen %s to %d bytes or more", fieldLabel(c.field, c.label), c.min))
 		}
-	default:
-		panic(fmt.Errorf("type not supported: %v", rv.Type()))
+	}
+	if c.optional && str == "" {
+		return nil
+	}
+	if len(str) < int(c.min) {
+		return createError(c.field, c.message, fmt.Sprintf("Please lengthen %s to %d bytes or more", fieldLabel(c.field, c.label), c.min))
 	}
 	return nil
 }
 
 // MarshalJSON for this validator
-func (c *MinValidator) MarshalJSON() ([]byte, error) {
+func (c *MinBytesValidator) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		Rule    string `json:"rule"`
-		Min     int64  `json:"min"`
-		Message string `json:"message,omitempty"`
-	}{"min", c.min, c.message})
+		Rule     string `json:"rule"`
+		Min      int64  `json:"min"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+	}{"minBytes", c.min, c.message, c.label, c.optional})
 }
 
 // CanExport for this validator
-func (c *MinValidator) CanExport() bool {
+func (c *MinBytesValidator) CanExport() bool {
 	return true
 }
 
-// Min field have minimum value
-func Min(min int64) *MinValidator {
-	return &MinValidator{
-		min: min,
+// Params of this validator
+func (c *MinBytesValidator) Params() map[string]any {
+	return map[string]any{"min": c.min, "optional": c.optional}
+}
+
+// MinBytes field must have minimum length in bytes
+func MinBytes(min int64) *MinBytesValidator {
+	return &MinBytesValidator{
+		min:      min,
+		optional: config.OptionalByDefault,
 	}
 }
 
 //
-// ==================== Max ====================
+// ==================== MaxBytes ====================
 //
 
-// MaxValidator field have maximum value
-type MaxValidator struct {
+// MaxBytesValidator field have maximum length in bytes
+type MaxBytesValidator struct {
 	field   []string
 	message string
+	label   string
 	max     int64
 }
 
 // Field of the field
-func (c *MaxValidator) Field() []string {
+func (c *MaxBytesValidator) Field() []string {
 	return c.field
 }
 
 // SetField of the field
-func (c *MaxValidator) SetField(name ...string) {
+func (c *MaxBytesValidator) SetField(name ...string) {
 	c.field = name
 }
 
 // SetMessage set error message
-func (c *MaxValidator) SetMessage(msg string) Validator {
+func (c *MaxBytesValidator) SetMessage(msg string) Validator {
 	c.message = msg
 	return c
 }
 
+// SetLabel set the label used in error messages
+func (c *MaxBytesValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
 // Validate the value
-func (c *MaxValidator) Validate(value any) Error {
-	rv := reflect.ValueOf(value)
-	newError := func() Error {
-		return createError(c.field, c.message, fmt.Sprintf("Please decrease %s to be %v or less", jsonFieldName(c.field), c.max))
-	}
-	switch rv.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if isMore(toInt64(value), c.max) {
-			return newError()
-		}
-	case reflect.Float32, reflect.Float64:
-		if isMore(toFloat64(value), float64(c.max)) {
-			return newError()
+func (c *MaxBytesValidator) Validate(value any) Error {
+	v, ok := value.(string)
+	if !ok {
+		if passMismatch(value, true) {
+			return nil
 		}
-	case reflect.Invalid:
-		return nil
-	default:
-		panic(fmt.Errorf("type not supported: %v", rv.Type()))
+		return createError(c.field, c.message, fmt.Sprintf("Please shorten %s to %d bytes or less", fieldLabel(c.field, c.label), c.max))
+	}
+	if len(v) > int(c.max) {
+		return createError(c.field, c.message, fmt.Sprintf("Please shorten %s to %d bytes or less", fieldLabel(c.field, c.label), c.max))
 	}
 	return nil
 }
 
 // MarshalJSON for this validator
-func (c *MaxValidator) MarshalJSON() ([]byte, error) {
+func (c *MaxBytesValidator) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Rule    string `json:"rule"`
 		Max     int64  `json:"max"`
 		Message string `json:"message,omitempty"`
-	}{"max", c.max, c.message})
+		Label   string `json:"label,omitempty"`
+	}{"maxBytes", c.max, c.message, c.label})
 }
 
 // CanExport for this validator
-func (c *MaxValidator) CanExport() bool {
+func (c *MaxBytesValidator) CanExport() bool {
 	return true
 }
 
-// Max field have maximum value
-func Max(max int64) *MaxValidator {
-	return &MaxValidator{
+// Params of this validator
+func (c *MaxBytesValidator) Params() map[string]any {
+	return map[string]any{"max": c.max}
+}
+
+// MaxBytes field have maximum length in bytes. Use this instead of MaxLength when the storage limit is in bytes.
+func MaxBytes(max int64) *MaxBytesValidator {
+	return &MaxBytesValidator{
 		max: max,
 	}
 }
 
 //
-// ==================== Pattern ====================
+// ==================== MinItems ====================
 //
 
-// PatternValidator field must match regexp
-type PatternValidator struct {
-	field    []string
-	message  string
-	re       *regexp.Regexp
-	optional bool
+// MinItemsValidator field must have minimum number of items
+type MinItemsValidator struct {
+	field   []string
+	message string
+	label   string
+	min     int64
 }
 
 // Field of the field
-func (c *PatternValidator) Field() []string {
+func (c *MinItemsValidator) Field() []string {
 	return c.field
 }
 
 // SetField of the field
-func (c *PatternValidator) SetField(name ...string) {
+func (c *MinItemsValidator) SetField(name ...string) {
 	c.field = name
 }
 
 // SetMessage set error message
-func (c *PatternValidator) SetMessage(msg string) Validator {
+func (c *MinItemsValidator) SetMessage(msg string) Validator {
 	c.message = msg
 	return c
 }
 
-// SetOptional don't validate if the value is zero
-func (c *PatternValidator) SetOptional() Validator {
-	c.optional = true
+// SetLabel set the label used in error messages
+func (c *MinItemsValidator) SetLabel(label string) Validator {
+	c.label = label
 	return c
 }
 
 // Validate the value
-func (c *PatternValidator) Validate(value any) Error {
-	str, ok := value.(string)
-	if !ok {
-		if c.optional {
-			return nil
-		} else {
-			return createError(c.field, c.message, fmt.Sprintf("Please correct %s into a valid format", jsonFieldName(c.field)))
-		}
+func (c *MinItemsValidator) Validate(value any) Error {
+	n, ok := countItems(value)
+	if (!ok && !passMismatch(value, false)) || (ok && n < c.min) {
+		return createError(c.field, c.message, fmt.Sprintf("Please add at least %d items to %s", c.min, fieldLabel(c.field, c.label)))
 	}
-	if c.optional && str == "" {
-		return nil
-	}
-	if c.re.MatchString(str) {
-		return nil
-	}
-	return createError(c.field, c.message, fmt.Sprintf("Please correct %s into a valid format", jsonFieldName(c.field)))
+	return nil
 }
 
 // MarshalJSON for this validator
-func (c *PatternValidator) MarshalJSON() ([]byte, error) {
+func (c *MinItemsValidator) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Rule    string `json:"rule"`
-		Pattern string `json:"pattern"`
+		Min     int64  `json:"min"`
 		Message string `json:"message,omitempty"`
-	}{"pattern", c.re.String(), c.message})
+		Label   string `json:"label,omitempty"`
+	}{"minItems", c.min, c.message, c.label})
 }
 
 // CanExport for this validator
-func (c *PatternValidator) CanExport() bool {
+func (c *MinItemsValidator) CanExport() bool {
 	return true
 }
 
-// Pattern field must match regexp
-func Pattern(pattern string) *PatternValidator {
-	return &PatternValidator{
-		re: regexp.MustCompile(pattern),
+// Params of this validator
+func (c *MinItemsValidator) Params() map[string]any {
+	return map[string]any{"min": c.min}
+}
+
+// MinItems field must have minimum number of items. Works with slices, arrays and maps.
+func MinItems(min int64) *MinItemsValidator {
+	return &MinItemsValidator{
+		min: min,
 	}
 }
 
 //
-// ==================== Email ====================
+// ==================== MaxItems ====================
 //
 
-// EmailValidator field must be a valid email address
-type EmailValidator struct {
-	Validator
-	field    []string
-	message  string
-	optional bool
-}
-
-var emailRegex = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
-
-// Email field must be a valid email address
-func Email() *EmailValidator {
-	return &EmailValidator{}
+// MaxItemsValidator field must have maximum number of items
+type MaxItemsValidator struct {
+	field   []string
+	message string
+	label   string
+	max     int64
 }
 
 // Field of the field
-func (c *EmailValidator) Field() []string {
+func (c *MaxItemsValidator) Field() []string {
 	return c.field
 }
 
 // SetField of the field
-func (c *EmailValidator) SetField(name ...string) {
+func (c *MaxItemsValidator) SetField(name ...string) {
 	c.field = name
 }
 
 // SetMessage set error message
-func (c *EmailValidator) SetMessage(msg string) Validator {
+func (c *MaxItemsValidator) SetMessage(msg string) Validator {
 	c.message = msg
 	return c
 }
 
-// SetOptional don't validate if the value is zero
-func (c *EmailValidator) SetOptional() Validator {
-	c.optional = true
+// SetLabel set the label used in error messages
+func (c *MaxItemsValidator) SetLabel(label string) Validator {
+	c.label = label
 	return c
 }
 
 // Validate the value
-func (c *EmailValidator) Validate(value any) Error {
-	str, ok := value.(string)
-	if !ok {
-		if c.optional {
-			return nil
-		} else {
-			return createError(c.field, c.message, fmt.Sprintf("Please use a valid email address for %s", jsonFieldName(c.field)))
-		}
-	}
-	if c.optional && str == "" {
-		return nil
-	}
-	if emailRegex.MatchString(str) {
-		return nil
+func (c *MaxItemsValidator) Validate(value any) Error {
+	n, ok := countItems(value)
+	if (!ok && !passMismatch(value, true)) || (ok && n > c.max) {
+		return createError(c.field, c.message, fmt.Sprintf("Please remove items from %s to have %d or less", fieldLabel(c.field, c.label), c.max))
 	}
-	return createError(c.field, c.message, fmt.Sprintf("Please use a valid email address for %s", jsonFieldName(c.field)))
-}
-
-// CanExport for this validator
-func (c *EmailValidator) CanExport() bool {
-	return true
+	return nil
 }
 
 // MarshalJSON for this validator
-func (c *EmailValidator) MarshalJSON() ([]byte, error) {
+func (c *MaxItemsValidator) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Rule    string `json:"rule"`
-		Type    string `json:"type"`
-		Pattern string `json:"pattern"`
+		Max     int64  `json:"max"`
 		Message string `json:"message,omitempty"`
-	}{"type", "email", emailRegex.String(), c.message})
+		Label   string `json:"label,omitempty"`
+	}{"maxItems", c.max, c.message, c.label})
 }
 
-// IsEmail returns true if the string is an email
-func IsEmail(email string) bool {
-	return emailRegex.MatchString(email)
+// CanExport for this validator
+func (c *MaxItemsValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *MaxItemsValidator) Params() map[string]any {
+	return map[string]any{"max": c.max}
+}
+
+// MaxItems field must have maximum number of items. Works with slices, arrays and maps.
+func MaxItems(max int64) *MaxItemsValidator {
+	return &MaxItemsValidator{
+		max: max,
+	}
 }
 
 //
-// ==================== Options ====================
+// ==================== Min ====================
 //
 
-// OptionsValidator for whitelisting accepted values
-type OptionsValidator struct {
-	field   []string
-	message string
-	options []any
+// MinValidator field have minimum value
+type MinValidator struct {
+	field     []string
+	message   string
+	label     string
+	min       int64
+	optional  bool
+	duration  bool
+	exclusive bool
 }
 
 // Field of the field
-func (c *OptionsValidator) Field() []string {
+func (c *MinValidator) Field() []string {
 	return c.field
 }
 
 // SetField of the field
-func (c *OptionsValidator) SetField(name ...string) {
+func (c *MinValidator) SetField(name ...string) {
 	c.field = name
 }
 
 // SetMessage set error message
-func (c *OptionsValidator) SetMessage(msg string) Validator {
+func (c *MinValidator) SetMessage(msg string) Validator {
 	c.message = msg
 	return c
 }
 
-// Validate the value
-func (c *OptionsValidator) Validate(value any) Error {
-	v := reflect.ValueOf(value)
-	actual := v.Interface()
-	for _, opt := range c.options {
-		if opt == actual {
-			return nil
-		}
-	}
-	return createError(c.field, c.message, fmt.Sprintf("Please select one of the valid options for %s", jsonFieldName(c.field)))
+// SetLabel set the label used in error messages
+func (c *MinValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
 }
 
-// CanExport for this validator
-func (c *OptionsValidator) CanExport() bool {
-	return true
+// SetOptional don't validate if the value is zero
+func (c *MinValidator) SetOptional() Validator {
+	c.optional = true
+	return c
 }
 
-// MarshalJSON for this validator
-func (c *OptionsValidator) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct {
-		Rule    string `json:"rule"`
-		Options []any  `json:"options"`
-		Message string `json:"message,omitempty"`
-	}{"options", c.options, c.message})
+// Exclusive makes the value fail when it equals the minimum
+func (c *MinValidator) Exclusive() *MinValidator {
+	c.exclusive = true
+	return c
 }
 
-// Options for whitelisting accepted values
-func Options(options ...any) Validator {
-	return &OptionsValidator{
-		options: options,
+// Validate the value
+func (c *MinValidator) Validate(value any) Error {
+	rv := reflect.ValueOf(value)
+	newError := func() Error {
+		if c.exclusive && isDuration(value, c.duration) {
+			return createError(c.field, c.message, fmt.Sprintf("Please increase %s to be more than %s", fieldLabel(c.field, c.label), formatDuration(c.min)))
+		}
+		if c.exclusive {
+			return createError(c.field, c.message, fmt.Sprintf("Please increase %s to be more than %v", fieldLabel(c.field, c.label), c.min))
+		}
+		if isDuration(value, c.duration) {
+			return createError(c.field, c.message, fmt.Sprintf("Please increase %s to be at least %s", fieldLabel(c.field, c.label), formatDuration(c.min)))
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please increase %s to be %v or more", fieldLabel(c.field, c.label), c.min))
 	}
-}
-
-//
-// ==================== FieldFunc ====================
-//
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isLess(toInt64(value), c.min, c.optional, c.exclusive) {
+			return newError()
+		}
+	case reflect.Float32, reflect.Float64:
+		if isLess(toFloat64(value), float64(c.min), c.optional, c.exclusive) {
+			return newError()
+		}
+	case reflect.Invalid:
+		if !c.optional {
+			return newError()
+		}
+	default:
+		if config.MismatchPolicy == MismatchDefault {
+			panic(fmt.Errorf("type not supported: %v", rv.Type()))
+		}
+		if !passMismatch(value, false) {
+			return newError()
+		}
+	}
+	return nil
+}
+
+// MarshalJSON for this validator
+func (c *MinValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule      string `json:"rule"`
+		Min       int64  `json:"min"`
+		Exclusive bool   `json:"exclusive,omitempty"`
+		Duration  string `json:"duration,omitempty"`
+		Message   string `json:"message,omitempty"`
+		Label     string `json:"label,omitempty"`
+		Optional  bool   `json:"optional,omitempty"`
+	}{"min", c.min, c.exclusive, exportDuration(c.min, c.duration), c.message, c.label, c.optional})
+}
+
+// CanExport for this validator
+func (c *MinValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *MinValidator) Params() map[string]any {
+	return map[string]any{"min": c.min, "exclusive": c.exclusive, "duration": c.duration, "optional": c.optional}
+}
+
+// Min field have minimum value
+func Min(min int64) *MinValidator {
+	return &MinValidator{
+		min:      min,
+		optional: config.OptionalByDefault,
+	}
+}
+
+// MinDuration field have minimum time.Duration value
+func MinDuration(min time.Duration) *MinValidator {
+	return &MinValidator{
+		min:      int64(min),
+		optional: config.OptionalByDefault,
+		duration: true,
+	}
+}
+
+//
+// ==================== Max ====================
+//
+
+// MaxValidator field have maximum value
+type MaxValidator struct {
+	field     []string
+	message   string
+	label     string
+	max       int64
+	duration  bool
+	exclusive bool
+}
+
+// Field of the field
+func (c *MaxValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *MaxValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *MaxValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *MaxValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Exclusive makes the value fail when it equals the maximum
+func (c *MaxValidator) Exclusive() *MaxValidator {
+	c.exclusive = true
+	return c
+}
+
+// Validate the value
+func (c *MaxValidator) Validate(value any) Error {
+	rv := reflect.ValueOf(value)
+	newError := func() Error {
+		if c.exclusive && isDuration(value, c.duration) {
+			return createError(c.field, c.message, fmt.Sprintf("Please decrease %s to be less than %s", fieldLabel(c.field, c.label), formatDuration(c.max)))
+		}
+		if c.exclusive {
+			return createError(c.field, c.message, fmt.Sprintf("Please decrease %s to be less than %v", fieldLabel(c.field, c.label), c.max))
+		}
+		if isDuration(value, c.duration) {
+			return createError(c.field, c.message, fmt.Sprintf("Please decrease %s to be at most %s", fieldLabel(c.field, c.label), formatDuration(c.max)))
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please decrease %s to be %v or less", fieldLabel(c.field, c.label), c.max))
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isMore(toInt64(value), c.max, c.exclusive) {
+			return newError()
+		}
+	case reflect.Float32, reflect.Float64:
+		if isMore(toFloat64(value), float64(c.max), c.exclusive) {
+			return newError()
+		}
+	case reflect.Invalid:
+		return nil
+	default:
+		if config.MismatchPolicy == MismatchDefault {
+			panic(fmt.Errorf("type not supported: %v", rv.Type()))
+		}
+		if !passMismatch(value, false) {
+			return newError()
+		}
+	}
+	return nil
+}
+
+// MarshalJSON for this validator
+func (c *MaxValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule      string `json:"rule"`
+		Max       int64  `json:"max"`
+		Exclusive bool   `json:"exclusive,omitempty"`
+		Duration  string `json:"duration,omitempty"`
+		Message   string `json:"message,omitempty"`
+		Label     string `json:"label,omitempty"`
+	}{"max", c.max, c.exclusive, exportDuration(c.max, c.duration), c.message, c.label})
+}
+
+// CanExport for this validator
+func (c *MaxValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *MaxValidator) Params() map[string]any {
+	return map[string]any{"max": c.max, "exclusive": c.exclusive, "duration": c.duration}
+}
+
+// Max field have maximum value
+func Max(max int64) *MaxValidator {
+	return &MaxValidator{
+		max: max,
+	}
+}
+
+// MaxDuration field have maximum time.Duration value
+func MaxDuration(max time.Duration) *MaxValidator {
+	return &MaxValidator{
+		max:      int64(max),
+		duration: true,
+	}
+}
+
+//
+// ==================== Pattern ====================
+//
+
+// PatternValidator field must match regexp
+type PatternValidator struct {
+	field    []string
+	message  string
+	label    string
+	re       *regexp.Regexp
+	optional bool
+}
+
+// Field of the field
+func (c *PatternValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *PatternValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *PatternValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *PatternValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *PatternValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Validate the value
+func (c *PatternValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		} else {
+			return createError(c.field, c.message, fmt.Sprintf("Please correct %s into a valid format", fieldLabel(c.field, c.label)))
+		}
+	}
+	if c.optional && str == "" {
+		return nil
+	}
+	if c.re.MatchString(str) {
+		return nil
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please correct %s into a valid format", fieldLabel(c.field, c.label)))
+}
+
+// MarshalJSON for this validator
+func (c *PatternValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule     string `json:"rule"`
+		Pattern  string `json:"pattern"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+	}{"pattern", c.re.String(), c.message, c.label, c.optional})
+}
+
+// CanExport for this validator
+func (c *PatternValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *PatternValidator) Params() map[string]any {
+	return map[string]any{"pattern": c.re.String(), "optional": c.optional}
+}
+
+// Pattern field must match regexp
+func Pattern(pattern string) *PatternValidator {
+	return &PatternValidator{
+		re:       regexp.MustCompile(pattern),
+		optional: config.OptionalByDefault,
+	}
+}
+
+//
+// ==================== Email ====================
+//
+
+// EmailMode decides how strictly email addresses are checked
+type EmailMode int
+
+const (
+	// EmailRegex checks the address with a permissive regular expression
+	EmailRegex EmailMode = iota
+	// EmailRFC5322 parses the address with net/mail, without allowing a display name
+	EmailRFC5322
+	// EmailIDN allows internationalized domain names, which are checked in their punycode form
+	EmailIDN
+)
+
+var emailModeNames = map[EmailMode]string{EmailRFC5322: "rfc5322", EmailIDN: "idn"}
+
+// EmailValidator field must be a valid email address
+type EmailValidator struct {
+	Validator
+	field    []string
+	message  string
+	label    string
+	optional bool
+	mode     EmailMode
+}
+
+var emailRegex = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
+
+// Email field must be a valid email address
+func Email() *EmailValidator {
+	return &EmailValidator{
+		optional: config.OptionalByDefault,
+	}
+}
+
+// Field of the field
+func (c *EmailValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *EmailValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *EmailValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *EmailValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *EmailValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Mode sets how strictly the address is checked
+func (c *EmailValidator) Mode(mode EmailMode) *EmailValidator {
+	c.mode = mode
+	return c
+}
+
+// Validate the value
+func (c *EmailValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		} else {
+			return createError(c.field, c.message, fmt.Sprintf("Please use a valid email address for %s", fieldLabel(c.field, c.label)))
+		}
+	}
+	if c.optional && str == "" {
+		return nil
+	}
+	if isEmailMode(str, c.mode) {
+		return nil
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please use a valid email address for %s", fieldLabel(c.field, c.label)))
+}
+
+// CanExport for this validator
+func (c *EmailValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *EmailValidator) Params() map[string]any {
+	return map[string]any{"pattern": emailRegex.String(), "mode": c.mode, "optional": c.optional}
+}
+
+// MarshalJSON for this validator
+func (c *EmailValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule     string `json:"rule"`
+		Type     string `json:"type"`
+		Pattern  string `json:"pattern"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Mode     string `json:"mode,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+	}{"type", "email", emailRegex.String(), c.message, c.label, emailModeNames[c.mode], c.optional})
+}
+
+// IsEmail returns true if the string is an email
+func IsEmail(email string) bool {
+	return emailRegex.MatchString(email)
+}
+
+func isEmailMode(email string, mode EmailMode) bool {
+	switch mode {
+	case EmailRFC5322:
+		addr, err := mail.ParseAddress(email)
+		return err == nil && addr.String() == "<"+email+">"
+	case EmailIDN:
+		at := strings.LastIndex(email, "@")
+		if at < 0 {
+			return false
+		}
+		domain, err := idna.Lookup.ToASCII(email[at+1:])
+		return err == nil && emailRegex.MatchString(email[:at+1]+domain)
+	default:
+		return emailRegex.MatchString(email)
+	}
+}
+
+//
+// ==================== Normalized ====================
+//
+
+// NormalizedValidator field must be in a Unicode normalization form
+type NormalizedValidator struct {
+	field    []string
+	message  string
+	label    string
+	form     norm.Form
+	optional bool
+}
+
+// Field of the field
+func (c *NormalizedValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *NormalizedValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *NormalizedValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *NormalizedValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// SetOptional don't validate if the value is zero
+func (c *NormalizedValidator) SetOptional() Validator {
+	c.optional = true
+	return c
+}
+
+// Validate the value
+func (c *NormalizedValidator) Validate(value any) Error {
+	str, ok := value.(string)
+	if !ok {
+		if passMismatch(value, c.optional) {
+			return nil
+		}
+		return createError(c.field, c.message, fmt.Sprintf("Please correct the special characters in %s", fieldLabel(c.field, c.label)))
+	}
+	if c.form.IsNormalString(str) {
+		return nil
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please correct the special characters in %s", fieldLabel(c.field, c.label)))
+}
+
+// MarshalJSON for this validator
+func (c *NormalizedValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule     string `json:"rule"`
+		Form     string `json:"form"`
+		Message  string `json:"message,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+	}{"normalized", normFormNames[c.form], c.message, c.label, c.optional})
+}
+
+// CanExport for this validator
+func (c *NormalizedValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *NormalizedValidator) Params() map[string]any {
+	return map[string]any{"form": c.form, "optional": c.optional}
+}
+
+var normFormNames = map[norm.Form]string{norm.NFC: "NFC", norm.NFD: "NFD", norm.NFKC: "NFKC", norm.NFKD: "NFKD"}
+
+// Normalized field must be in the Unicode normalization form, so visually identical strings have the same bytes
+func Normalized(form norm.Form) *NormalizedValidator {
+	return &NormalizedValidator{
+		form:     form,
+		optional: config.OptionalByDefault,
+	}
+}
+
+// NFC field must be in Unicode normalization form C, the form most text input produces
+func NFC() *NormalizedValidator {
+	return Normalized(norm.NFC)
+}
+
+//
+// ==================== Options ====================
+//
+
+// OptionsValidator for whitelisting accepted values
+type OptionsValidator struct {
+	field   []string
+	message string
+	label   string
+	options []any
+	source  func() []any
+	equal   func(a, b any) bool
+	noCase  bool
+}
+
+// Field of the field
+func (c *OptionsValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *OptionsValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *OptionsValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *OptionsValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// EqualFunc compares the value against each option with a custom function instead of strict equality.
+// The first argument is the value being validated and the second is the option. It can't be combined with
+// CaseInsensitive, fold the case in the function instead.
+func (c *OptionsValidator) EqualFunc(equal func(a, b any) bool) *OptionsValidator {
+	if c.noCase {
+		panic(fmt.Errorf("options can't use EqualFunc with CaseInsensitive"))
+	}
+	c.equal = equal
+	return c
+}
+
+// CaseInsensitive compares string values without case, including values of named string types. It can't be combined
+// with EqualFunc.
+func (c *OptionsValidator) CaseInsensitive() *OptionsValidator {
+	if c.equal != nil {
+		panic(fmt.Errorf("options can't use CaseInsensitive with EqualFunc"))
+	}
+	c.noCase = true
+	return c
+}
+
+// Validate the value
+func (c *OptionsValidator) Validate(value any) Error {
+	v := reflect.ValueOf(value)
+	actual := v.Interface()
+	for _, opt := range c.getOptions() {
+		if c.equal != nil {
+			if c.equal(actual, opt) {
+				return nil
+			}
+		} else if c.noCase && equalFold(actual, opt) {
+			return nil
+		} else if opt == actual {
+			return nil
+		}
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please select one of the valid options for %s", fieldLabel(c.field, c.label)))
+}
+
+// CanExport for this validator
+func (c *OptionsValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *OptionsValidator) Params() map[string]any {
+	return map[string]any{"options": c.getOptions(), "caseInsensitive": c.noCase}
+}
+
+// MarshalJSON for this validator
+func (c *OptionsValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule            string `json:"rule"`
+		Options         []any  `json:"options"`
+		CaseInsensitive bool   `json:"caseInsensitive,omitempty"`
+		Message         string `json:"message,omitempty"`
+		Label           string `json:"label,omitempty"`
+	}{"options", c.getOptions(), c.noCase, c.message, c.label})
+}
+
+func (c *OptionsValidator) getOptions() []any {
+	if c.source != nil {
+		return c.source()
+	}
+	return c.options
+}
+
+// Options for whitelisting accepted values
+func Options(options ...any) *OptionsValidator {
+	return &OptionsValidator{
+		options: options,
+	}
+}
+
+// OptionsFromMap for whitelisting the keys of a map. Keys are sorted so the exported rules are stable.
+func OptionsFromMap[K constraints.Ordered, V any](m map[K]V) *OptionsValidator {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	options := make([]any, len(keys))
+	for i, k := range keys {
+		options[i] = k
+	}
+	return Options(options...)
+}
+
+// OptionsFromStringers for whitelisting the string values of a list
+func OptionsFromStringers[T fmt.Stringer](values []T) *OptionsValidator {
+	options := make([]any, len(values))
+	for i, v := range values {
+		options[i] = v.String()
+	}
+	return Options(options...)
+}
+
+// OptionsFunc for whitelisting values returned by a function. The function is called each time the rule is validated
+// or exported so the options are always up to date.
+func OptionsFunc(f func() []any) *OptionsValidator {
+	return &OptionsValidator{
+		source: f,
+	}
+}
+
+//
+// ==================== NotOptions ====================
+//
+
+// NotOptionsValidator for blacklisting values, e.g. reserved names
+type NotOptionsValidator struct {
+	field   []string
+	message string
+	label   string
+	options []any
+	noCase  bool
+}
+
+// Field of the field
+func (c *NotOptionsValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *NotOptionsValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *NotOptionsValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *NotOptionsValidator) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// CaseInsensitive compares string values without case, including values of named string types
+func (c *NotOptionsValidator) CaseInsensitive() *NotOptionsValidator {
+	c.noCase = true
+	return c
+}
+
+// Validate the value
+func (c *NotOptionsValidator) Validate(value any) Error {
+	for _, opt := range c.options {
+		if opt == value || c.noCase && equalFold(value, opt) {
+			return createError(c.field, c.message, fmt.Sprintf("Please choose a different %s", fieldLabel(c.field, c.label)))
+		}
+	}
+	return nil
+}
+
+// CanExport for this validator
+func (c *NotOptionsValidator) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *NotOptionsValidator) Params() map[string]any {
+	return map[string]any{"options": c.options, "caseInsensitive": c.noCase}
+}
+
+// MarshalJSON for this validator
+func (c *NotOptionsValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule            string `json:"rule"`
+		Options         []any  `json:"options"`
+		CaseInsensitive bool   `json:"caseInsensitive,omitempty"`
+		Message         string `json:"message,omitempty"`
+		Label           string `json:"label,omitempty"`
+	}{"notOptions", c.options, c.noCase, c.message, c.label})
+}
+
+// NotOptions for blacklisting values
+func NotOptions(options ...any) *NotOptionsValidator {
+	return &NotOptionsValidator{
+		options: options,
+	}
+}
+
+//
+// ==================== OptionsOf ====================
+//
+
+// OptionsOfValidator for whitelisting accepted values of a specific type
+type OptionsOfValidator[T comparable] struct {
+	field   []string
+	message string
+	label   string
+	options []T
+}
+
+// Field of the field
+func (c *OptionsOfValidator[T]) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *OptionsOfValidator[T]) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *OptionsOfValidator[T]) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *OptionsOfValidator[T]) SetLabel(label string) Validator {
+	c.label = label
+	return c
+}
+
+// Validate the value
+func (c *OptionsOfValidator[T]) Validate(value any) Error {
+	if v, ok := convertOption[T](value); ok {
+		for _, opt := range c.options {
+			if opt == v {
+				return nil
+			}
+		}
+	}
+	return createError(c.field, c.message, fmt.Sprintf("Please select one of the valid options for %s", fieldLabel(c.field, c.label)))
+}
+
+// CanExport for this validator
+func (c *OptionsOfValidator[T]) CanExport() bool {
+	return true
+}
+
+// Params of this validator
+func (c *OptionsOfValidator[T]) Params() map[string]any {
+	return map[string]any{"options": c.optionValues()}
+}
+
+// MarshalJSON for this validator
+func (c *OptionsOfValidator[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule    string `json:"rule"`
+		Options []T    `json:"options"`
+		Message string `json:"message,omitempty"`
+		Label   string `json:"label,omitempty"`
+	}{"options", c.options, c.message, c.label})
+}
+
+func (c *OptionsOfValidator[T]) optionValues() []any {
+	values := make([]any, len(c.options))
+	for i, opt := range c.options {
+		values[i] = opt
+	}
+	return values
+}
+
+// convertOption converts the value to T if it has the same kind, e.g. a named string type, or if both are integers and
+// the value fits in T, e.g. an int64 field with OptionsOf(1, 2)
+func convertOption[T comparable](value any) (T, bool) {
+	var zero T
+	if v, ok := value.(T); ok {
+		return v, true
+	}
+	rv := reflect.ValueOf(value)
+	t := reflect.TypeOf(zero)
+	if !rv.IsValid() || t == nil {
+		return zero, false
+	}
+	target := reflect.New(t).Elem()
+	switch {
+	case rv.Kind() == t.Kind() && rv.Type().ConvertibleTo(t):
+		target.Set(rv.Convert(t))
+	case isSigned(rv.Kind()) && isSigned(t.Kind()) && !target.OverflowInt(rv.Int()):
+		target.SetInt(rv.Int())
+	case isSigned(rv.Kind()) && isUnsigned(t.Kind()) && rv.Int() >= 0 && !target.OverflowUint(uint64(rv.Int())):
+		target.SetUint(uint64(rv.Int()))
+	case isUnsigned(rv.Kind()) && isUnsigned(t.Kind()) && !target.OverflowUint(rv.Uint()):
+		target.SetUint(rv.Uint())
+	case isUnsigned(rv.Kind()) && isSigned(t.Kind()) && rv.Uint() <= math.MaxInt64 && !target.OverflowInt(int64(rv.Uint())):
+		target.SetInt(int64(rv.Uint()))
+	default:
+		return zero, false
+	}
+	return target.Interface().(T), true
+}
+
+func isSigned(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUnsigned(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// OptionsOf for whitelisting accepted values of the type of the options. Values of the same kind, such as a named
+// string type, and integers that fit in the type are converted before they are compared. Other values are rejected.
+func OptionsOf[T comparable](options ...T) *OptionsOfValidator[T] {
+	return &OptionsOfValidator[T]{
+		options: options,
+	}
+}
+
+//
+// ==================== If ====================
+//
+
+// ConditionalValidator applies validators depending on whether a condition passes
+type ConditionalValidator struct {
+	field     []string
+	fieldPtr  any
+	fieldName string
+	predicate Validator
+	then      []Validator
+	otherwise []Validator
+}
+
+// Field of the field
+func (c *ConditionalValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ConditionalValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.branches() {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators in both branches
+func (c *ConditionalValidator) SetMessage(msg string) Validator {
+	for _, v := range c.branches() {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ConditionalValidator) SetLabel(label string) Validator {
+	for _, v := range c.branches() {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Then validators are used when the condition passes
+func (c *ConditionalValidator) Then(validators ...Validator) *ConditionalValidator {
+	c.then = append(c.then, validators...)
+	return c
+}
+
+// Else validators are used when the condition fails
+func (c *ConditionalValidator) Else(validators ...Validator) *ConditionalValidator {
+	c.otherwise = append(c.otherwise, validators...)
+	return c
+}
+
+// Validate the value. The condition is checked against the same value since other fields are not available.
+func (c *ConditionalValidator) Validate(value any) Error {
+	branch := c.otherwise
+	if c.predicate.Validate(value) == nil {
+		branch = c.then
+	}
+	for _, v := range branch {
+		if err := v.Validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ConditionalValidator) resolveFields(structPtr any) {
+	if c.fieldPtr != nil {
+		c.predicate.SetField(getField(structPtr, c.fieldPtr)...)
+	} else {
+		c.predicate.SetField(c.field...)
+	}
+	for _, v := range c.branches() {
+		if fv, ok := v.(fieldsValidator); ok {
+			fv.resolveFields(structPtr)
+		}
+	}
+}
+
+// loadStruct finds the field of the condition by its JSON name and passes the struct on to the branches
+func (c *ConditionalValidator) loadStruct(structType reflect.Type, fieldType reflect.Type) error {
+	if c.fieldName == "" {
+		c.predicate.SetField(c.field...)
+	} else if field, _, err := findFieldPath(structType, c.fieldName); err == nil {
+		c.predicate.SetField(field...)
+	} else {
+		return err
+	}
+	for _, v := range c.branches() {
+		if sl, ok := v.(structLoader); ok {
+			if err := sl.loadStruct(structType, fieldType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *ConditionalValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *ConditionalValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	branch := c.otherwise
+	if cond, ok := fieldValue(vmap, c.predicate.Field()); ok && c.predicate.Validate(cond) == nil {
+		branch = c.then
+	}
+	return validateAll(ctx, branch, c.field, vmap)
+}
+
+func (c *ConditionalValidator) clone() Validator {
+	clone := *c
+	clone.predicate = cloneValidator(c.predicate)
+	clone.then = make([]Validator, len(c.then))
+	for i, v := range c.then {
+		clone.then[i] = cloneValidator(v)
+	}
+	clone.otherwise = make([]Validator, len(c.otherwise))
+	for i, v := range c.otherwise {
+		clone.otherwise[i] = cloneValidator(v)
+	}
+	return &clone
+}
+
+func (c *ConditionalValidator) branches() []Validator {
+	return append(append([]Validator{}, c.then...), c.otherwise...)
+}
+
+// CanExport for this validator
+func (c *ConditionalValidator) CanExport() bool {
+	if !c.predicate.CanExport() {
+		return false
+	}
+	for _, v := range c.branches() {
+		if !v.CanExport() {
+			return false
+		}
+	}
+	return true
+}
+
+// Params of this validator
+func (c *ConditionalValidator) Params() map[string]any {
+	return map[string]any{"condition": c.predicate, "then": c.then, "else": c.otherwise}
+}
+
+// MarshalJSON for this validator
+func (c *ConditionalValidator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rule      string      `json:"rule"`
+		Field     string      `json:"field"`
+		Condition Validator   `json:"condition"`
+		Then      []Validator `json:"then,omitempty"`
+		Else      []Validator `json:"else,omitempty"`
+	}{"if", jsonFieldName(c.predicate.Field()), c.predicate, c.then, c.otherwise})
+}
+
+// If applies validators depending on whether the predicate passes for the given field.
+// Pass nil as the field to check the predicate against the field being validated.
+func If(fieldPtr any, predicate Validator) *ConditionalValidator {
+	return &ConditionalValidator{
+		fieldPtr:  fieldPtr,
+		predicate: predicate,
+		then:      make([]Validator, 0),
+		otherwise: make([]Validator, 0),
+	}
+}
+
+//
+// ==================== IfCtx ====================
+//
+
+// ContextConditionalValidator applies validators depending on the context given to ValidateCtx
+type ContextConditionalValidator struct {
+	field     []string
+	predicate func(ctx context.Context) bool
+	then      []Validator
+	otherwise []Validator
+}
+
+// Field of the field
+func (c *ContextConditionalValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ContextConditionalValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.branches() {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators in both branches
+func (c *ContextConditionalValidator) SetMessage(msg string) Validator {
+	for _, v := range c.branches() {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ContextConditionalValidator) SetLabel(label string) Validator {
+	for _, v := range c.branches() {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Then validators are used when the predicate returns true
+func (c *ContextConditionalValidator) Then(validators ...Validator) *ContextConditionalValidator {
+	c.then = append(c.then, validators...)
+	return c
+}
+
+// Else validators are used when the predicate returns false
+func (c *ContextConditionalValidator) Else(validators ...Validator) *ContextConditionalValidator {
+	c.otherwise = append(c.otherwise, validators...)
+	return c
+}
+
+// Validate the value with the predicate given a background context
+func (c *ContextConditionalValidator) Validate(value any) Error {
+	branch := c.otherwise
+	if c.predicate(context.Background()) {
+		branch = c.then
+	}
+	for _, v := range branch {
+		if err := v.Validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ContextConditionalValidator) resolveFields(structPtr any) {
+	for _, v := range c.branches() {
+		if fv, ok := v.(fieldsValidator); ok {
+			fv.resolveFields(structPtr)
+		}
+	}
+}
+
+func (c *ContextConditionalValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *ContextConditionalValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	branch := c.otherwise
+	if c.predicate(ctx) {
+		branch = c.then
+	}
+	return validateAll(ctx, branch, c.field, vmap)
+}
+
+func (c *ContextConditionalValidator) clone() Validator {
+	clone := *c
+	clone.then = make([]Validator, len(c.then))
+	for i, v := range c.then {
+		clone.then[i] = cloneValidator(v)
+	}
+	clone.otherwise = make([]Validator, len(c.otherwise))
+	for i, v := range c.otherwise {
+		clone.otherwise[i] = cloneValidator(v)
+	}
+	return &clone
+}
+
+func (c *ContextConditionalValidator) branches() []Validator {
+	return append(append([]Validator{}, c.then...), c.otherwise...)
+}
+
+// CanExport for this validator. The context is only known on the server.
+func (c *ContextConditionalValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *ContextConditionalValidator) Params() map[string]any {
+	return map[string]any{"then": c.then, "else": c.otherwise}
+}
+
+// IfCtx applies validators depending on the context given to ValidateCtx, e.g. the role of the caller or a feature
+// flag. Validate uses a background context.
+func IfCtx(predicate func(ctx context.Context) bool) *ContextConditionalValidator {
+	return &ContextConditionalValidator{
+		predicate: predicate,
+		then:      make([]Validator, 0),
+		otherwise: make([]Validator, 0),
+	}
+}
+
+// RequiredIfCtx field must not be zero when the predicate returns true for the context given to ValidateCtx
+func RequiredIfCtx(predicate func(ctx context.Context) bool) *ContextConditionalValidator {
+	return IfCtx(predicate).Then(Required())
+}
+
+//
+// ==================== When ====================
+//
+
+// WhenValidator applies validators when a condition on the whole subject holds
+type WhenValidator struct {
+	field      []string
+	cond       func(subject any) bool
+	validators []Validator
+}
+
+// Field of the field
+func (c *WhenValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *WhenValidator) SetField(name ...string) {
+	c.field = name
+	for _, v := range c.validators {
+		v.SetField(name...)
+	}
+}
+
+// SetMessage set error message of all validators
+func (c *WhenValidator) SetMessage(msg string) Validator {
+	for _, v := range c.validators {
+		v.SetMessage(msg)
+	}
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *WhenValidator) SetLabel(label string) Validator {
+	for _, v := range c.validators {
+		setLabel(v, label)
+	}
+	return c
+}
+
+// Validate the value. There is no subject outside of Rules, so the condition gets nil.
+func (c *WhenValidator) Validate(value any) Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value if the condition returns true for the subject being validated by Rules, e.g. when
+// When is held by another validator
+func (c *WhenValidator) ValidateCtx(ctx context.Context, value any) Error {
+	if !c.cond(ctx.Value(subjectKey{})) {
+		return nil
+	}
+	for _, v := range c.validators {
+		if err := validateValue(ctx, v, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *WhenValidator) resolveFields(structPtr any) {
+	for _, v := range c.validators {
+		if fv, ok := v.(fieldsValidator); ok {
+			fv.resolveFields(structPtr)
+		}
+	}
+}
+
+func (c *WhenValidator) validateFields(vmap map[string]any) ErrorSlice {
+	return c.validateContext(context.Background(), vmap)
+}
+
+func (c *WhenValidator) validateContext(ctx context.Context, vmap map[string]any) ErrorSlice {
+	if !c.cond(ctx.Value(subjectKey{})) {
+		return make(ErrorSlice, 0)
+	}
+	return validateAll(ctx, c.validators, c.field, vmap)
+}
+
+func (c *WhenValidator) clone() Validator {
+	clone := *c
+	clone.validators = make([]Validator, len(c.validators))
+	for i, v := range c.validators {
+		clone.validators[i] = cloneValidator(v)
+	}
+	return &clone
+}
+
+// CanExport for this validator. The condition is only known on the server.
+func (c *WhenValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *WhenValidator) Params() map[string]any {
+	return map[string]any{"rules": c.validators}
+}
+
+// When applies validators only when the condition returns true for the subject given to Validate, e.g. requiring a
+// shipping address only when the delivery method is "ship". Errors are on the field the validators are added to.
+// Pointers to the subject stay pointers, so the condition should expect the same type that is validated.
+func When(cond func(subject any) bool, validators ...Validator) *WhenValidator {
+	return &WhenValidator{
+		cond:       cond,
+		validators: validators,
+	}
+}
+
+// Unless applies validators only when the condition returns false for the subject given to Validate
+func Unless(cond func(subject any) bool, validators ...Validator) *WhenValidator {
+	return When(func(subject any) bool { return !cond(subject) }, validators...)
+}
+
+//
+// ==================== FieldFunc ====================
+//
 
 // FieldFuncValidator for validating with custom function
 type FieldFuncValidator struct {
@@ -609,6 +1945,11 @@ func (c *FieldFuncValidator) CanExport() bool {
 	return false
 }
 
+// Params of this validator
+func (c *FieldFuncValidator) Params() map[string]any {
+	return map[string]any{}
+}
+
 // FieldFunc for validating with custom function
 func FieldFunc(f func([]string, any) Error) Validator {
 	return &FieldFuncValidator{
@@ -616,6 +1957,61 @@ func FieldFunc(f func([]string, any) Error) Validator {
 	}
 }
 
+//
+// ==================== FieldFuncCtx ====================
+//
+
+// FieldFuncCtxValidator for validating with a custom function that uses the context given to ValidateCtx
+type FieldFuncCtxValidator struct {
+	field   []string
+	message string
+	checker func(context.Context, []string, any) Error
+}
+
+// Field of the field
+func (c *FieldFuncCtxValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *FieldFuncCtxValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *FieldFuncCtxValidator) SetMessage(msg string) Validator {
+	c.message = msg
+	return c
+}
+
+// Validate the value with a background context
+func (c *FieldFuncCtxValidator) Validate(value any) Error {
+	return c.checker(context.Background(), c.field, value)
+}
+
+// ValidateCtx validates the value with the context
+func (c *FieldFuncCtxValidator) ValidateCtx(ctx context.Context, value any) Error {
+	return c.checker(ctx, c.field, value)
+}
+
+// CanExport for this validator
+func (c *FieldFuncCtxValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *FieldFuncCtxValidator) Params() map[string]any {
+	return map[string]any{}
+}
+
+// FieldFuncCtx for validating with a custom function that gets the context, e.g. a uniqueness check in the database
+// that should stop when the request is cancelled
+func FieldFuncCtx(f func(context.Context, []string, any) Error) Validator {
+	return &FieldFuncCtxValidator{
+		checker: f,
+	}
+}
+
 //
 // ==================== StructFunc ====================
 //
@@ -653,6 +2049,11 @@ func (c *StructFuncValidator) CanExport() bool {
 	return false
 }
 
+// Params of this validator
+func (c *StructFuncValidator) Params() map[string]any {
+	return map[string]any{}
+}
+
 // StructFunc validate struct with custom function
 func StructFunc(f func(any) Error) Validator {
 	return &StructFuncValidator{
@@ -689,18 +2090,102 @@ func toFloat64(value any) float64 {
 	panic(fmt.Errorf("cannot convert %v to float64", v.Kind()))
 }
 
-func isLess[T number](value T, min T, optional bool) bool {
+// equalFold compares values of string kinds without case, and other types with strict equality
+func equalFold(a, b any) bool {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() == reflect.String && bv.Kind() == reflect.String {
+		return strings.EqualFold(av.String(), bv.String())
+	}
+	return a == b
+}
+
+// isDuration returns true if the bound or value is a time.Duration
+func isDuration(value any, duration bool) bool {
+	_, ok := value.(time.Duration)
+	return ok || duration
+}
+
+// formatDuration formats nanoseconds without trailing zero units, e.g. "5m" instead of "5m0s"
+func formatDuration(d int64) string {
+	s := time.Duration(d).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
+// exportDuration returns the formatted duration of duration rules
+func exportDuration(d int64, duration bool) string {
+	if !duration {
+		return ""
+	}
+	return formatDuration(d)
+}
+
+// countItems returns the number of items in a slice, array or map. Nil counts as no items.
+func countItems(value any) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return int64(v.Len()), true
+	case reflect.Invalid:
+		return 0, true
+	}
+	return 0, false
+}
+
+// hasNilElement returns true if a slice or array of pointers contains a nil element
+func hasNilElement(v reflect.Value) bool {
+	if v.Type().Elem().Kind() != reflect.Ptr {
+		return false
+	}
+	for i := 0; i < v.Len(); i++ {
+		if v.Index(i).IsNil() {
+			return true
+		}
+	}
+	return false
+}
+
+// timeValue returns the time held by a time.Time or non nil *time.Time
+func timeValue(v reflect.Value) (time.Time, bool) {
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return time.Time{}, false
+	}
+	t, ok := v.Interface().(time.Time)
+	return t, ok
+}
+
+// elementValue dereferences pointer elements of a collection so validators see the underlying value.
+// Nil elements become nil so they are treated as zero.
+func elementValue(v reflect.Value) any {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	}
+	return unwrapOptional(v.Interface())
+}
+
+func isLess[T number](value T, min T, optional bool, exclusive bool) bool {
 	if optional && value == 0 {
 		return false
 	}
-	if value < min {
+	if value < min || exclusive && value == min {
 		return true
 	}
 	return false
 }
 
-func isMore[T number](value T, max T) bool {
-	return value > max
+func isMore[T number](value T, max T, exclusive bool) bool {
+	return value > max || exclusive && value == max
 }
 
 type number interface {
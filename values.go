@@ -0,0 +1,113 @@
+package xvalid
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ShowValues adds the value and the limit it broke to default error messages, e.g. "Please shorten name to 5
+// characters or less ('abcdefg' is 7 characters, maximum is 5)", which speeds up debugging in internal tools. Custom
+// messages and the values of sensitive fields are left as is.
+func (r Rules) ShowValues() Rules {
+	r.showValues = true
+	return r
+}
+
+// addValues adds the value to the default messages of the errors
+func (r Rules) addValues(validator Validator, vmap map[string]any, errs ErrorSlice) ErrorSlice {
+	field := validator.Field()
+	if len(errs) == 0 || len(field) == 0 || hasCustomMessage(validator) || r.isSensitive(field) {
+		return errs
+	}
+	v, ok := fieldValue(vmap, field)
+	if !ok {
+		return errs
+	}
+	detail := valueDetail(validator, v)
+	shown := make(ErrorSlice, len(errs))
+	for i, err := range errs {
+		shown[i], _ = withMessage(err, fmt.Sprintf("%s (%s)", err.Error(), detail))
+	}
+	return shown
+}
+
+// hasCustomMessage returns true if the message of the validator was set with SetMessage
+func hasCustomMessage(validator Validator) bool {
+	v := reflect.Indirect(reflect.ValueOf(validator))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	m := v.FieldByName("message")
+	return m.IsValid() && m.Kind() == reflect.String && m.String() != ""
+}
+
+// valueDetail describes the value and the limit of the validator
+func valueDetail(validator Validator, value any) string {
+	shown := showValue(value)
+	switch c := validator.(type) {
+	case *MinLengthValidator:
+		if s, ok := value.(string); ok {
+			return fmt.Sprintf("%s is %d characters, minimum is %d", shown, utf8.RuneCountInString(s), c.min)
+		}
+	case *MaxLengthValidator:
+		if s, ok := value.(string); ok {
+			return fmt.Sprintf("%s is %d characters, maximum is %d", shown, utf8.RuneCountInString(s), c.max)
+		}
+	case *MinBytesValidator:
+		if s, ok := value.(string); ok {
+			return fmt.Sprintf("%s is %d bytes, minimum is %d", shown, len(s), c.min)
+		}
+	case *MaxBytesValidator:
+		if s, ok := value.(string); ok {
+			return fmt.Sprintf("%s is %d bytes, maximum is %d", shown, len(s), c.max)
+		}
+	case *MinItemsValidator:
+		if n, ok := itemCount(value); ok {
+			return fmt.Sprintf("has %d items, minimum is %d", n, c.min)
+		}
+	case *MaxItemsValidator:
+		if n, ok := itemCount(value); ok {
+			return fmt.Sprintf("has %d items, maximum is %d", n, c.max)
+		}
+	case *MinValidator:
+		return fmt.Sprintf("%s is below the minimum of %s", shown, formatLimit(c.min, c.duration))
+	case *MaxValidator:
+		return fmt.Sprintf("%s is above the maximum of %s", shown, formatLimit(c.max, c.duration))
+	case *PatternValidator:
+		return fmt.Sprintf("%s doesn't match %s", shown, c.re.String())
+	}
+	return fmt.Sprintf("value is %s", shown)
+}
+
+// itemCount returns the number of items of a slice, array or map
+func itemCount(value any) (int, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// formatLimit formats a limit that may be a duration
+func formatLimit(limit int64, duration bool) string {
+	if duration {
+		return fmt.Sprint(time.Duration(limit))
+	}
+	return fmt.Sprint(limit)
+}
+
+// showValue quotes strings and shortens long values
+func showValue(value any) string {
+	s := fmt.Sprint(value)
+	if r := []rune(s); len(r) > 40 {
+		s = string(r[:40]) + "…"
+	}
+	if _, ok := value.(string); ok {
+		return "'" + strings.ReplaceAll(s, "'", "\\'") + "'"
+	}
+	return s
+}
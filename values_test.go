@@ -0,0 +1,43 @@
+package xvalid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowValues(t *testing.T) {
+	type form struct {
+		Name     string   `json:"name"`
+		Age      int      `json:"age"`
+		Tags     []string `json:"tags"`
+		Code     string   `json:"code"`
+		Password string   `json:"password"`
+	}
+	f := form{}
+	rules := New(&f).
+		Field(&f.Name, MaxLength(5)).
+		Field(&f.Age, Min(18)).
+		Field(&f.Tags, MaxItems(1)).
+		Field(&f.Code, Pattern(`^[A-Z]+$`).SetMessage("Bad code")).
+		Field(&f.Password, MinLength(10)).
+		Sensitive(&f.Password)
+	subject := form{Name: "abcdefg", Age: 16, Tags: []string{"a", "b"}, Code: "x", Password: "secret"}
+
+	errs := rules.ShowValues().Validate(subject).(ErrorSlice)
+	assert.Equal(t, "Please shorten name to 5 characters or less ('abcdefg' is 7 characters, maximum is 5)", errs[0].Error(), "Length")
+	assert.Contains(t, errs[1].Error(), "(16 is below the minimum of 18)", "Value")
+	assert.Contains(t, errs[2].Error(), "(has 2 items, maximum is 1)", "Items")
+	assert.Equal(t, "Bad code", errs[3].Error(), "Custom message kept")
+	assert.NotContains(t, errs[4].Error(), "secret", "Sensitive value hidden")
+
+	errs = rules.Validate(subject).(ErrorSlice)
+	assert.Equal(t, "Please shorten name to 5 characters or less", errs[0].Error(), "Off by default")
+
+	// cached errors are shared between calls, so the value must only be added to copies
+	rules = New(&f).Field(&f.Name, Cached(MaxLength(5), time.Minute)).ShowValues()
+	rules.Validate(subject)
+	errs = rules.Validate(subject).(ErrorSlice)
+	assert.Equal(t, "Please shorten name to 5 characters or less (value is 'abcdefg')", errs[0].Error(), "Cached")
+}
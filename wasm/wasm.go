@@ -0,0 +1,94 @@
+//go:build js && wasm
+
+// Package wasm exposes rules to JavaScript when compiled with GOOS=js GOARCH=wasm, so the browser can run the exact
+// same rules as the server.
+//
+//	wasm.Register("user", user.Rules())
+//	select {} // keep the program running
+//
+// In JavaScript, validate a JSON string with xvalid.validate("user", json), which returns null if it's valid, or a
+// list of {field, message} errors. xvalid.rules("user") returns the exported rules.
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"syscall/js"
+
+	"github.com/AgentCosmic/xvalid/v2"
+)
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]xvalid.Rules)
+	once     sync.Once
+)
+
+// Register makes the rules available to JavaScript under the name
+func Register(name string, rules xvalid.Rules) {
+	mu.Lock()
+	registry[name] = rules
+	mu.Unlock()
+	once.Do(install)
+}
+
+// install the global xvalid object
+func install() {
+	obj := js.Global().Get("Object").New()
+	obj.Set("validate", js.FuncOf(validate))
+	obj.Set("rules", js.FuncOf(exportRules))
+	js.Global().Set("xvalid", obj)
+}
+
+// lookup the rules of the first argument
+func lookup(args []js.Value) (xvalid.Rules, error) {
+	if len(args) == 0 {
+		return xvalid.Rules{}, errors.New("xvalid: missing rules name")
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	rules, ok := registry[args[0].String()]
+	if !ok {
+		return rules, errors.New("xvalid: unknown rules " + args[0].String())
+	}
+	return rules, nil
+}
+
+// validate(name, json) returns null or a list of errors
+func validate(this js.Value, args []js.Value) any {
+	rules, err := lookup(args)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	if len(args) < 2 {
+		return js.Global().Get("Error").New("xvalid: missing JSON")
+	}
+	err = rules.ValidateJSON([]byte(args[1].String()))
+	if err == nil {
+		return js.Null()
+	}
+	var errs xvalid.ErrorSlice
+	if !errors.As(err, &errs) {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	return parse(errs)
+}
+
+// rules(name) returns the exported rules
+func exportRules(this js.Value, args []js.Value) any {
+	rules, err := lookup(args)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	return parse(rules)
+}
+
+// parse the JSON encoding of the value into a JavaScript value
+func parse(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	return js.Global().Get("JSON").Call("parse", string(b))
+}
@@ -0,0 +1,86 @@
+package xvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON writes the same JSON as Export to w, encoding one field at a time instead of building the whole document
+// in memory. Use Compact to leave out the indentation.
+func (r Rules) WriteJSON(w io.Writer, options ...ExportOption) error {
+	rmap, config, err := r.exportMap(options)
+	if err != nil {
+		return err
+	}
+	return rmap.writeJSON(w, !config.compact)
+}
+
+// WriteJSON writes the same JSON as MarshalJSON to w, encoding one error at a time instead of building the whole list
+// in memory
+func (e ErrorSlice) WriteJSON(w io.Writer) error {
+	if e == nil {
+		_, err := io.WriteString(w, "null")
+		return err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	buf.WriteByte('[')
+	for i, err := range e {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(err); err != nil {
+			return err
+		}
+		// the encoder ends every value with a new line
+		buf.Truncate(buf.Len() - 1)
+		if _, err := buf.WriteTo(w); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// writeJSON writes the map to w, indented the same way as json.MarshalIndent with a tab if indent is true
+func (m *orderedMap) writeJSON(w io.Writer, indent bool) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if indent {
+		enc.SetIndent("	", "	")
+	}
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if indent {
+			buf.WriteString("\n	")
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		if indent {
+			buf.WriteByte(' ')
+		}
+		if err := enc.Encode(m.values[k]); err != nil {
+			return err
+		}
+		// the encoder ends every value with a new line
+		buf.Truncate(buf.Len() - 1)
+		if _, err := buf.WriteTo(w); err != nil {
+			return err
+		}
+	}
+	if indent && len(m.keys) > 0 {
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('}')
+	_, err := buf.WriteTo(w)
+	return err
+}
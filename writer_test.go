@@ -0,0 +1,44 @@
+package xvalid
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJSON(t *testing.T) {
+	type writerType struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age"`
+		Tags  []string `json:"tags"`
+		Email string   `json:"email"`
+	}
+	s := writerType{}
+	rules := New(&s).
+		Field(&s.Name, Required(), MaxLength(5).SetMessage("<b>Too long</b>")).
+		Field(&s.Age, Min(18)).
+		Field(&s.Tags, MaxItems(3), Options("a", "b")).
+		Field(&s.Email, Email())
+
+	for _, options := range [][]ExportOption{nil, {WithTypes()}, {OnlyFields("age")}, {Compact()}, {Compact(), WithTypes()}} {
+		var buf bytes.Buffer
+		assert.Nil(t, rules.WriteJSON(&buf, options...), "Written")
+		j, _ := rules.Export(options...)
+		assert.Equal(t, string(j), buf.String(), "Same as Export")
+	}
+	var buf bytes.Buffer
+	assert.Nil(t, New(&s).WriteJSON(&buf), "No rules")
+	assert.Equal(t, "{}", buf.String(), "No rules")
+	j, _ := rules.Export(Compact())
+	assert.NotContains(t, string(j), "\n", "Compact")
+
+	errs := rules.Validate(writerType{Name: "toolong", Tags: []string{"c"}}).(ErrorSlice)
+	for _, e := range []ErrorSlice{errs, {}, nil} {
+		buf.Reset()
+		assert.Nil(t, e.WriteJSON(&buf), "Errors written")
+		j, _ := json.Marshal(e)
+		assert.Equal(t, string(j), buf.String(), "Same as MarshalJSON")
+	}
+}
@@ -1,11 +1,16 @@
 package xvalid
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/unicode/norm"
 )
 
 func TestRequired(t *testing.T) {
@@ -60,9 +65,73 @@ func TestRequired(t *testing.T) {
 
 	msg := "custom message"
 	assert.Equal(t, msg, New(&r).Field(&r.Number, Required().SetMessage(msg)).
-		Validate(requiredType{})[0].Error(), "Custom error message")
+		Validate(requiredType{}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&r).Field(&r.Number, Required()).
-		Validate(requiredType{})[0].Error(), "Default error message")
+		Validate(requiredType{}).(ErrorSlice)[0].Error(), "Default error message")
+}
+
+func TestRequiredPointerElements(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	type listType struct {
+		Items []*item
+	}
+	l := listType{}
+	rules := New(&l).Field(&l.Items, Required())
+	assert.Len(t, rules.Validate(listType{Items: []*item{}}), 1, "Empty slice")
+	assert.Len(t, rules.Validate(listType{Items: []*item{{Name: "a"}, nil}}), 1, "Nil element")
+	assert.Nil(t, rules.Validate(listType{Items: []*item{{Name: "a"}, {}}}), "All elements set")
+}
+
+func TestRequiredZeroTime(t *testing.T) {
+	type timeType struct {
+		At  time.Time  `json:"at"`
+		Ptr *time.Time `json:"ptr"`
+	}
+	tt := timeType{}
+	var decoded timeType
+	assert.Nil(t, json.Unmarshal([]byte(`{"at":"0001-01-01T00:00:00+00:00","ptr":"0001-01-01T00:00:00Z"}`), &decoded), "Decode zero times")
+	now := time.Now()
+
+	rules := New(&tt).Field(&tt.At, Required()).Field(&tt.Ptr, Required())
+	assert.Len(t, rules.Validate(timeType{}), 2, "Exact zero is unset")
+	assert.Len(t, rules.Validate(decoded), 1, "Zero instant with location is set")
+	assert.Nil(t, rules.Validate(timeType{At: now, Ptr: &now}), "Non zero time")
+
+	rules = New(&tt).Field(&tt.At, Required().ZeroTime(ZeroTimeInstant)).Field(&tt.Ptr, Required().ZeroTime(ZeroTimeInstant))
+	assert.Len(t, rules.Validate(decoded), 2, "Zero instant is unset")
+
+	rules = New(&tt).Field(&tt.At, Required().ZeroTime(ZeroTimeSet)).Field(&tt.Ptr, Required().ZeroTime(ZeroTimeSet))
+	assert.Nil(t, rules.Validate(decoded), "Zero times are set")
+	assert.Len(t, rules.Validate(timeType{}), 1, "Nil pointer is still unset")
+}
+
+func TestItems(t *testing.T) {
+	type itemsType struct {
+		Slice []string       `json:"slice"`
+		Array [3]int         `json:"array"`
+		Map   map[string]int `json:"map"`
+		Ptrs  [2]*int        `json:"ptrs"`
+	}
+	i := itemsType{}
+	rules := New(&i).
+		Field(&i.Slice, MinItems(1), MaxItems(2)).
+		Field(&i.Array, Required(), MinItems(3), MaxItems(3)).
+		Field(&i.Map, MaxItems(1)).
+		Field(&i.Ptrs, Required())
+	one := 1
+	errs := rules.Validate(itemsType{Map: map[string]int{"a": 1, "b": 2}}).(ErrorSlice)
+	assert.Len(t, errs, 4, "Empty collections")
+	assert.Equal(t, "Please add at least 1 items to slice", errs[0].Error(), "Nil slice has no items")
+	assert.Equal(t, []string{"array"}, errs[1].Field(), "Zero array is not set")
+	assert.Equal(t, "Please remove items from map to have 1 or less", errs[2].Error(), "Too many map items")
+	assert.Equal(t, []string{"ptrs"}, errs[3].Field(), "Nil array elements")
+	assert.Nil(t, rules.Validate(itemsType{Slice: []string{"a"}, Array: [3]int{0, 1}, Ptrs: [2]*int{&one, &one}}), "Valid")
+	assert.Len(t, rules.Validate(itemsType{Slice: []string{"a", "b", "c"}, Array: [3]int{1}, Ptrs: [2]*int{&one, &one}}), 1, "Too many slice items")
+
+	j, _ := json.Marshal(New(&i).Field(&i.Slice, MinItems(1), MaxItems(2)))
+	assert.Equal(t, `{"slice":[{"rule":"minItems","min":1},{"rule":"maxItems","max":2}]}`, string(j), "Export")
 }
 
 func TestMinLength(t *testing.T) {
@@ -77,9 +146,9 @@ func TestMinLength(t *testing.T) {
 	assert.Len(t, rules.Validate(strType{Field: "£"}), 1, "Multi-byte characters too short")
 	msg := "custom message"
 	assert.Equal(t, msg, New(&str).Field(&str.Field, MinLength(2).SetMessage(msg)).
-		Validate(strType{Field: "1"})[0].Error(), "Custom error message")
+		Validate(strType{Field: "1"}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&str).Field(&str.Field, MinLength(2)).
-		Validate(strType{Field: "1"})[0].Error(), "Default error message")
+		Validate(strType{Field: "1"}).(ErrorSlice)[0].Error(), "Default error message")
 	// optional
 	rules = New(&str).Field(&str.Field, MinLength(3).SetOptional())
 	assert.Nil(t, rules.Validate(strType{Field: ""}), "Invalid but zero")
@@ -87,6 +156,19 @@ func TestMinLength(t *testing.T) {
 	assert.Nil(t, rules.Validate(strType{Field: "123"}), "Valid and not zero")
 }
 
+func TestTrimmedLength(t *testing.T) {
+	type trimType struct {
+		Name string `json:"name"`
+	}
+	tt := trimType{}
+	rules := New(&tt).Field(&tt.Name, MinLength(4).Trimmed(), MaxLength(5).Trimmed())
+	assert.Len(t, rules.Validate(trimType{Name: " abc "}), 1, "White space doesn't count toward min")
+	assert.Nil(t, rules.Validate(trimType{Name: "  abcde  "}), "White space doesn't count toward max")
+	assert.Nil(t, New(&tt).Field(&tt.Name, MinLength(4)).Validate(trimType{Name: " abc "}), "Counted by default")
+	j, _ := json.Marshal(MinLength(4).Trimmed())
+	assert.Equal(t, `{"rule":"minLength","min":4,"trimmed":true}`, string(j), "Export trimmed")
+}
+
 func TestMaxLength(t *testing.T) {
 	type strType struct {
 		Field string
@@ -99,9 +181,31 @@ func TestMaxLength(t *testing.T) {
 	assert.Nil(t, rules.Validate(strType{Field: "世界"}), "Multi-byte characters are short enough")
 	msg := "custom message"
 	assert.Equal(t, msg, New(&str).Field(&str.Field, MaxLength(0).SetMessage(msg)).
-		Validate(strType{Field: "1"})[0].Error(), "Custom error message")
+		Validate(strType{Field: "1"}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&str).Field(&str.Field, MaxLength(0)).
-		Validate(strType{Field: "1"})[0].Error(), "Default error message")
+		Validate(strType{Field: "1"}).(ErrorSlice)[0].Error(), "Default error message")
+}
+
+func TestBytes(t *testing.T) {
+	type strType struct {
+		Field string
+	}
+	str := strType{}
+	rules := New(&str).Field(&str.Field, MaxBytes(4))
+	assert.Nil(t, rules.Validate(strType{Field: "1234"}), "Exactly hit max")
+	assert.Len(t, rules.Validate(strType{Field: "世界"}), 1, "Multi-byte characters are too long")
+	rules = New(&str).Field(&str.Field, MinBytes(2))
+	assert.Nil(t, rules.Validate(strType{Field: "£"}), "Multi-byte characters are long enough")
+	assert.Len(t, rules.Validate(strType{Field: "1"}), 1, "Too short")
+	msg := "custom message"
+	assert.Equal(t, msg, New(&str).Field(&str.Field, MaxBytes(0).SetMessage(msg)).
+		Validate(strType{Field: "1"}).(ErrorSlice)[0].Error(), "Custom error message")
+	assert.Equal(t, msg, New(&str).Field(&str.Field, MinBytes(2).SetMessage(msg)).
+		Validate(strType{Field: "1"}).(ErrorSlice)[0].Error(), "Custom error message")
+	// optional
+	rules = New(&str).Field(&str.Field, MinBytes(3).SetOptional())
+	assert.Nil(t, rules.Validate(strType{Field: ""}), "Invalid but zero")
+	assert.Len(t, rules.Validate(strType{Field: " "}), 1, "Invalid and not zero")
 }
 
 func TestMinInt(t *testing.T) {
@@ -116,9 +220,9 @@ func TestMinInt(t *testing.T) {
 	assert.Len(t, rules.Validate(intType{Int: -1}), 1, "Too low")
 	msg := "custom message"
 	assert.Equal(t, msg, New(&i).Field(&i.Int, Min(0).SetMessage(msg)).
-		Validate(intType{Int: -1})[0].Error(), "Custom error message")
+		Validate(intType{Int: -1}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&i).Field(&i.Int, Min(0)).
-		Validate(intType{Int: -1})[0].Error(), "Default error message")
+		Validate(intType{Int: -1}).(ErrorSlice)[0].Error(), "Default error message")
 	// optional
 	rules = New(&i).Field(&i.Int, Min(5).SetOptional())
 	assert.Nil(t, rules.Validate(intType{Int: 0}), "Invalid but zero")
@@ -131,9 +235,9 @@ func TestMinInt(t *testing.T) {
 	assert.Nil(t, rules.Validate(intType{Float: 0}), "Exactly hit min")
 	assert.Len(t, rules.Validate(intType{Float: -1}), 1, "Too low")
 	assert.Equal(t, msg, New(&i).Field(&i.Float, Min(0).SetMessage(msg)).
-		Validate(intType{Float: -1})[0].Error(), "Custom error message")
+		Validate(intType{Float: -1}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&i).Field(&i.Float, Min(0)).
-		Validate(intType{Float: -1})[0].Error(), "Default error message")
+		Validate(intType{Float: -1}).(ErrorSlice)[0].Error(), "Default error message")
 	// optional
 	rules = New(&i).Field(&i.Float, Min(5).SetOptional())
 	assert.Nil(t, rules.Validate(intType{Float: 0}), "Invalid but zero")
@@ -153,9 +257,9 @@ func TestMaxInt(t *testing.T) {
 	assert.Nil(t, rules.Validate(intType{Field: -1}), "Low engouh")
 	msg := "custom message"
 	assert.Equal(t, msg, New(&i).Field(&i.Field, Max(0).SetMessage(msg)).
-		Validate(intType{Field: 1})[0].Error(), "Custom error message")
+		Validate(intType{Field: 1}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&i).Field(&i.Field, Max(0)).
-		Validate(intType{Field: 1})[0].Error(), "Default error message")
+		Validate(intType{Field: 1}).(ErrorSlice)[0].Error(), "Default error message")
 
 	// float
 	rules = New(&i).Field(&i.Float, Max(0))
@@ -163,9 +267,51 @@ func TestMaxInt(t *testing.T) {
 	assert.Nil(t, rules.Validate(intType{Float: 0}), "Exactly hit max")
 	assert.Nil(t, rules.Validate(intType{Float: -1}), "Low engouh")
 	assert.Equal(t, msg, New(&i).Field(&i.Float, Max(0).SetMessage(msg)).
-		Validate(intType{Float: 1})[0].Error(), "Custom error message")
+		Validate(intType{Float: 1}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&i).Field(&i.Float, Max(0)).
-		Validate(intType{Float: 1})[0].Error(), "Default error message")
+		Validate(intType{Float: 1}).(ErrorSlice)[0].Error(), "Default error message")
+}
+
+func TestDuration(t *testing.T) {
+	type durationType struct {
+		Timeout time.Duration `json:"timeout"`
+	}
+	d := durationType{}
+	rules := New(&d).Field(&d.Timeout, MinDuration(5*time.Minute), MaxDuration(time.Hour+30*time.Minute))
+	errs := rules.Validate(durationType{Timeout: time.Minute}).(ErrorSlice)
+	assert.Equal(t, "Please increase timeout to be at least 5m", errs[0].Error(), "Humane min message")
+	errs = rules.Validate(durationType{Timeout: 2 * time.Hour}).(ErrorSlice)
+	assert.Equal(t, "Please decrease timeout to be at most 1h30m", errs[0].Error(), "Humane max message")
+	assert.Nil(t, rules.Validate(durationType{Timeout: time.Hour}), "Within range")
+	errs = New(&d).Field(&d.Timeout, Max(int64(time.Second))).Validate(durationType{Timeout: time.Minute}).(ErrorSlice)
+	assert.Equal(t, "Please decrease timeout to be at most 1s", errs[0].Error(), "Duration value detected")
+	j, _ := json.Marshal(MinDuration(90 * time.Second))
+	assert.Equal(t, `{"rule":"min","min":90000000000,"duration":"1m30s"}`, string(j), "Export duration")
+}
+
+func TestExclusive(t *testing.T) {
+	type exclusiveType struct {
+		Price float64       `json:"price"`
+		Count int           `json:"count"`
+		Delay time.Duration `json:"delay"`
+	}
+	e := exclusiveType{}
+	rules := New(&e).
+		Field(&e.Price, Min(0).Exclusive()).
+		Field(&e.Count, Max(10).Exclusive()).
+		Field(&e.Delay, MaxDuration(time.Minute).Exclusive())
+	assert.Nil(t, rules.Validate(exclusiveType{Price: 0.01, Count: 9, Delay: time.Second}), "Within bounds")
+	errs := rules.Validate(exclusiveType{Price: 0, Count: 10, Delay: time.Minute}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Bounds are excluded")
+	assert.Equal(t, "Please increase price to be more than 0", errs[0].Error(), "Exclusive min message")
+	assert.Equal(t, "Please decrease count to be less than 10", errs[1].Error(), "Exclusive max message")
+	assert.Equal(t, "Please decrease delay to be less than 1m", errs[2].Error(), "Exclusive duration message")
+	assert.Nil(t, New(&e).Field(&e.Price, Min(0).Exclusive().SetOptional()).Validate(exclusiveType{}), "Optional zero")
+
+	j, _ := json.Marshal(Min(0).Exclusive())
+	assert.Equal(t, `{"rule":"min","min":0,"exclusive":true}`, string(j), "Export exclusive")
+	j, _ = json.Marshal(Max(5))
+	assert.Equal(t, `{"rule":"max","max":5}`, string(j), "Inclusive by default")
 }
 
 func TestPattern(t *testing.T) {
@@ -179,9 +325,9 @@ func TestPattern(t *testing.T) {
 	assert.Len(t, rules.Validate(patternType{Field: "wrong"}), 1, "Pattern is wrong")
 	msg := "custom message"
 	assert.Equal(t, msg, New(&p).Field(&p.Field, Pattern(`\d{2}`).SetMessage(msg)).
-		Validate(patternType{Field: "message"})[0].Error(), "Custom error message")
+		Validate(patternType{Field: "message"}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&p).Field(&p.Field, Pattern(`\d{2}`)).
-		Validate(patternType{Field: "message"})[0].Error(), "Default error message")
+		Validate(patternType{Field: "message"}).(ErrorSlice)[0].Error(), "Default error message")
 	// optional
 	rules = New(&p).Field(&p.Field, Pattern(`\w{3,}`).SetOptional())
 	assert.Nil(t, rules.Validate(patternType{Field: ""}), "Invalid but zero")
@@ -189,6 +335,22 @@ func TestPattern(t *testing.T) {
 	assert.Nil(t, rules.Validate(patternType{Field: "123"}), "Valid and not zero")
 }
 
+func TestNormalized(t *testing.T) {
+	type userType struct {
+		Username string `json:"username"`
+	}
+	u := userType{}
+	composed, decomposed := "caf\u00e9", "cafe\u0301"
+	rules := New(&u).Field(&u.Username, NFC())
+	assert.Nil(t, rules.Validate(userType{Username: composed}), "Composed is NFC")
+	assert.Len(t, rules.Validate(userType{Username: decomposed}), 1, "Decomposed is not NFC")
+	rules = New(&u).Field(&u.Username, Normalized(norm.NFD))
+	assert.Nil(t, rules.Validate(userType{Username: decomposed}), "Decomposed is NFD")
+	assert.Len(t, rules.Validate(userType{Username: composed}), 1, "Composed is not NFD")
+	j, _ := json.Marshal(Normalized(norm.NFKC))
+	assert.Equal(t, `{"rule":"normalized","form":"NFKC"}`, string(j), "Export form")
+}
+
 func TestEmail(t *testing.T) {
 	type emailType struct {
 		Field string
@@ -199,9 +361,9 @@ func TestEmail(t *testing.T) {
 	assert.Nil(t, rules.Validate(emailType{Field: "test@mail.com"}), "Valid email address")
 	msg := "custom message"
 	assert.Equal(t, msg, New(&p).Field(&p.Field, Email().SetMessage(msg)).
-		Validate(emailType{Field: "invalid"})[0].Error(), "Custom error message")
+		Validate(emailType{Field: "invalid"}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&p).Field(&p.Field, Email()).
-		Validate(emailType{Field: "invalid"})[0].Error(), "Default error message")
+		Validate(emailType{Field: "invalid"}).(ErrorSlice)[0].Error(), "Default error message")
 	// optional
 	rules = New(&p).Field(&p.Field, Email().SetOptional())
 	assert.Nil(t, rules.Validate(emailType{Field: ""}), "Invalid but zero")
@@ -209,6 +371,31 @@ func TestEmail(t *testing.T) {
 	assert.Nil(t, rules.Validate(emailType{Field: "test@mail.com"}), "Valid and not zero")
 }
 
+func TestEmailMode(t *testing.T) {
+	type emailType struct {
+		Email string `json:"email"`
+	}
+	e := emailType{}
+	regex := New(&e).Field(&e.Email, Email())
+	strict := New(&e).Field(&e.Email, Email().Mode(EmailRFC5322))
+	idn := New(&e).Field(&e.Email, Email().Mode(EmailIDN))
+
+	assert.Nil(t, regex.Validate(emailType{Email: "a..b@mail.com"}), "Regex allows consecutive dots")
+	assert.Len(t, strict.Validate(emailType{Email: "a..b@mail.com"}), 1, "RFC 5322 rejects consecutive dots")
+	assert.Len(t, regex.Validate(emailType{Email: `"john doe"@mail.com`}), 1, "Regex rejects quoted local part")
+	assert.Nil(t, strict.Validate(emailType{Email: `"john doe"@mail.com`}), "RFC 5322 allows quoted local part")
+	assert.Len(t, strict.Validate(emailType{Email: "John <john@mail.com>"}), 1, "RFC 5322 rejects display name")
+
+	assert.Len(t, regex.Validate(emailType{Email: "user@bücher.de"}), 1, "Regex rejects IDN")
+	assert.Nil(t, idn.Validate(emailType{Email: "user@bücher.de"}), "IDN domain")
+	assert.Nil(t, idn.Validate(emailType{Email: "user@xn--bcher-kva.de"}), "Punycode domain")
+	assert.Len(t, idn.Validate(emailType{Email: "user@bü cher.de"}), 1, "Invalid IDN domain")
+	assert.Len(t, idn.Validate(emailType{Email: "user"}), 1, "Missing domain")
+
+	j, _ := json.Marshal(Email().Mode(EmailIDN))
+	assert.Contains(t, string(j), `"mode":"idn"`, "Export mode")
+}
+
 func TestOptions(t *testing.T) {
 	type optionsType struct {
 		Str string
@@ -221,9 +408,9 @@ func TestOptions(t *testing.T) {
 	assert.Nil(t, rules.Validate(optionsType{Str: "b"}), "Valid option")
 	msg := "custom message"
 	assert.Equal(t, msg, New(&o).Field(&o.Str, Options().SetMessage(msg)).
-		Validate(optionsType{Str: "invalid"})[0].Error(), "Custom error message")
+		Validate(optionsType{Str: "invalid"}).(ErrorSlice)[0].Error(), "Custom error message")
 	assert.NotEqual(t, msg, New(&o).Field(&o.Str, Options()).
-		Validate(optionsType{Str: "invalid"})[0].Error(), "Default error message")
+		Validate(optionsType{Str: "invalid"}).(ErrorSlice)[0].Error(), "Default error message")
 	// int
 	rules = New(&o).Field(&o.Int, Options(1, 2, 3))
 	assert.Len(t, rules.Validate(optionsType{Int: 5}), 1, "Not in options")
@@ -232,6 +419,189 @@ func TestOptions(t *testing.T) {
 	rules = New(&o).Field(&o.Int, Options("a", 5, make([]byte, 0)))
 	assert.Len(t, rules.Validate(optionsType{Int: -1}), 1, "Not in options")
 	assert.Nil(t, rules.Validate(optionsType{Int: 5}), "Valid option")
+	// custom equality
+	rules = New(&o).Field(&o.Str, Options("a", "b").EqualFunc(func(a, b any) bool {
+		return strings.EqualFold(a.(string), b.(string))
+	}))
+	assert.Nil(t, rules.Validate(optionsType{Str: "B"}), "Valid option with custom equality")
+	assert.Len(t, rules.Validate(optionsType{Str: "x"}), 1, "Not in options with custom equality")
+	// case insensitive
+	rules = New(&o).Field(&o.Str, Options("a", "b").CaseInsensitive())
+	assert.Nil(t, rules.Validate(optionsType{Str: "A"}), "Valid option ignoring case")
+	assert.Len(t, rules.Validate(optionsType{Str: "x"}), 1, "Not in options ignoring case")
+	j, _ := json.Marshal(rules)
+	assert.Equal(t, `{"Str":[{"rule":"options","options":["a","b"],"caseInsensitive":true}]}`, string(j), "Export case insensitive")
+	type status string
+	assert.Nil(t, Options(status("active")).CaseInsensitive().Validate(status("ACTIVE")), "Named string type ignoring case")
+	assert.Nil(t, NotOptions("admin").CaseInsensitive().Validate(status("user")), "Named string type not denied")
+	assert.NotNil(t, NotOptions("admin").CaseInsensitive().Validate(status("Admin")), "Named string type denied")
+	assert.Panics(t, func() { Options("a").CaseInsensitive().EqualFunc(func(a, b any) bool { return true }) }, "EqualFunc with CaseInsensitive")
+	assert.Panics(t, func() { Options("a").EqualFunc(func(a, b any) bool { return true }).CaseInsensitive() }, "CaseInsensitive with EqualFunc")
+}
+
+type weekday int
+
+func (d weekday) String() string {
+	return [...]string{"sun", "mon", "tue"}[d]
+}
+
+func TestNotOptions(t *testing.T) {
+	type userType struct {
+		Name string `json:"name"`
+	}
+	u := userType{}
+	rules := New(&u).Field(&u.Name, NotOptions("admin", "root"))
+	assert.Nil(t, rules.Validate(userType{Name: "alice"}), "Allowed")
+	errs := rules.Validate(userType{Name: "root"}).(ErrorSlice)
+	assert.Equal(t, "Please choose a different name", errs[0].Error(), "Blacklisted")
+	assert.Nil(t, rules.Validate(userType{Name: "Admin"}), "Case sensitive by default")
+	rules = New(&u).Field(&u.Name, NotOptions("admin").CaseInsensitive())
+	assert.Len(t, rules.Validate(userType{Name: "ADMIN"}), 1, "Case insensitive")
+	j, _ := json.Marshal(NotOptions("admin").CaseInsensitive())
+	assert.Equal(t, `{"rule":"notOptions","options":["admin"],"caseInsensitive":true}`, string(j), "Export")
+}
+
+func TestOptionsSource(t *testing.T) {
+	type optionsType struct {
+		Str string
+	}
+	o := optionsType{}
+	// map
+	rules := New(&o).Field(&o.Str, OptionsFromMap(map[string]int{"b": 2, "a": 1}))
+	assert.Nil(t, rules.Validate(optionsType{Str: "a"}), "Valid map key")
+	assert.Len(t, rules.Validate(optionsType{Str: "c"}), 1, "Not a map key")
+	j, _ := json.Marshal(rules)
+	assert.Equal(t, `{"Str":[{"rule":"options","options":["a","b"]}]}`, string(j), "Sorted map keys")
+	// stringers
+	rules = New(&o).Field(&o.Str, OptionsFromStringers([]weekday{0, 1}))
+	assert.Nil(t, rules.Validate(optionsType{Str: "mon"}), "Valid stringer")
+	assert.Len(t, rules.Validate(optionsType{Str: "tue"}), 1, "Not a stringer")
+	// lazy
+	options := []any{"a"}
+	rules = New(&o).Field(&o.Str, OptionsFunc(func() []any { return options }))
+	assert.Len(t, rules.Validate(optionsType{Str: "b"}), 1, "Not in options yet")
+	options = append(options, "b")
+	assert.Nil(t, rules.Validate(optionsType{Str: "b"}), "Options are refreshed")
+	j, _ = json.Marshal(rules)
+	assert.Equal(t, `{"Str":[{"rule":"options","options":["a","b"]}]}`, string(j), "Export refreshed options")
+}
+
+func TestOptionsOf(t *testing.T) {
+	type optionsType struct {
+		Str   string
+		Int   int
+		Int64 int64
+	}
+	o := optionsType{}
+	rules := New(&o).Field(&o.Str, OptionsOf("a", "b", "c"))
+	assert.Len(t, rules.Validate(optionsType{Str: "x"}), 1, "Not in options")
+	assert.Nil(t, rules.Validate(optionsType{Str: "b"}), "Valid option")
+	rules = New(&o).Field(&o.Int, OptionsOf(1, 2, 3))
+	assert.Len(t, rules.Validate(optionsType{Int: 5}), 1, "Not in options")
+	assert.Nil(t, rules.Validate(optionsType{Int: 1}), "Valid option")
+	rules = New(&o).Field(&o.Int64, OptionsOf(1, 2, 3))
+	assert.Nil(t, rules.Validate(optionsType{Int64: 1}), "Integer of another size")
+	assert.Len(t, rules.Validate(optionsType{Int64: 5}), 1, "Integer of another size not in options")
+	assert.NotNil(t, OptionsOf[int8](1).Validate(int64(257)), "Integer overflow")
+	assert.NotNil(t, OptionsOf[uint](1).Validate(-1), "Negative unsigned")
+	assert.Nil(t, OptionsOf[uint](1).Validate(1), "Signed to unsigned")
+	type status string
+	assert.Nil(t, OptionsOf("active").Validate(status("active")), "Named string type")
+	assert.NotNil(t, OptionsOf("1").Validate(1), "Different kind")
+	rules = New(&o).Field(&o.Int64, OptionsOf[int64](1, 2, 3))
+	assert.Nil(t, rules.Validate(optionsType{Int64: 1}), "Same type")
+	msg := "custom message"
+	assert.Equal(t, msg, New(&o).Field(&o.Str, OptionsOf("a").SetMessage(msg)).
+		Validate(optionsType{Str: "invalid"}).(ErrorSlice)[0].Error(), "Custom error message")
+	j, _ := json.Marshal(New(&o).Field(&o.Int, OptionsOf(1, 2)))
+	assert.Equal(t, `{"Int":[{"rule":"options","options":[1,2]}]}`, string(j), "Export options")
+}
+
+func TestIf(t *testing.T) {
+	type address struct {
+		Country string `json:"country"`
+		Zip     string `json:"zip"`
+	}
+	a := address{}
+	rules := New(&a).Field(&a.Zip, If(&a.Country, Options("US")).
+		Then(Required(), Pattern(`^\d{5}$`)).
+		Else(MaxLength(10)))
+	assert.Nil(t, rules.Validate(address{Country: "US", Zip: "12345"}), "Then passes")
+	assert.Len(t, rules.Validate(address{Country: "US"}), 2, "Then fails")
+	assert.Nil(t, rules.Validate(address{Country: "SG"}), "Else passes")
+	assert.Len(t, rules.Validate(address{Country: "SG", Zip: "12345678901"}), 1, "Else fails")
+	errs := rules.Validate(address{Country: "US"}).(ErrorSlice)
+	assert.Equal(t, []string{"zip"}, errs[0].Field(), "Error on validated field")
+	j, _ := json.Marshal(rules)
+	assert.Equal(t,
+		`{"zip":[{"rule":"if","field":"country","condition":{"rule":"options","options":["US"]},"then":[{"rule":"required"},{"rule":"pattern","pattern":"^\\d{5}$"}],"else":[{"rule":"maxLength","max":10}]}]}`,
+		string(j), "Export condition")
+	// same field
+	rules = New(&a).Field(&a.Zip, If(nil, MinLength(1)).Then(Pattern(`^\d+$`)))
+	assert.Nil(t, rules.Validate(address{}), "Condition fails")
+	assert.Len(t, rules.Validate(address{Zip: "x"}), 1, "Condition passes")
+}
+
+func TestIfCtx(t *testing.T) {
+	type roleKey struct{}
+	isAdmin := func(ctx context.Context) bool { return ctx.Value(roleKey{}) == "admin" }
+	admin := context.WithValue(context.Background(), roleKey{}, "admin")
+	type post struct {
+		Reason string `json:"reason"`
+		Title  string `json:"title"`
+	}
+	p := post{}
+	rules := New(&p).
+		Field(&p.Reason, RequiredIfCtx(isAdmin)).
+		Field(&p.Title, IfCtx(isAdmin).Then(MaxLength(100)).Else(MaxLength(5)))
+	assert.Nil(t, rules.ValidateCtx(context.Background(), post{Title: "short"}), "Not admin")
+	assert.Len(t, rules.ValidateCtx(context.Background(), post{Title: "not short"}), 1, "Else branch")
+	errs := rules.ValidateCtx(admin, post{Title: "not short"}).(ErrorSlice)
+	assert.Len(t, errs, 1, "Then branch")
+	assert.Equal(t, []string{"reason"}, errs[0].Field(), "Required for admins")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Rule of the branch")
+	assert.Nil(t, rules.Validate(post{}), "Background context without ValidateCtx")
+	assert.False(t, RequiredIfCtx(isAdmin).CanExport(), "Not exportable")
+
+	// nested in other conditions
+	rules = New(&p).Field(&p.Title, If(&p.Reason, Required()).Then(RequiredIfCtx(isAdmin)))
+	assert.Nil(t, rules.ValidateCtx(admin, post{}), "Outer condition fails")
+	assert.Len(t, rules.ValidateCtx(admin, post{Reason: "x"}), 1, "Outer condition passes")
+}
+
+func TestWhen(t *testing.T) {
+	type address struct {
+		Street string `json:"street"`
+	}
+	type order struct {
+		DeliveryMethod string  `json:"deliveryMethod"`
+		Shipping       address `json:"shipping"`
+		Note           string  `json:"note"`
+	}
+	ship := func(subject any) bool { return subject.(order).DeliveryMethod == "ship" }
+	o := order{}
+	rules := New(&o).
+		Field(&o.Shipping.Street, When(ship, Required(), MinLength(5))).
+		Field(&o.Note, Unless(ship, MaxLength(3)))
+	assert.Nil(t, rules.Validate(order{DeliveryMethod: "pickup"}), "Condition false")
+	errs := rules.Validate(order{DeliveryMethod: "ship"}).(ErrorSlice)
+	assert.Len(t, errs, 2, "Condition true")
+	assert.Equal(t, []string{"shipping", "street"}, errs[0].Field(), "Nested field path")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Rule of the validator")
+	assert.Nil(t, rules.Validate(order{DeliveryMethod: "ship", Shipping: address{"1 Main St"}, Note: "long note"}), "Unless")
+	assert.Len(t, rules.Validate(order{DeliveryMethod: "pickup", Note: "long note"}), 1, "Unless applies")
+	assert.Len(t, rules.Explain(order{DeliveryMethod: "ship"})[0].Errors, 2, "Explain")
+	assert.False(t, When(ship).CanExport(), "Not exportable")
+
+	// nested in other conditions
+	rules = New(&o).Field(&o.Note, If(&o.Shipping.Street, Required()).Then(When(ship, Required())))
+	assert.Nil(t, rules.Validate(order{DeliveryMethod: "ship"}), "Outer condition fails")
+	assert.Len(t, rules.Validate(order{DeliveryMethod: "ship", Shipping: address{"x"}}), 1, "Outer condition passes")
+
+	// held by validators that only pass the value
+	rules = New(&o).Field(&o.Note, ForRoles([]string{"admin"}, When(ship, MaxLength(3))))
+	assert.Nil(t, rules.Validate(order{DeliveryMethod: "pickup", Note: "long note"}), "Subject in wrapper")
+	assert.Len(t, rules.Validate(order{DeliveryMethod: "ship", Note: "long note"}), 1, "Subject in wrapper applies")
 }
 
 func TestFieldFunc(t *testing.T) {
@@ -250,6 +620,66 @@ func TestFieldFunc(t *testing.T) {
 	assert.Len(t, rules.Validate(funcTest{Field: "invalid"}), 1, "Invalid")
 }
 
+func TestFieldFuncCtx(t *testing.T) {
+	type tenantKey struct{}
+	type account struct {
+		Email string `json:"email"`
+	}
+	taken := map[string]string{"acme": "a@acme.com"}
+	unique := FieldFuncCtx(func(ctx context.Context, field []string, value any) Error {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		if taken[tenant] == value {
+			return NewError("Please use another email", field...)
+		}
+		return nil
+	})
+	a := account{}
+	rules := New(&a).Field(&a.Email, unique)
+	acme := context.WithValue(context.Background(), tenantKey{}, "acme")
+	assert.Len(t, rules.ValidateCtx(acme, account{Email: "a@acme.com"}), 1, "Request scoped value")
+	assert.Nil(t, rules.Validate(account{Email: "a@acme.com"}), "Background context")
+
+	var deadline bool
+	wait := FieldFuncCtx(func(ctx context.Context, field []string, value any) Error {
+		_, deadline = ctx.Deadline()
+		return nil
+	})
+	assert.Nil(t, New(&a).Field(&a.Email, wait).Timeout(time.Second).ValidateCtx(context.Background(), a), "Timeout")
+	assert.True(t, deadline, "Deadline of timeout passed on")
+
+	calls := 0
+	cached := Cached(FieldFuncCtx(func(ctx context.Context, field []string, value any) Error {
+		calls++
+		return unique.(ValidatorCtx).ValidateCtx(ctx, value)
+	}), time.Minute)
+	rules = New(&a).Field(&a.Email, cached)
+	assert.Len(t, rules.ValidateCtx(acme, account{Email: "a@acme.com"}), 1, "Context through cache")
+	assert.Len(t, rules.ValidateCtx(acme, account{Email: "a@acme.com"}), 1, "Cached")
+	assert.Equal(t, 1, calls, "Called once")
+
+	// wrappers pass the context on to the validators they hold
+	type order struct {
+		Email string   `json:"email"`
+		Tags  []string `json:"tags"`
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	stopped := FieldFuncCtx(func(ctx context.Context, field []string, value any) Error {
+		if ctx.Err() != nil {
+			return NewError("Cancelled", field...)
+		}
+		return nil
+	})
+	o := order{}
+	rules = New(&o).
+		Field(&o.Email, If(nil, Required()).Then(stopped), When(func(any) bool { return true }, stopped), Bundle(stopped)).
+		Field(&o.Email, ForRoles([]string{"admin"}, stopped)).
+		Field(&o.Tags, Each(stopped))
+	subject := order{Email: "a@b.com", Tags: []string{"a"}}
+	assert.Nil(t, rules.Validate(subject), "Background context")
+	assert.Len(t, rules.ValidateCtx(cancelled, subject), 5, "Cancelled context")
+}
+
 func TestStructFunc(t *testing.T) {
 	type funcTest struct {
 		A int
@@ -265,7 +695,7 @@ func TestStructFunc(t *testing.T) {
 	p := funcTest{}
 	rules := New(&p).Struct(StructFunc(checker))
 	assert.Nil(t, rules.Validate(funcTest{A: 3, B: 10}), "Valid")
-	errs := rules.Validate(funcTest{A: 3, B: 1})
+	errs := rules.Validate(funcTest{A: 3, B: 1}).(ErrorSlice)
 	assert.Len(t, errs, 1, "Invalid")
 	assert.Equal(t, errs[0].Error(), "custom error", "Error message")
 }
@@ -293,6 +723,258 @@ func TestEmbeded(t *testing.T) {
 	assert.Nil(t, rules.Validate(nestedType{Top: "abc", Embed: Embed{EmbedStr: "x", EmbedFloat: 3, Deep: Deep{5}}}), "All pass")
 }
 
+func TestNested(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	type Customer struct {
+		Name    string   `json:"name"`
+		Billing *Address `json:"billing"`
+	}
+	type order struct {
+		ID       string `json:"id"`
+		Address  Address
+		Customer Customer `json:"customer"`
+	}
+	o := order{Customer: Customer{Billing: &Address{}}}
+	rules := New(&o).
+		Field(&o.Address, Required()).
+		Field(&o.Address.City, Required()).
+		Field(&o.Address.Zip, MaxLength(5)).
+		Field(&o.Customer.Name, MinLength(2)).
+		Field(&o.Customer.Billing.City, Required())
+	errs := rules.Validate(order{}).(ErrorSlice)
+	assert.Len(t, errs, 4, "Nested fields validated")
+	assert.Equal(t, []string{"Address"}, errs[0].Field(), "Struct field itself")
+	assert.Equal(t, []string{"Address", "city"}, errs[1].Field(), "First field of nested struct")
+	assert.Equal(t, []string{"customer", "name"}, errs[2].Field(), "Nested struct path")
+	assert.Equal(t, []string{"customer", "billing", "city"}, errs[3].Field(), "Nil pointer along the path")
+	assert.Nil(t, rules.Validate(order{
+		Address:  Address{City: "Paris", Zip: "75001"},
+		Customer: Customer{Name: "Jo", Billing: &Address{City: "Paris"}},
+	}), "All pass")
+	j, _ := json.Marshal(New(&o).Field(&o.Address.City, Required()))
+	assert.Equal(t, `{"city":[{"rule":"required"}]}`, string(j), "Export uses last field name")
+}
+
+type Comment struct {
+	Text string `json:"text"`
+	*Comment
+}
+
+func TestSelfReference(t *testing.T) {
+	c := Comment{}
+	rules := New(&c).Field(&c.Text, Required())
+	loop := &Comment{Text: "loop"}
+	loop.Comment = loop
+	assert.Nil(t, rules.Validate(Comment{Text: "top", Comment: loop}), "Cycle terminates")
+	assert.Nil(t, rules.Validate(&Comment{Text: "top"}), "Nil embedded pointer")
+	assert.Len(t, rules.Validate(Comment{}), 1, "Top level still validated")
+
+	parent := &Comment{Text: "parent"}
+	c = Comment{Comment: parent}
+	rules = New(&c).Field(&c.Comment.Text, MinLength(3))
+	assert.Nil(t, rules.Validate(Comment{Text: "x", Comment: &Comment{Text: "abc"}}), "Embedded pointer field")
+	assert.Len(t, rules.Validate(Comment{Text: "abc", Comment: &Comment{Text: "x"}}), 1, "Embedded pointer field invalid")
+	assert.Len(t, rules.Validate(Comment{Text: "abc"}), 1, "Nil embedded pointer field")
+}
+
+func TestComplexity(t *testing.T) {
+	type child struct {
+		Tags []string `json:"tags"`
+	}
+	type parent struct {
+		Name     string  `json:"name"`
+		Children []child `json:"children"`
+		Data     []byte  `json:"data"`
+	}
+	p := parent{}
+	rules := New(&p).Field(&p.Name, Required())
+	subject := parent{Children: []child{{Tags: []string{"a", "b", "c"}}}, Data: make([]byte, 100)}
+	assert.Len(t, rules.Validate(subject), 1, "No limits")
+	// depth
+	errs := rules.MaxDepth(2).Validate(subject)
+	assert.Len(t, errs, 1, "Too deep")
+	assert.ErrorIs(t, errs, ErrTooComplex, "Too complex error")
+	assert.Equal(t, []string{"children", "tags"}, errs.(ErrorSlice)[0].Field(), "Field that is too deep")
+	assert.NotErrorIs(t, rules.MaxDepth(3).Validate(subject), ErrTooComplex, "Deep enough")
+	// elements
+	errs = rules.MaxElements(2).Validate(subject)
+	assert.ErrorIs(t, errs, ErrTooComplex, "Too many elements")
+	assert.NotErrorIs(t, rules.MaxElements(3).Validate(subject), ErrTooComplex, "Few enough elements")
+	// cycle
+	loop := &Comment{Text: "loop"}
+	loop.Comment = loop
+	c := Comment{}
+	assert.Nil(t, New(&c).MaxElements(1).Validate(loop), "Cycle terminates")
+}
+
+func TestTimeout(t *testing.T) {
+	type slowType struct {
+		Name string `json:"name"`
+	}
+	slow := FieldFunc(func(field []string, value any) Error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	s := slowType{}
+	rules := New(&s).Field(&s.Name, Required(), slow)
+	assert.Nil(t, rules.ValidateCtx(context.Background(), slowType{Name: "ok"}), "No limit")
+	errs := rules.Timeout(time.Millisecond).ValidateCtx(context.Background(), slowType{Name: "ok"})
+	assert.Len(t, errs, 1, "Validator timed out")
+	assert.ErrorIs(t, errs, ErrTimeout, "Timeout error")
+	assert.Equal(t, []string{"name"}, errs.(ErrorSlice)[0].Field(), "Timeout field")
+	j, _ := json.Marshal(errs)
+	assert.Equal(t, `[{"message":"Please try again, name took too long to validate","field":"name","code":"timeout"}]`, string(j), "Timeout code")
+	var parsed ErrorSlice
+	assert.Nil(t, json.Unmarshal(j, &parsed), "Parsed")
+	assert.ErrorIs(t, parsed, ErrTimeout, "Parsed timeout")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, rules.ValidateCtx(ctx, slowType{Name: "ok"}), ErrTimeout, "Context deadline")
+	assert.Len(t, rules.Timeout(time.Second).ValidateCtx(context.Background(), slowType{}), 1, "Fast enough")
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	assert.Nil(t, rules.ValidateCtx(ctx, slowType{Name: "ok"}), "No deadline left to the validator")
+}
+
+func TestPanic(t *testing.T) {
+	type panicType struct {
+		Name string  `json:"name"`
+		Ptr  *string `json:"ptr"`
+	}
+	p := panicType{}
+	cause := errors.New("cause")
+	rules := New(&p).
+		Field(&p.Name, FieldFunc(func(field []string, value any) Error {
+			panic(cause)
+		})).
+		Field(&p.Ptr, Min(1)).
+		Struct(StructFunc(func(value any) Error {
+			panic("struct")
+		}))
+	errs := rules.Validate(panicType{})
+	assert.Len(t, errs, 3, "All panics recovered")
+	assert.ErrorIs(t, errs, ErrInternal, "Internal error")
+	assert.ErrorIs(t, errs, cause, "Panic value")
+	assert.Equal(t, []string{"name"}, errs.(ErrorSlice)[0].Field(), "Field of custom validator")
+	assert.Equal(t, []string{"ptr"}, errs.(ErrorSlice)[1].Field(), "Field of type mismatch")
+	assert.Equal(t, "Something went wrong while validating the data", errs.(ErrorSlice)[2].Error(), "Struct validator")
+	assert.ErrorIs(t, rules.Timeout(time.Second).Validate(panicType{}), ErrInternal, "Recovered with timeout")
+}
+
+func TestMetrics(t *testing.T) {
+	type metricsType struct {
+		Name string `json:"name"`
+	}
+	m := metricsType{}
+	type evaluation struct {
+		rule   string
+		field  []string
+		failed bool
+	}
+	evaluations := make([]evaluation, 0)
+	metrics := MetricsFunc(func(rule string, field []string, failed bool, duration time.Duration) {
+		evaluations = append(evaluations, evaluation{rule, field, failed})
+	})
+	rules := New(&m).Field(&m.Name, Required(), MinLength(3), OptionsOf("abc")).Metrics(metrics)
+	rules.Validate(metricsType{Name: "ab"})
+	assert.Equal(t, []evaluation{
+		{"required", []string{"name"}, false},
+		{"minLength", []string{"name"}, true},
+		{"optionsOf", []string{"name"}, true},
+	}, evaluations, "Every rule evaluated")
+}
+
+type testTracer struct {
+	spans []string
+}
+
+func (t *testTracer) Start(ctx context.Context, structType string, rules int) (context.Context, func(errors int)) {
+	return ctx, func(errors int) {
+		t.spans = append(t.spans, fmt.Sprintf("%s %d %d", structType, rules, errors))
+	}
+}
+
+func TestTracer(t *testing.T) {
+	type traceType struct {
+		Name string `json:"name"`
+	}
+	tt := traceType{}
+	tracer := &testTracer{}
+	rules := New(&tt).Field(&tt.Name, Required(), MinLength(3)).Tracer(tracer)
+	rules.Validate(traceType{})
+	rules.ValidateCtx(context.Background(), traceType{Name: "abc"})
+	assert.Equal(t, []string{"xvalid.traceType 2 2", "xvalid.traceType 2 0"}, tracer.spans, "Span per validation")
+}
+
+func TestValidateJSON(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	u := user{}
+	rules := New(&u).Field(&u.Name, Required())
+	assert.Nil(t, rules.ValidateJSON([]byte(`{"name":"Jo"}`)), "Valid")
+	errs := rules.ValidateJSON([]byte(`{}`)).(ErrorSlice)
+	assert.Equal(t, []string{"name"}, errs[0].Field(), "Invalid")
+	_, ok := rules.ValidateJSON([]byte(`{`)).(ErrorSlice)
+	assert.False(t, ok, "Decode error")
+}
+
+func TestValidateMap(t *testing.T) {
+	type reading struct {
+		Device string   `json:"device"`
+		Value  float64  `json:"value"`
+		Tags   []string `json:"tags"`
+		Meta   struct {
+			Unit string `json:"unit"`
+		} `json:"meta"`
+	}
+	r := reading{}
+	rules := New(&r).Field(&r.Device, Required()).Field(&r.Value, Max(100)).Field(&r.Meta.Unit, Options("C", "F"))
+	assert.Nil(t, rules.ValidateMap(map[any]any{"device": "a1", "value": uint64(20), "tags": []any{"x"},
+		"meta": map[any]any{"unit": "C"}}), "Valid map[any]any")
+	errs := rules.ValidateMap(map[string]any{"value": 120.5, "meta": map[any]any{"unit": "K"}}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Invalid map[string]any")
+	_, ok := rules.ValidateMap(map[any]any{"device": 1}).(ErrorSlice)
+	assert.False(t, ok, "Conversion error")
+}
+
+func TestExplain(t *testing.T) {
+	type Embed struct {
+		Inner string `json:"inner"`
+	}
+	type explainType struct {
+		Name string `json:"name"`
+		Embed
+	}
+	e := explainType{}
+	rules := New(&e).
+		Field(&e.Name, Required(), MinLength(3)).
+		Field(&e.Inner, Required()).
+		Struct(StructFunc(func(value any) Error { return nil }))
+	explanations := rules.Explain(explainType{Name: "ab", Embed: Embed{Inner: "x"}})
+	assert.Len(t, explanations, 4, "Every validator explained")
+	assert.Equal(t, Explanation{Rule: "required", Field: []string{"name"}, Value: "ab", Found: true, Evaluated: true},
+		explanations[0], "Passed")
+	assert.Equal(t, "minLength", explanations[1].Rule, "Rule name")
+	assert.Len(t, explanations[1].Errors, 1, "Failed")
+	assert.Equal(t, []string{"Embed", "inner"}, explanations[2].Field, "Embedded field")
+	assert.Equal(t, "x", explanations[2].Value, "Embedded value")
+	assert.True(t, explanations[3].Evaluated, "Struct validator")
+	assert.Empty(t, explanations[3].Field, "Struct validator has no field")
+
+	// validator bound to a field that doesn't exist in the subject
+	type otherType struct {
+		Other string
+	}
+	explanations = rules.Explain(otherType{})
+	assert.False(t, explanations[0].Found, "Field not found")
+	assert.Nil(t, explanations[0].Value, "Validated with nil")
+}
+
 func TestMarshalJSON(t *testing.T) {
 	type Embed struct {
 		EmbedStr string `json:"embedStr"`
@@ -310,10 +992,10 @@ func TestMarshalJSON(t *testing.T) {
 		Field(&e.EmbedStr, Required())
 	j, _ := json.Marshal(rules)
 	assert.Equal(t,
-		`{"Str":[{"rule":"required"},{"rule":"maxLength","max":5}],"embedStr":[{"rule":"required"}],"number":[{"rule":"min","min":10,"message":"my message"}]}`,
+		`{"embedStr":[{"rule":"required"}],"Str":[{"rule":"required"},{"rule":"maxLength","max":5}],"number":[{"rule":"min","min":10,"message":"my message","optional":true}]}`,
 		string(j), "Export rules to json")
 	// json errors
-	errs := rules.Validate(e)
+	errs := rules.Validate(e).(ErrorSlice)
 	j, _ = json.Marshal(errs)
 	assert.Equal(t,
 		`[{"message":"Please enter the Str","field":"Str"},{"message":"Please enter the embedStr","field":"embedStr"}]`,
@@ -325,3 +1007,144 @@ func TestMarshalJSON(t *testing.T) {
 		`{"Str":"Please enter the Str","embedStr":"Please enter the embedStr"}`,
 		string(j), "Export errors json as map")
 }
+
+func TestToNestedMap(t *testing.T) {
+	type Deep struct {
+		DeepInt int `json:"deepInt"`
+	}
+	type Other struct {
+		DeepInt int `json:"deepInt"`
+	}
+	type nestedType struct {
+		Deep
+		Other `json:"other"`
+		Name  string `json:"name"`
+	}
+	n := nestedType{}
+	errs := New(&n).
+		Field(&n.Deep.DeepInt, Min(1)).
+		Field(&n.Other.DeepInt, Min(2)).
+		Field(&n.Name, Required()).
+		Struct(StructFunc(func(any) Error { return NewError("Please check the data") })).
+		Validate(n).(ErrorSlice)
+	assert.Len(t, errs.ToMap(), 3, "Flat map collides")
+
+	nested := errs.ToNestedMap()
+	assert.Equal(t, errs[0], nested["Deep"].(NestedErrorMap)["deepInt"], "Embedded field")
+	assert.Equal(t, errs[1], nested["other"].(NestedErrorMap)["deepInt"], "Same field name of another struct")
+	assert.Equal(t, errs[2], nested["name"], "Top level field")
+	assert.Equal(t, errs[3], nested[""], "Struct error")
+	j, _ := json.Marshal(nested)
+	assert.JSONEq(t,
+		`{"Deep":{"deepInt":"Please increase deepInt to be 1 or more"},"other":{"deepInt":"Please increase deepInt to be 2 or more"},"name":"Please enter the name","":"Please check the data"}`,
+		string(j), "Nested JSON")
+
+	nested = ErrorSlice{NewError("Please check the address", "address"), NewError("Please enter the city", "address", "city")}.ToNestedMap()
+	assert.Equal(t, "Please enter the city", nested["address"].(NestedErrorMap)["city"].(Error).Error(), "Nested field kept")
+	assert.Equal(t, "Please check the address", nested["address"].(NestedErrorMap)["_error"].(Error).Error(), "Parent error kept")
+	nested = ErrorSlice{NewError("Please enter the city", "address", "city"), NewError("Please check the address", "address")}.ToNestedMap()
+	assert.Equal(t, "Please enter the city", nested["address"].(NestedErrorMap)["city"].(Error).Error(), "Nested field not replaced")
+	assert.Equal(t, "Please check the address", nested["address"].(NestedErrorMap)["_error"].(Error).Error(), "Parent error added")
+	j, _ = json.Marshal(nested)
+	assert.JSONEq(t, `{"address":{"_error":"Please check the address","city":"Please enter the city"}}`, string(j), "Parent error JSON")
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var errs ErrorSlice
+	err := json.Unmarshal([]byte(`[{"message":"Please enter the name","field":"items[2].name","rule":"required"},{"message":"Too short","field":"password"}]`), &errs)
+	assert.Nil(t, err, "Parsed")
+	assert.Len(t, errs, 2, "All errors")
+	assert.Equal(t, "Please enter the name", errs[0].Error(), "Message")
+	assert.Equal(t, []string{"items", "2", "name"}, errs[0].Field(), "Indexed field")
+	assert.Equal(t, "required", errs[0].(interface{ Rule() string }).Rule(), "Rule")
+	assert.Equal(t, []string{"password"}, errs[1].Field(), "Field")
+	assert.Equal(t, "", errs[1].(interface{ Rule() string }).Rule(), "No rule")
+
+	type roundTrip struct {
+		Name string `json:"name"`
+	}
+	r := roundTrip{}
+	original := New(&r).Field(&r.Name, Required()).Validate(r).(ErrorSlice)
+	j, _ := json.Marshal(original)
+	assert.Equal(t, `[{"message":"Please enter the name","field":"name"}]`, string(j), "Rule left out")
+	defer Configure(CurrentConfig())
+	Configure(Config{ErrorRules: true})
+	j, _ = json.Marshal(original)
+	var parsed ErrorSlice
+	assert.Nil(t, json.Unmarshal(j, &parsed), "Round trip parsed")
+	assert.Equal(t, original, parsed, "Round trip")
+
+	var errsMap ErrorMap
+	assert.Nil(t, json.Unmarshal([]byte(`{"[0].name":"Please enter the name"}`), &errsMap), "Map parsed")
+	assert.Equal(t, []string{"0", "name"}, errsMap["[0].name"].Field(), "Map field")
+	assert.Equal(t, "Please enter the name", errsMap["[0].name"].Error(), "Map message")
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"message":"x"}`), &errs), "Not a list")
+}
+
+func TestExportOrder(t *testing.T) {
+	type form struct {
+		Zip     string `json:"zip"`
+		Address string `json:"address"`
+		Name    string `json:"name"`
+	}
+	f := form{}
+	rules := New(&f).
+		Field(&f.Name, Required()).
+		Field(&f.Zip, Required()).
+		Field(&f.Address, Required()).
+		Field(&f.Name, MaxLength(5))
+	for i := 0; i < 10; i++ {
+		j, _ := json.Marshal(rules)
+		assert.Equal(t, `{"zip":[{"rule":"required"}],"address":[{"rule":"required"}],"name":[{"rule":"required"},{"rule":"maxLength","max":5}]}`,
+			string(j), "Fields in declaration order")
+	}
+	j, _ := rules.Export(WithTypes())
+	assert.Regexp(t, `(?s)"zip".*"address".*"name"`, string(j), "Typed export in declaration order")
+}
+
+func TestLabel(t *testing.T) {
+	type labelType struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	l := labelType{}
+	// validator label
+	errs := New(&l).Field(&l.Name, Required().SetLabel("Full name")).Validate(labelType{}).(ErrorSlice)
+	assert.Equal(t, "Please enter the Full name", errs[0].Error(), "Label in message")
+	assert.Equal(t, []string{"name"}, errs[0].Field(), "Field name unchanged")
+	// rules label before and after the field
+	rules := New(&l).
+		Label(&l.Name, "Full name").
+		Field(&l.Name, Required()).
+		Field(&l.Email, Required()).
+		Label(&l.Email, "Email address")
+	errs = rules.Validate(labelType{}).(ErrorSlice)
+	assert.Equal(t, "Please enter the Full name", errs[0].Error(), "Label set before field")
+	assert.Equal(t, "Please enter the Email address", errs[1].Error(), "Label set after field")
+	j, _ := json.Marshal(rules)
+	assert.Equal(t,
+		`{"name":[{"rule":"required","label":"Full name"}],"email":[{"rule":"required","label":"Email address"}]}`,
+		string(j), "Export labels")
+	j, _ = json.Marshal(errs.ToMap())
+	assert.Equal(t,
+		`{"email":"Please enter the Email address","name":"Please enter the Full name"}`,
+		string(j), "Error keys use field names")
+
+	type address struct {
+		City string `json:"city"`
+	}
+	type order struct {
+		Billing  address `json:"billing"`
+		Shipping address `json:"shipping"`
+	}
+	o := order{}
+	errs = New(&o).
+		Label(&o.Billing.City, "Billing city").
+		Field(&o.Billing.City, Required()).
+		Field(&o.Shipping.City, Required()).
+		Label(&o.Shipping.City, "Shipping city").
+		Validate(order{}).(ErrorSlice)
+	assert.Equal(t, "Please enter the Billing city", errs[0].Error(), "Nested label")
+	assert.Equal(t, "Please enter the Shipping city", errs[1].Error(), "Nested field with the same name")
+}
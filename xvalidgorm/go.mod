@@ -0,0 +1,22 @@
+module github.com/AgentCosmic/xvalid/v2/xvalidgorm
+
+go 1.22
+
+require (
+	github.com/AgentCosmic/xvalid/v2 v2.0.0
+	github.com/stretchr/testify v1.9.0
+	gorm.io/gorm v1.25.10
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/exp v0.0.0-20240404231335-c0f41cb1a7a0 // indirect
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/AgentCosmic/xvalid/v2 => ../
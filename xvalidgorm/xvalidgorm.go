@@ -0,0 +1,94 @@
+/*
+Package xvalidgorm validates models with xvalid rules before GORM creates or updates them, so invalid rows never reach
+the database, even when they don't come through an HTTP handler.
+
+	plugin := xvalidgorm.New()
+	xvalidgorm.Register[User](plugin, user.Rules())
+	db.Use(plugin)
+
+A model that fails validation aborts the statement, and the transaction it is in, with the xvalid.ErrorSlice as the
+error. Use errors.As to get it.
+
+The package is a module of its own, so GORM is only a dependency of projects that use it:
+
+	go get github.com/AgentCosmic/xvalid/v2/xvalidgorm
+*/
+package xvalidgorm
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"gorm.io/gorm"
+)
+
+// Plugin is a GORM plugin that validates registered models
+type Plugin struct {
+	mutex sync.RWMutex
+	rules map[reflect.Type]xvalid.Rules
+}
+
+// New plugin without any models
+func New() *Plugin {
+	return &Plugin{
+		rules: make(map[reflect.Type]xvalid.Rules),
+	}
+}
+
+// Register the rules of the model type T. Models without rules are saved without validation.
+func Register[T any](p *Plugin, rules xvalid.Rules) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.rules[reflect.TypeOf((*T)(nil)).Elem()] = rules
+}
+
+// Name of the plugin
+func (p *Plugin) Name() string {
+	return "xvalid"
+}
+
+// Initialize registers the callbacks that run before creating and updating
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("xvalid:validate", p.validate); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("xvalid:validate", p.validate)
+}
+
+// validate the models of the statement. Updates of single columns or maps are skipped as they don't hold the model.
+func (p *Plugin) validate(tx *gorm.DB) {
+	if tx.Error != nil || reflect.Indirect(reflect.ValueOf(tx.Statement.Dest)).Kind() == reflect.Map {
+		return
+	}
+	v := tx.Statement.ReflectValue
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !p.validateModel(tx, v.Index(i)) {
+				return
+			}
+		}
+	case reflect.Struct:
+		p.validateModel(tx, v)
+	}
+}
+
+// validateModel adds the errors of the model to the statement and returns false if it is invalid
+func (p *Plugin) validateModel(tx *gorm.DB, v reflect.Value) bool {
+	v = reflect.Indirect(v)
+	if !v.IsValid() {
+		return true
+	}
+	p.mutex.RLock()
+	rules, ok := p.rules[v.Type()]
+	p.mutex.RUnlock()
+	if !ok {
+		return true
+	}
+	if err := rules.ValidateCtx(tx.Statement.Context, v.Interface()); err != nil {
+		tx.AddError(err)
+		return false
+	}
+	return true
+}
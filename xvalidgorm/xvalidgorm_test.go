@@ -0,0 +1,44 @@
+package xvalidgorm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+type user struct {
+	ID   uint
+	Name string `json:"name"`
+}
+
+func (u *user) Rules() xvalid.Rules {
+	return xvalid.New(u).Field(&u.Name, xvalid.Required(), xvalid.MaxLength(5))
+}
+
+func TestPlugin(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	assert.Nil(t, err, "Open")
+	plugin := New()
+	Register[user](plugin, (&user{}).Rules())
+	assert.Nil(t, db.Use(plugin), "Use plugin")
+
+	err = db.Create(&user{Name: "toolong"}).Error
+	var errs xvalid.ErrorSlice
+	assert.True(t, errors.As(err, &errs), "Validation error")
+	assert.Equal(t, []string{"name"}, errs[0].Field(), "Error field")
+	assert.Nil(t, db.Create(&user{Name: "ok"}).Error, "Valid create")
+
+	assert.NotNil(t, db.Create(&[]user{{Name: "ok"}, {}}).Error, "Batch create")
+	assert.NotNil(t, db.Save(&user{ID: 1}).Error, "Save")
+	assert.Nil(t, db.Model(&user{ID: 1}).Update("name", "").Error, "Column update skipped")
+
+	type other struct {
+		ID   uint
+		Name string
+	}
+	assert.Nil(t, db.Create(&other{}).Error, "Model without rules")
+}
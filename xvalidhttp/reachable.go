@@ -0,0 +1,156 @@
+package xvalidhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/AgentCosmic/xvalid/v2"
+)
+
+// ReachableValidator field must be a URL that responds to a HEAD request
+type ReachableValidator struct {
+	field       []string
+	message     string
+	label       string
+	denyPrivate bool
+	client      *http.Client
+	isPrivate   func(ctx context.Context, host string) bool
+}
+
+// Field of the field
+func (c *ReachableValidator) Field() []string {
+	return c.field
+}
+
+// SetField of the field
+func (c *ReachableValidator) SetField(name ...string) {
+	c.field = name
+}
+
+// SetMessage set error message
+func (c *ReachableValidator) SetMessage(msg string) xvalid.Validator {
+	c.message = msg
+	return c
+}
+
+// SetLabel set the label used in error messages
+func (c *ReachableValidator) SetLabel(label string) xvalid.Validator {
+	c.label = label
+	return c
+}
+
+// DenyPrivateHosts refuses requests to private addresses like xvalid.URL().DenyPrivateHosts() does, including
+// redirects. The address that is actually dialed is checked, so a host can't resolve to a private address after
+// passing validation.
+func (c *ReachableValidator) DenyPrivateHosts() *ReachableValidator {
+	c.denyPrivate = true
+	return c
+}
+
+// reachableTimeout limits requests when the context has no earlier deadline
+const reachableTimeout = 10 * time.Second
+
+// Validate the value with a background context
+func (c *ReachableValidator) Validate(value any) xvalid.Error {
+	return c.ValidateCtx(context.Background(), value)
+}
+
+// ValidateCtx validates the value, cancelling the request when the context is done. Empty strings pass, use Required
+// to require one.
+func (c *ReachableValidator) ValidateCtx(ctx context.Context, value any) xvalid.Error {
+	str, ok := value.(string)
+	if ok && str == "" {
+		return nil
+	}
+	u, err := url.Parse(str)
+	if !ok || err != nil || u.Scheme == "" || u.Host == "" {
+		return createError(c.field, c.message, fmt.Sprintf("Please use a valid URL for %s", fieldLabel(c.field, c.label)))
+	}
+	ctx, cancel := context.WithTimeout(ctx, reachableTimeout)
+	defer cancel()
+	if c.denyPrivate && c.isPrivate(ctx, u.Hostname()) {
+		return createError(c.field, c.message, fmt.Sprintf("Please use a public URL for %s", fieldLabel(c.field, c.label)))
+	}
+	if !c.isReachable(ctx, str) {
+		return createError(c.field, c.message, fmt.Sprintf("Please use a URL that can be reached for %s", fieldLabel(c.field, c.label)))
+	}
+	return nil
+}
+
+var errPrivateHost = errors.New("dial to a private address")
+
+// control refuses connections to private addresses when they are denied. It runs after the host is resolved for
+// dialing, so a host that resolves to a public address during validation and a private one afterwards is refused.
+func (c *ReachableValidator) control(network string, address string, conn syscall.RawConn) error {
+	if !c.denyPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || xvalid.IsPrivateIP(ip) {
+		return errPrivateHost
+	}
+	return nil
+}
+
+var errPrivateRedirect = errors.New("redirect to a private host")
+
+func (c *ReachableValidator) isReachable(ctx context.Context, rawURL string) bool {
+	client := *c.client
+	if client.Transport == nil {
+		client.Transport = &http.Transport{
+			// a proxy would dial the host instead, without the check of control
+			Proxy:       nil,
+			DialContext: (&net.Dialer{Control: c.control}).DialContext,
+		}
+	}
+	if c.denyPrivate {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if c.isPrivate(req.Context(), req.URL.Hostname()) {
+				return errPrivateRedirect
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return false
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	return res.StatusCode < 400 || res.StatusCode == http.StatusMethodNotAllowed
+}
+
+// CanExport for this validator. Clients can't check reachability the same way.
+func (c *ReachableValidator) CanExport() bool {
+	return false
+}
+
+// Params of this validator
+func (c *ReachableValidator) Params() map[string]any {
+	return map[string]any{"denyPrivateHosts": c.denyPrivate}
+}
+
+// Reachable field must be a URL that responds to a HEAD request with a status below 400, or 405 since some servers
+// don't support HEAD. The request is bound by the context given to ValidateCtx and limited to 10 seconds. Combine
+// with xvalid.URL to check the scheme.
+func Reachable() *ReachableValidator {
+	return &ReachableValidator{
+		client:    &http.Client{},
+		isPrivate: xvalid.IsPrivateHost,
+	}
+}
@@ -0,0 +1,73 @@
+package xvalidhttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method, "HEAD request")
+		switch r.URL.Path {
+		case "/ok":
+		case "/no-head":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/redirect-private":
+			http.Redirect(w, r, "http://127.0.0.1/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	type hookType struct {
+		Webhook string `json:"webhook"`
+	}
+	h := hookType{}
+	rules := xvalid.New(&h).Field(&h.Webhook, xvalid.URL(), Reachable())
+	assert.Nil(t, rules.Validate(hookType{Webhook: server.URL + "/ok"}), "Reachable")
+	assert.Nil(t, rules.Validate(hookType{Webhook: server.URL + "/no-head"}), "HEAD not allowed")
+	assert.Nil(t, rules.Validate(hookType{Webhook: server.URL + "/redirect"}), "Redirect followed")
+	errs := rules.Validate(hookType{Webhook: server.URL + "/missing"}).(xvalid.ErrorSlice)
+	assert.Equal(t, "Please use a URL that can be reached for webhook", errs[0].Error(), "Not found")
+	assert.Equal(t, "reachable", errs[0].(interface{ Rule() string }).Rule(), "Rule name")
+	assert.Nil(t, Reachable().Validate(""), "Empty")
+	assert.False(t, Reachable().CanExport(), "Not exportable")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NotNil(t, Reachable().ValidateCtx(ctx, server.URL+"/ok"), "Cancelled context")
+
+	// the test server is on a loopback address, so dial it through a host that resolves to a public address
+	v := Reachable().DenyPrivateHosts()
+	v.isPrivate = func(ctx context.Context, host string) bool {
+		return host != "public.example.com"
+	}
+	v.client = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return net.Dial(network, server.Listener.Addr().String())
+		},
+	}}
+	rules = xvalid.New(&h).Field(&h.Webhook, v)
+	assert.Nil(t, rules.Validate(hookType{Webhook: "http://public.example.com/ok"}), "Public host")
+	assert.Nil(t, rules.Validate(hookType{Webhook: "http://public.example.com/redirect"}), "Redirect to public host")
+	assert.Len(t, rules.Validate(hookType{Webhook: "http://public.example.com/redirect-private"}), 1, "Redirect to private host")
+	errs = rules.Validate(hookType{Webhook: "http://127.0.0.1/ok"}).(xvalid.ErrorSlice)
+	assert.Equal(t, "Please use a public URL for webhook", errs[0].Error(), "Private host")
+
+	// the host resolves to a public address when validated and to the loopback test server when dialed
+	v.client = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return (&net.Dialer{Control: v.control}).DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}}
+	assert.Len(t, rules.Validate(hookType{Webhook: "http://public.example.com/ok"}), 1, "Rebinding to private address")
+}
@@ -0,0 +1,48 @@
+package xvalidhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/AgentCosmic/xvalid/v2"
+)
+
+// httpChecker posts {"value": value} as JSON to the URL. A 2xx status accepts the value, a 4xx status rejects it, and
+// any other status is an error.
+type httpChecker struct {
+	url    string
+	client *http.Client
+}
+
+func (h httpChecker) Check(ctx context.Context, value any) (bool, error) {
+	body, err := json.Marshal(map[string]any{"value": value})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := h.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return true, nil
+	case res.StatusCode >= 400 && res.StatusCode < 500:
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected status from %s: %d", h.url, res.StatusCode)
+}
+
+// Checker posts {"value": value} as JSON to the URL with the client. A 2xx status accepts the value, a 4xx status
+// rejects it, and any other status is an error. Remote validators without a checker use it with http.DefaultClient.
+func Checker(url string, client *http.Client) xvalid.RemoteChecker {
+	return httpChecker{url, client}
+}
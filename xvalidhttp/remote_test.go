@@ -0,0 +1,56 @@
+package xvalidhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"), "JSON body")
+		var body struct{ Value string }
+		json.NewDecoder(r.Body).Decode(&body)
+		switch body.Value {
+		case "DE123":
+			w.WriteHeader(http.StatusOK)
+		case "slow":
+			// wait for the client to give up
+			<-r.Context().Done()
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	}))
+	defer server.Close()
+
+	type company struct {
+		VAT string `json:"vat"`
+	}
+	c := company{}
+	rules := xvalid.New(&c).Field(&c.VAT, xvalid.Remote(server.URL, xvalid.RemoteOptions{Timeout: 20 * time.Millisecond}))
+	assert.Nil(t, rules.Validate(company{VAT: "DE123"}), "Accepted by default checker")
+	assert.Len(t, rules.Validate(company{VAT: "XX"}), 1, "Rejected")
+	errs := rules.Validate(company{VAT: "broken"}).(xvalid.ErrorSlice)
+	assert.True(t, errors.Is(errs[0], xvalid.ErrInternal), "Service error")
+	errs = rules.Validate(company{VAT: "slow"}).(xvalid.ErrorSlice)
+	assert.True(t, errors.Is(errs[0], xvalid.ErrInternal), "Timeout")
+	assert.Equal(t, int32(4), calls.Load(), "Posted to the URL")
+
+	loaded, err := xvalid.New(&c).Load([]byte(`{"vat":[{"rule":"remote","url":"` + server.URL + `"}]}`))
+	assert.Nil(t, err, "Load")
+	assert.Len(t, loaded.Validate(company{VAT: "XX"}), 1, "Loaded rule posts to the URL")
+
+	opts := xvalid.RemoteOptions{Checker: Checker(server.URL, server.Client())}
+	assert.Nil(t, xvalid.New(&c).Field(&c.VAT, xvalid.Remote("", opts)).Validate(company{VAT: "DE123"}), "Client")
+}
@@ -0,0 +1,53 @@
+/*
+Package xvalidhttp provides the validators that need net/http, so the xvalid package stays free of it and small when
+built for WebAssembly. Importing it also lets the content validators of xvalid read uploaded files and sniff content
+types, and remote validators without a checker post the value to their URL:
+
+	import _ "github.com/AgentCosmic/xvalid/v2/xvalidhttp"
+*/
+package xvalidhttp
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/AgentCosmic/xvalid/v2"
+)
+
+func init() {
+	xvalid.RegisterContent(openFile)
+	xvalid.RegisterSniffer(http.DetectContentType)
+	xvalid.RegisterRemote(func(url string) xvalid.RemoteChecker {
+		return Checker(url, http.DefaultClient)
+	})
+}
+
+// openFile opens uploaded files, given as *multipart.FileHeader
+func openFile(value any) (io.ReadCloser, bool) {
+	f, ok := value.(*multipart.FileHeader)
+	if !ok || f == nil {
+		return nil, false
+	}
+	r, err := f.Open()
+	if err != nil {
+		return nil, false
+	}
+	return r, true
+}
+
+// fieldLabel returns the label, or the last field name if there isn't one
+func fieldLabel(field []string, label string) string {
+	if label != "" || len(field) == 0 {
+		return label
+	}
+	return field[len(field)-1]
+}
+
+// createError with the custom message if there is one
+func createError(field []string, custom string, fallback string) xvalid.Error {
+	if custom != "" {
+		return xvalid.NewError(custom, field...)
+	}
+	return xvalid.NewError(fallback, field...)
+}
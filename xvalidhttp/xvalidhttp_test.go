@@ -0,0 +1,34 @@
+package xvalidhttp
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"testing"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploads(t *testing.T) {
+	var img bytes.Buffer
+	assert.Nil(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, _ := w.CreateFormFile("avatar", "avatar.png")
+	part.Write(img.Bytes())
+	w.Close()
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	assert.Nil(t, err)
+
+	type upload struct {
+		Avatar *multipart.FileHeader `json:"avatar"`
+	}
+	u := upload{}
+	rules := xvalid.New(&u).Field(&u.Avatar, xvalid.Image(), xvalid.ContentType("image/png"), xvalid.MaxSize(1<<10))
+	assert.Nil(t, rules.Validate(upload{Avatar: form.File["avatar"][0]}), "File header")
+	assert.Nil(t, rules.Validate(upload{}), "No upload")
+	rules = xvalid.New(&u).Field(&u.Avatar, xvalid.ContentType("application/pdf"), xvalid.MaxSize(10))
+	assert.Len(t, rules.Validate(upload{Avatar: form.File["avatar"][0]}), 2, "Sniffed and measured")
+}
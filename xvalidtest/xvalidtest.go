@@ -0,0 +1,101 @@
+/*
+Package xvalidtest provides assertions for testing xvalid rules without matching error messages.
+*/
+package xvalidtest
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/AgentCosmic/xvalid/v2"
+)
+
+// T is the part of testing.TB used by the assertions, so they can be checked with a fake
+type T interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertValid fails the test if the subject doesn't pass the rules
+func AssertValid(t T, rules xvalid.Rules, subject any) bool {
+	t.Helper()
+	if err := rules.Validate(subject); err != nil {
+		t.Errorf("expected subject to be valid, got: %v", err)
+		return false
+	}
+	return true
+}
+
+// AssertInvalid fails the test if the subject passes the rules
+func AssertInvalid(t T, rules xvalid.Rules, subject any) bool {
+	t.Helper()
+	if err := rules.Validate(subject); err == nil {
+		t.Errorf("expected subject to be invalid")
+		return false
+	}
+	return true
+}
+
+// AssertFieldError fails the test if err doesn't contain an error for the field from the rule, e.g. "required" or
+// "minLength". The field is the full path of JSON names, e.g. "email" or "items[3].name", which can also be written as
+// "items.3.name".
+func AssertFieldError(t T, err error, field string, rule string) bool {
+	t.Helper()
+	if findError(err, field, rule) == nil {
+		t.Errorf("expected %s error for %s, got: %v", rule, field, describe(err))
+		return false
+	}
+	return true
+}
+
+// AssertNoFieldError fails the test if err contains any error for the field, which is a path like in AssertFieldError
+func AssertNoFieldError(t T, err error, field string) bool {
+	t.Helper()
+	if e := findError(err, field, ""); e != nil {
+		t.Errorf("expected no error for %s, got: %v", field, e)
+		return false
+	}
+	return true
+}
+
+// indexReplacer writes indexes of a path as parts, e.g. "items.3.name" for "items[3].name"
+var indexReplacer = strings.NewReplacer("[", ".", "]", "")
+
+// findError returns the first error matching the field and rule. An empty rule matches any rule.
+func findError(err error, field string, rule string) xvalid.Error {
+	var errs xvalid.ErrorSlice
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	path := indexReplacer.Replace(field)
+	for _, e := range errs {
+		if strings.Join(e.Field(), ".") != path {
+			continue
+		}
+		if rule == "" || ruleOf(e) == rule {
+			return e
+		}
+	}
+	return nil
+}
+
+// ruleOf returns the rule name of the error if available
+func ruleOf(err xvalid.Error) string {
+	if r, ok := err.(interface{ Rule() string }); ok {
+		return r.Rule()
+	}
+	return ""
+}
+
+// describe lists the field and rule of each error
+func describe(err error) string {
+	var errs xvalid.ErrorSlice
+	if !errors.As(err, &errs) {
+		return "no errors"
+	}
+	list := make([]string, len(errs))
+	for i, e := range errs {
+		list[i] = strings.Join(e.Field(), ".") + ":" + ruleOf(e)
+	}
+	return "[" + strings.Join(list, ", ") + "]"
+}
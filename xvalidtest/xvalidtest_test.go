@@ -0,0 +1,69 @@
+package xvalidtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AgentCosmic/xvalid/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeT records the failures of the assertions under test
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	type user struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	u := user{}
+	rules := xvalid.New(&u).
+		Field(&u.Name, xvalid.MinLength(3)).
+		Field(&u.Email, xvalid.Required(), xvalid.Email())
+
+	assert.True(t, AssertValid(t, rules, user{Name: "abc", Email: "a@b.com"}), "Valid")
+	assert.True(t, AssertInvalid(t, rules, user{}), "Invalid")
+	err := rules.Validate(user{Name: "abc"})
+	assert.True(t, AssertFieldError(t, err, "email", "required"), "Required error")
+	assert.True(t, AssertFieldError(t, err, "email", "email"), "Email error")
+	assert.True(t, AssertNoFieldError(t, err, "name"), "No name error")
+
+	fake := &fakeT{}
+	assert.False(t, AssertFieldError(fake, err, "name", "minLength"), "Missing error")
+	assert.Equal(t, "expected minLength error for name, got: [email:required, email:email]", fake.errors[0], "Missing error message")
+	assert.False(t, AssertNoFieldError(fake, err, "email"), "Unexpected error")
+	assert.False(t, AssertValid(fake, rules, user{}), "Not valid")
+	assert.False(t, AssertInvalid(fake, rules, user{Name: "abc", Email: "a@b.com"}), "Not invalid")
+	assert.Len(t, fake.errors, 4, "Failures reported")
+}
+
+func TestAssertionPaths(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+	type order struct {
+		Owner item   `json:"owner"`
+		Items []item `json:"items"`
+	}
+	i := item{}
+	itemRules := xvalid.New(&i).Field(&i.Name, xvalid.Required())
+	o := order{}
+	rules := xvalid.New(&o).Nested(&o.Owner, itemRules).Field(&o.Items, xvalid.Each(xvalid.Required()))
+	err := rules.Validate(order{Items: []item{{Name: "a"}, {}}})
+
+	assert.True(t, AssertFieldError(t, err, "owner.name", "required"), "Nested path")
+	assert.True(t, AssertFieldError(t, err, "items[1]", "required"), "Indexed path")
+	assert.True(t, AssertFieldError(t, err, "items.1", "required"), "Dotted path")
+	assert.True(t, AssertNoFieldError(t, err, "name"), "Full path needed")
+	assert.True(t, AssertNoFieldError(t, err, "items[0]"), "Other element")
+	fake := &fakeT{}
+	assert.False(t, AssertFieldError(fake, err, "1", "required"), "Last part only")
+}
@@ -0,0 +1,115 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// listParams are wrapped in a list when given a single value, e.g. "phone: MY"
+var listParams = map[string]bool{"options": true, "schemes": true, "regions": true, "types": true}
+
+// commonRuleKeys can be given next to the rule in YAML
+var commonRuleKeys = map[string]bool{"message": true, "label": true, "optional": true}
+
+// LoadYAML is like Load but reads a YAML document of field names to lists of rules, so policy can be maintained
+// outside Go code. A rule is written as its name, as its name with its main parameter, or as its name with a mapping
+// of parameters. Message, label and optional can be given next to it. The exported form with a "rule" key also works.
+//
+//	name:
+//	  - required
+//	  - maxLength: 50
+//	    message: Please keep the name short
+//	age:
+//	  - min: {min: 18, exclusive: true}
+//
+// Rules of other packages are resolved the same way, with the main parameter set by Extension.Param.
+func (r Rules) LoadYAML(data []byte) (Rules, error) {
+	j, err := yamlRules(data)
+	if err != nil {
+		return r, err
+	}
+	return r.Load(j)
+}
+
+// yamlRules converts the YAML rules to the exported JSON
+func yamlRules(data []byte) ([]byte, error) {
+	var doc map[string][]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("empty document")
+	}
+	fields := make(map[string][]map[string]any, len(doc))
+	for name, list := range doc {
+		rules := make([]map[string]any, len(list))
+		for i, item := range list {
+			rule, err := yamlRule(item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			rules[i] = rule
+		}
+		fields[name] = rules
+	}
+	return json.Marshal(fields)
+}
+
+// yamlRule converts a rule written in YAML to the exported form
+func yamlRule(item any) (map[string]any, error) {
+	switch v := item.(type) {
+	case string:
+		return map[string]any{"rule": v}, nil
+	case map[string]any:
+		if _, ok := v["rule"]; ok {
+			return v, nil
+		}
+		rule := make(map[string]any)
+		for key, value := range v {
+			if commonRuleKeys[key] {
+				rule[key] = value
+				continue
+			}
+			if _, ok := rule["rule"]; ok {
+				return nil, fmt.Errorf("more than one rule: %s", key)
+			}
+			ext, ok := lookupExtension(key)
+			if !ok {
+				return nil, fmt.Errorf("rule not supported: %s", key)
+			}
+			rule["rule"] = key
+			if params, ok := value.(map[string]any); ok {
+				for k, p := range params {
+					rule[k] = p
+				}
+			} else if ext.Param != "" {
+				if _, isList := value.([]any); listParams[ext.Param] && !isList {
+					value = []any{value}
+				}
+				rule[ext.Param] = value
+			} else if value != nil && value != true {
+				return nil, fmt.Errorf("%s needs a mapping of parameters", key)
+			}
+		}
+		if _, ok := rule["rule"]; !ok {
+			return nil, errors.New("missing rule")
+		}
+		if list, ok := rule["rules"].([]any); ok {
+			// rules of each, keys and values
+			nested := make([]any, len(list))
+			for i, item := range list {
+				n, err := yamlRule(item)
+				if err != nil {
+					return nil, err
+				}
+				nested[i] = n
+			}
+			rule["rules"] = nested
+		}
+		return rule, nil
+	}
+	return nil, fmt.Errorf("invalid rule: %v", item)
+}
@@ -0,0 +1,67 @@
+package xvalid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadYAML(t *testing.T) {
+	type signup struct {
+		Name    string `json:"name"`
+		Age     int    `json:"age"`
+		Phone   string `json:"phone"`
+		Country string `json:"country"`
+	}
+	s := signup{}
+	rules, err := New(&s).LoadYAML([]byte(`
+name:
+  - required
+  - maxLength: 5
+    message: Please keep the name short
+age:
+  - min: {min: 18, exclusive: true}
+phone:
+  - phone: MY
+    optional: true
+country:
+  - options: [MY, SG]
+`))
+	assert.Nil(t, err, "Load YAML")
+	j, _ := json.Marshal(rules)
+	assert.Equal(t,
+		`{"name":[{"rule":"required"},{"rule":"maxLength","max":5,"message":"Please keep the name short"}],"age":[{"rule":"min","min":18,"exclusive":true}],"phone":[{"rule":"phone","regions":["MY"],"optional":true}],"country":[{"rule":"options","options":["MY","SG"]}]}`,
+		string(j), "Compiled rules")
+	errs := rules.Validate(signup{Name: "toolong", Age: 18, Country: "US"}).(ErrorSlice)
+	assert.Len(t, errs, 3, "Validate compiled rules")
+	assert.Nil(t, rules.Validate(signup{Name: "ok", Age: 19, Country: "SG"}), "Valid")
+
+	rules, err = New(&s).LoadYAML([]byte("name:\n  - rule: maxLength\n    max: 3\n"))
+	assert.Nil(t, err, "Exported form")
+	assert.Len(t, rules.Validate(signup{Name: "four", Age: 19, Country: "SG"}), 1, "Exported form rules")
+
+	type tagged struct {
+		Tags []string `json:"tags"`
+	}
+	tg := tagged{}
+	rules, err = New(&tg).LoadYAML([]byte("tags:\n  - each:\n      - minLength: 2\n      - maxLength: 5\n"))
+	assert.Nil(t, err, "Nested rules")
+	j, _ = json.Marshal(rules)
+	assert.Equal(t, `{"tags":[{"rule":"each","rules":[{"rule":"minLength","min":2},{"rule":"maxLength","max":5}]}]}`,
+		string(j), "Nested rules compiled")
+
+	RegisterRule("yamlCustom", func(data []byte) (Validator, error) { return Required(), nil })
+	_, err = New(&s).LoadYAML([]byte("name:\n  - yamlCustom: 1\n"))
+	assert.EqualError(t, err, "name: yamlCustom needs a mapping of parameters", "Custom rule without mapping")
+	_, err = New(&s).LoadYAML([]byte("name:\n  - yamlCustom\n"))
+	assert.Nil(t, err, "Custom rule by name")
+	_, err = New(&s).LoadYAML([]byte("name:\n  - unknown: 1\n"))
+	assert.EqualError(t, err, "name: rule not supported: unknown", "Unknown rule")
+	_, err = New(&s).LoadYAML([]byte("name:\n  - required: true\n"))
+	assert.Nil(t, err, "Rule without parameters set to true")
+	_, err = New(&s).LoadYAML([]byte("name:\n  - required: true\n    maxLength: 3\n"))
+	assert.NotNil(t, err, "More than one rule")
+	_, err = New(&s).LoadYAML([]byte("name:\n  - message: Missing\n"))
+	assert.EqualError(t, err, "name: missing rule", "Missing rule")
+}